@@ -0,0 +1,373 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	// 导入生成的protobuf代码
+	pb "grpc-basic-client/pb"
+
+	// 导入gRPC相关包
+	"google.golang.org/protobuf/proto"
+)
+
+// commandFunc 解析后的子命令，使用 client 发送请求并按 out 的格式输出结果
+type commandFunc func(ctx context.Context, client *BookClient, out *output) error
+
+// subcommand 命令行子命令：parse 解析子命令自己的参数
+type subcommand struct {
+	name        string
+	description string
+	parse       func(fs *flag.FlagSet, args []string) (commandFunc, error)
+}
+
+// defaultCommand 未指定子命令时执行的子命令
+const defaultCommand = "demo"
+
+// subcommands 支持的子命令，按帮助信息中的顺序排列
+var subcommands = []subcommand{
+	{"create", "创建图书", parseCreate},
+	{"get", "获取图书信息", parseGet},
+	{"update", "更新图书，只修改指定的字段", parseUpdate},
+	{"delete", "删除图书", parseDelete},
+	{"list", "分页列出图书", parseList},
+	{"search", "按价格区间查询图书", parseSearch},
+	{"demo", "演示所有基本操作（默认）", parseDemo},
+}
+
+// usage 打印全局参数和子命令的帮助信息
+func usage() {
+	out := flag.CommandLine.Output()
+	fmt.Fprintf(out, "用法: %s [全局参数] <子命令> [子命令参数]\n\n子命令:\n", os.Args[0])
+	for _, cmd := range subcommands {
+		fmt.Fprintf(out, "  %-8s %s\n", cmd.name, cmd.description)
+	}
+	fmt.Fprintf(out, "\n全局参数:\n")
+	flag.PrintDefaults()
+}
+
+// parseCommand 解析子命令及其参数，args 为空时执行演示
+func parseCommand(args []string) (commandFunc, error) {
+	name := defaultCommand
+	if len(args) > 0 {
+		name, args = args[0], args[1:]
+	}
+	for _, cmd := range subcommands {
+		if cmd.name == name {
+			fs := flag.NewFlagSet(name, flag.ContinueOnError)
+			return cmd.parse(fs, args)
+		}
+	}
+	return nil, fmt.Errorf("未知的子命令: %s，可用的子命令: %s", name, strings.Join(subcommandNames(), ", "))
+}
+
+// subcommandNames 返回所有子命令的名称
+func subcommandNames() []string {
+	names := make([]string, 0, len(subcommands))
+	for _, cmd := range subcommands {
+		names = append(names, cmd.name)
+	}
+	return names
+}
+
+// parseFlags 解析子命令参数，不接受多余的位置参数，required 中的参数必须显式指定。
+// 返回显式指定的参数名集合
+func parseFlags(fs *flag.FlagSet, args []string, required ...string) (map[string]bool, error) {
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+	if fs.NArg() > 0 {
+		return nil, fmt.Errorf("%s: 多余的参数: %s", fs.Name(), strings.Join(fs.Args(), " "))
+	}
+
+	set := make(map[string]bool)
+	fs.Visit(func(f *flag.Flag) { set[f.Name] = true })
+	for _, name := range required {
+		if !set[name] {
+			return nil, fmt.Errorf("%s: 缺少参数 -%s", fs.Name(), name)
+		}
+	}
+	return set, nil
+}
+
+// parseCreate 解析 create 子命令：create -title T -author A -price P [-description D] [-year Y]
+func parseCreate(fs *flag.FlagSet, args []string) (commandFunc, error) {
+	title := fs.String("title", "", "图书标题")
+	author := fs.String("author", "", "作者")
+	price := fs.Float64("price", 0, "价格")
+	description := fs.String("description", "", "图书描述，不指定时由服务端填充默认描述")
+	year := fs.Int("year", 0, "出版年份，不指定时由服务端填充默认年份")
+	if _, err := parseFlags(fs, args, "title", "author", "price"); err != nil {
+		return nil, err
+	}
+
+	return func(ctx context.Context, client *BookClient, out *output) error {
+		id, err := client.CreateBook(ctx, *title, *author, float32(*price), *description, int32(*year))
+		if err != nil {
+			return err
+		}
+		// JSON 格式输出只有ID的图书对象，其他格式只输出ID
+		if out.format == formatJSON {
+			return printBookInfo(out, &pb.Book{Id: id})
+		}
+		fmt.Fprintln(out.w, id)
+		return nil
+	}, nil
+}
+
+// parseGet 解析 get 子命令：get -id ID
+func parseGet(fs *flag.FlagSet, args []string) (commandFunc, error) {
+	id := fs.String("id", "", "图书ID")
+	if _, err := parseFlags(fs, args, "id"); err != nil {
+		return nil, err
+	}
+
+	return func(ctx context.Context, client *BookClient, out *output) error {
+		book, err := client.GetBook(ctx, *id)
+		if err != nil {
+			return err
+		}
+		return printBookInfo(out, book)
+	}, nil
+}
+
+// parseUpdate 解析 update 子命令：update -id ID [-title T] [-author A] [-price P] [-description D] [-year Y]。
+// 先获取图书的当前内容，未指定的字段保持不变
+func parseUpdate(fs *flag.FlagSet, args []string) (commandFunc, error) {
+	id := fs.String("id", "", "图书ID")
+	title := fs.String("title", "", "新的图书标题")
+	author := fs.String("author", "", "新的作者")
+	price := fs.Float64("price", 0, "新的价格")
+	description := fs.String("description", "", "新的图书描述")
+	year := fs.Int("year", 0, "新的出版年份")
+	set, err := parseFlags(fs, args, "id")
+	if err != nil {
+		return nil, err
+	}
+	if len(set) == 1 {
+		return nil, fmt.Errorf("update: 至少需要指定一个要修改的字段")
+	}
+
+	return func(ctx context.Context, client *BookClient, out *output) error {
+		// 标题和作者是必填字段，需要从当前图书中取得；可选字段只发送指定的，其余由服务端保留原值。
+		// 携带读取时的 etag，图书在此期间被其他请求修改时更新失败，不会覆盖对方的修改
+		current, etag, err := client.GetBookForUpdate(ctx, *id)
+		if err != nil {
+			return err
+		}
+		book := &pb.Book{Id: *id, Title: current.GetTitle(), Author: current.GetAuthor()}
+		if set["title"] {
+			book.Title = *title
+		}
+		if set["author"] {
+			book.Author = *author
+		}
+		if set["price"] {
+			book.Price = proto.Float32(float32(*price))
+		}
+		if set["description"] {
+			book.Description = proto.String(*description)
+		}
+		if set["year"] {
+			book.PublishYear = proto.Int32(int32(*year))
+		}
+		if err := client.UpdateBookIfMatch(ctx, book, etag); err != nil {
+			return err
+		}
+
+		updated, err := client.GetBook(ctx, *id)
+		if err != nil {
+			return err
+		}
+		return printBookInfo(out, updated)
+	}, nil
+}
+
+// parseDelete 解析 delete 子命令：delete -id ID
+func parseDelete(fs *flag.FlagSet, args []string) (commandFunc, error) {
+	id := fs.String("id", "", "图书ID")
+	if _, err := parseFlags(fs, args, "id"); err != nil {
+		return nil, err
+	}
+
+	return func(ctx context.Context, client *BookClient, out *output) error {
+		if err := client.DeleteBook(ctx, *id); err != nil {
+			return err
+		}
+		out.message("已删除图书: %s\n", *id)
+		return nil
+	}, nil
+}
+
+// parseList 解析 list 子命令：list [-page N] [-page-size N]
+func parseList(fs *flag.FlagSet, args []string) (commandFunc, error) {
+	page := fs.Int("page", 1, "页码，从1开始")
+	pageSize := fs.Int("page-size", 10, "每页数量")
+	if _, err := parseFlags(fs, args); err != nil {
+		return nil, err
+	}
+	if *page < 1 || *pageSize < 1 {
+		return nil, fmt.Errorf("list: -page 和 -page-size 必须大于0")
+	}
+
+	return func(ctx context.Context, client *BookClient, out *output) error {
+		books, total, truncated, err := client.ListBooks(ctx, int32(*page), int32(*pageSize))
+		if err != nil {
+			return err
+		}
+		out.message("总共有 %d 本图书\n", total)
+		if err := printBookList(out, books); err != nil {
+			return err
+		}
+		if truncated {
+			log.Printf("⚠️ 结果被截断，请使用 -page-size %d 重新分页", len(books))
+		}
+		return nil
+	}, nil
+}
+
+// parseSearch 解析 search 子命令：search [-min P] -max P
+func parseSearch(fs *flag.FlagSet, args []string) (commandFunc, error) {
+	minPrice := fs.Float64("min", 0, "最低价格")
+	maxPrice := fs.Float64("max", 0, "最高价格")
+	if _, err := parseFlags(fs, args, "max"); err != nil {
+		return nil, err
+	}
+	if *minPrice > *maxPrice {
+		return nil, fmt.Errorf("search: -min 不能大于 -max")
+	}
+
+	return func(ctx context.Context, client *BookClient, out *output) error {
+		books, err := client.SearchBooksByPrice(ctx, float32(*minPrice), float32(*maxPrice))
+		if err != nil {
+			return err
+		}
+		return printBookList(out, books)
+	}, nil
+}
+
+// parseDemo 解析 demo 子命令，没有参数
+func parseDemo(fs *flag.FlagSet, args []string) (commandFunc, error) {
+	if _, err := parseFlags(fs, args); err != nil {
+		return nil, err
+	}
+	return runDemo, nil
+}
+
+// runDemo 依次演示创建、获取、更新、列出、查询和删除图书，单个步骤失败时记录日志并继续
+func runDemo(ctx context.Context, client *BookClient, out *output) error {
+	log.Println("🚀 开始演示图书管理服务...")
+	log.Println("==================================================")
+
+	// 演示1: 创建图书
+	log.Println("📝 演示1: 创建图书")
+	bookID1, err := client.CreateBook(
+		ctx,
+		"The Go Programming Language",
+		"Alan A. A. Donovan",
+		45.99,
+		"Go语言的权威指南，适合初学者和有经验的开发者",
+		2015,
+	)
+	if err != nil {
+		log.Printf("❌ 创建图书失败: %v", err)
+	}
+
+	_, err = client.CreateBook(
+		ctx,
+		"Design Patterns",
+		"Erich Gamma",
+		39.99,
+		"面向对象设计模式的经典著作",
+		1994,
+	)
+	if err != nil {
+		log.Printf("❌ 创建图书失败: %v", err)
+	}
+
+	bookID3, err := client.CreateBook(
+		ctx,
+		"Clean Code",
+		"Robert C. Martin",
+		29.99,
+		"编写可维护代码的最佳实践",
+		2008,
+	)
+	if err != nil {
+		log.Printf("❌ 创建图书失败: %v", err)
+	}
+
+	// 演示2: 获取图书信息
+	log.Println("📖 演示2: 获取图书信息")
+	book, err := client.GetBook(ctx, bookID1)
+	if err != nil {
+		log.Printf("❌ 获取图书失败: %v", err)
+	} else {
+		printBookInfo(out, book)
+	}
+
+	// 演示3: 更新图书信息
+	log.Println("✏️ 演示3: 更新图书信息")
+	err = client.UpdateBook(
+		ctx,
+		bookID1,
+		"The Go Programming Language (Updated)",
+		"Alan A. A. Donovan",
+		49.99,
+		"Go语言的权威指南，适合初学者和有经验的开发者 (更新版)",
+		2015,
+	)
+	if err != nil {
+		log.Printf("❌ 更新图书失败: %v", err)
+	}
+
+	// 验证更新结果
+	updatedBook, err := client.GetBook(ctx, bookID1)
+	if err != nil {
+		log.Printf("❌ 获取更新后的图书失败: %v", err)
+	} else {
+		printBookInfo(out, updatedBook)
+	}
+
+	// 演示4: 列出所有图书
+	log.Println("📋 演示4: 列出所有图书")
+	books, total, _, err := client.ListBooks(ctx, 1, 10)
+	if err != nil {
+		log.Printf("❌ 列出图书失败: %v", err)
+	} else {
+		out.message("总共有 %d 本图书\n", total)
+		printBookList(out, books)
+	}
+
+	// 演示5: 按价格区间查询
+	log.Println("🔍 演示5: 按价格区间查询 (¥30-50)")
+	priceBooks, err := client.SearchBooksByPrice(ctx, 30, 50)
+	if err != nil {
+		log.Printf("❌ 按价格查询失败: %v", err)
+	} else {
+		printBookList(out, priceBooks)
+	}
+
+	// 演示6: 删除图书
+	log.Println("🗑️ 演示6: 删除图书")
+	err = client.DeleteBook(ctx, bookID3)
+	if err != nil {
+		log.Printf("❌ 删除图书失败: %v", err)
+	}
+
+	// 验证删除结果
+	log.Println("📋 删除后的图书列表:")
+	booksAfterDelete, _, _, err := client.ListBooks(ctx, 1, 10)
+	if err != nil {
+		log.Printf("❌ 列出图书失败: %v", err)
+	} else {
+		printBookList(out, booksAfterDelete)
+	}
+
+	log.Println("🎉 演示完成!")
+	return nil
+}
@@ -0,0 +1,211 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+
+	// 导入生成的protobuf代码
+	pb "grpc-basic-client/pb"
+
+	// 导入gRPC相关包
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// UpdateBook 与服务端一样合并图书：未设置的可选字段保留原值；携带的 if-match 与版本不一致时返回 Aborted
+func (s *memoryServer) UpdateBook(ctx context.Context, req *pb.UpdateBookRequest) (*pb.UpdateBookResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	book := req.GetBook()
+	existing, ok := s.books[book.GetId()]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "图书不存在")
+	}
+	md, _ := metadata.FromIncomingContext(ctx)
+	if vals := md.Get(ifMatchHeader); len(vals) > 0 && vals[0] != strconv.Itoa(s.versions[book.GetId()]) {
+		return nil, status.Errorf(codes.Aborted, "图书已被其他请求修改")
+	}
+	if book.Price == nil {
+		book.Price = existing.Price
+	}
+	if book.Description == nil {
+		book.Description = existing.Description
+	}
+	if book.PublishYear == nil {
+		book.PublishYear = existing.PublishYear
+	}
+	s.books[book.GetId()] = book
+	if s.versions == nil {
+		s.versions = make(map[string]int)
+	}
+	s.versions[book.GetId()]++
+	return &pb.UpdateBookResponse{}, nil
+}
+
+// DeleteBook 删除图书
+func (s *memoryServer) DeleteBook(ctx context.Context, req *pb.DeleteBookRequest) (*pb.DeleteBookResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.books, req.GetId())
+	return &pb.DeleteBookResponse{}, nil
+}
+
+// ListBooks 按ID顺序返回所有图书，忽略分页参数
+func (s *memoryServer) ListBooks(ctx context.Context, req *pb.ListBooksRequest) (*pb.ListBooksResponse, error) {
+	books := s.sorted(func(*pb.Book) bool { return true })
+	return &pb.ListBooksResponse{Books: books, Total: int32(len(books))}, nil
+}
+
+// SearchBooksByPrice 返回价格在区间内的图书
+func (s *memoryServer) SearchBooksByPrice(ctx context.Context, req *pb.SearchBooksByPriceRequest) (*pb.SearchBooksByPriceResponse, error) {
+	books := s.sorted(func(b *pb.Book) bool {
+		return b.GetPrice() >= req.GetMinPrice() && b.GetPrice() <= req.GetMaxPrice()
+	})
+	return &pb.SearchBooksByPriceResponse{Books: books}, nil
+}
+
+// sorted 按ID顺序返回满足条件的图书
+func (s *memoryServer) sorted(match func(*pb.Book) bool) []*pb.Book {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var books []*pb.Book
+	for _, book := range s.books {
+		if match(book) {
+			books = append(books, book)
+		}
+	}
+	sort.Slice(books, func(i, j int) bool { return books[i].GetId() < books[j].GetId() })
+	return books
+}
+
+// TestParseCommand 测试子命令及其参数的解析
+func TestParseCommand(t *testing.T) {
+	tests := []struct {
+		args    []string
+		wantErr string
+	}{
+		{nil, ""},
+		{[]string{"demo"}, ""},
+		{[]string{"create", "-title", "图书", "-author", "作者", "-price", "10"}, ""},
+		{[]string{"create", "-title", "图书", "-author", "作者"}, "缺少参数 -price"},
+		{[]string{"get", "-id", "book-1"}, ""},
+		{[]string{"get"}, "缺少参数 -id"},
+		{[]string{"get", "-id", "book-1", "extra"}, "多余的参数"},
+		{[]string{"update", "-id", "book-1", "-price", "20"}, ""},
+		{[]string{"update", "-id", "book-1"}, "至少需要指定一个要修改的字段"},
+		{[]string{"delete", "-id", "book-1"}, ""},
+		{[]string{"list", "-page", "2", "-page-size", "5"}, ""},
+		{[]string{"list", "-page", "0"}, "必须大于0"},
+		{[]string{"search", "-min", "10", "-max", "50"}, ""},
+		{[]string{"search", "-min", "50", "-max", "10"}, "-min 不能大于 -max"},
+		{[]string{"search", "-min", "abc", "-max", "10"}, "invalid value"},
+		{[]string{"export"}, "未知的子命令: export"},
+	}
+
+	for _, tt := range tests {
+		cmd, err := parseCommand(tt.args)
+		if tt.wantErr == "" {
+			if err != nil || cmd == nil {
+				t.Errorf("%v: 期望解析成功，实际错误为: %v", tt.args, err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("%v: 期望错误包含 %q，实际为: %v", tt.args, tt.wantErr, err)
+		}
+	}
+}
+
+// TestCommandRoundTrip 测试通过子命令创建、更新、获取、列出、查询和删除图书
+func TestCommandRoundTrip(t *testing.T) {
+	server := &memoryServer{books: make(map[string]*pb.Book)}
+	client := startTestClient(t, server)
+	ctx := context.Background()
+
+	run := func(args ...string) string {
+		t.Helper()
+		cmd, err := parseCommand(args)
+		if err != nil {
+			t.Fatalf("%v: 解析失败: %v", args, err)
+		}
+		var out bytes.Buffer
+		if err := cmd(ctx, client, &output{w: &out, format: formatTable}); err != nil {
+			t.Fatalf("%v: 执行失败: %v", args, err)
+		}
+		return out.String()
+	}
+
+	id := strings.TrimSpace(run("create", "-title", "Go语言", "-author", "作者", "-price", "45.5", "-description", "描述", "-year", "2015"))
+	if id == "" {
+		t.Fatal("期望输出新图书的ID")
+	}
+
+	// 只修改价格，其余字段保持不变
+	out := run("update", "-id", id, "-price", "50")
+	if !strings.Contains(out, "¥50.00") || !strings.Contains(out, "Go语言") || !strings.Contains(out, "描述") {
+		t.Errorf("更新后的图书信息不正确: %s", out)
+	}
+	if book := server.books[id]; book.GetPublishYear() != 2015 || book.GetAuthor() != "作者" {
+		t.Errorf("未指定的字段不应被修改，实际为: %v", book)
+	}
+
+	if out := run("get", "-id", id); !strings.Contains(out, "ID: "+id) || !strings.Contains(out, "出版年份: 2015") {
+		t.Errorf("获取的图书信息不正确: %s", out)
+	}
+
+	run("create", "-title", "便宜图书", "-author", "作者", "-price", "5")
+	if out := run("list"); !strings.Contains(out, "总共有 2 本图书") {
+		t.Errorf("列出的图书不正确: %s", out)
+	}
+	if out := run("search", "-min", "10", "-max", "100"); !strings.Contains(out, "共 1 本") || !strings.Contains(out, "Go语言") {
+		t.Errorf("按价格查询的结果不正确: %s", out)
+	}
+
+	run("delete", "-id", id)
+	if _, ok := server.books[id]; ok {
+		t.Error("期望图书已被删除")
+	}
+
+	// 服务端返回的错误原样返回给调用方
+	cmd, err := parseCommand([]string{"get", "-id", id})
+	if err != nil {
+		t.Fatalf("解析失败: %v", err)
+	}
+	if err := cmd(ctx, client, &output{w: &bytes.Buffer{}, format: formatTable}); status.Code(err) != codes.NotFound {
+		t.Errorf("期望错误码为NotFound，实际为: %v", err)
+	}
+}
+
+// TestUpdateRejectsConcurrentEdit 测试更新前读取的图书已被其他请求修改时，更新失败而不是覆盖对方的修改
+func TestUpdateRejectsConcurrentEdit(t *testing.T) {
+	server := &memoryServer{books: map[string]*pb.Book{
+		"book-1": {Id: "book-1", Title: "图书", Author: "作者", Price: proto.Float32(10)},
+	}}
+	client := startTestClient(t, server)
+	ctx := context.Background()
+
+	current, etag, err := client.GetBookForUpdate(ctx, "book-1")
+	if err != nil {
+		t.Fatalf("获取图书失败: %v", err)
+	}
+
+	// 其他客户端在此期间修改了图书
+	if err := client.UpdateBookIfMatch(ctx, &pb.Book{Id: "book-1", Title: "并发修改", Author: "作者"}, ""); err != nil {
+		t.Fatalf("更新图书失败: %v", err)
+	}
+
+	err = client.UpdateBookIfMatch(ctx, &pb.Book{Id: "book-1", Title: current.GetTitle(), Author: current.GetAuthor(), Price: proto.Float32(20)}, etag)
+	if status.Code(err) != codes.Aborted {
+		t.Errorf("期望错误码为Aborted，实际为: %v", err)
+	}
+	if book := server.books["book-1"]; book.GetTitle() != "并发修改" || book.GetPrice() != 10 {
+		t.Errorf("并发的修改不应被覆盖，实际为: %v", book)
+	}
+}
@@ -0,0 +1,131 @@
+package main
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	// 导入生成的protobuf代码
+	pb "grpc-basic-client/pb"
+
+	// 导入gRPC相关包
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/proto"
+)
+
+// blockingServer 测试用的服务端，GetBook 会一直阻塞直到调用被取消
+type blockingServer struct {
+	pb.UnimplementedBookServiceServer
+
+	// 收到请求时通知测试
+	started chan struct{}
+}
+
+// GetBook 阻塞直到客户端取消
+func (s *blockingServer) GetBook(ctx context.Context, req *pb.GetBookRequest) (*pb.GetBookResponse, error) {
+	s.started <- struct{}{}
+	<-ctx.Done()
+	return nil, status.FromContextError(ctx.Err()).Err()
+}
+
+// startTestClient 使用 bufconn 启动测试服务端，返回连接到它的客户端
+func startTestClient(t *testing.T, impl pb.BookServiceServer, opts ...ClientOption) *BookClient {
+	t.Helper()
+
+	lis := bufconn.Listen(1 << 20)
+	s := grpc.NewServer()
+	pb.RegisterBookServiceServer(s, impl)
+	go s.Serve(lis)
+	t.Cleanup(s.Stop)
+
+	dialer := grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+		return lis.DialContext(ctx)
+	})
+	client, err := NewBookClient("passthrough:///bufnet", append([]ClientOption{WithDialOptions(dialer)}, opts...)...)
+	if err != nil {
+		t.Fatalf("创建客户端失败: %v", err)
+	}
+	t.Cleanup(func() { client.Close() })
+	return client
+}
+
+// TestRootContextCancel 测试取消根上下文后进行中的调用返回 Canceled
+func TestRootContextCancel(t *testing.T) {
+	server := &blockingServer{started: make(chan struct{}, 2)}
+	client := startTestClient(t, server)
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	// 同时发起两个调用
+	errs := make(chan error, 2)
+	for i := 0; i < 2; i++ {
+		go func() {
+			_, err := client.GetBook(ctx, "book-1")
+			errs <- err
+		}()
+	}
+	<-server.started
+	<-server.started
+
+	// 取消根上下文，所有调用都应返回 Canceled
+	cancel()
+	for i := 0; i < 2; i++ {
+		select {
+		case err := <-errs:
+			if status.Code(err) != codes.Canceled {
+				t.Errorf("期望错误码为Canceled，实际为: %v", err)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("取消后调用未及时返回")
+		}
+	}
+}
+
+// truncatedListServer 测试用的服务端，ListBooks 返回被截断的结果
+type truncatedListServer struct {
+	pb.UnimplementedBookServiceServer
+}
+
+// ListBooks 返回被截断的一页
+func (s *truncatedListServer) ListBooks(ctx context.Context, req *pb.ListBooksRequest) (*pb.ListBooksResponse, error) {
+	return &pb.ListBooksResponse{
+		Books:     []*pb.Book{{Id: "book-1"}},
+		Total:     5,
+		Truncated: true,
+		PageSize:  1,
+	}, nil
+}
+
+// TestListBooksTruncated 测试客户端返回截断标记
+func TestListBooksTruncated(t *testing.T) {
+	client := startTestClient(t, &truncatedListServer{})
+
+	books, total, truncated, err := client.ListBooks(context.Background(), 1, 10)
+	if err != nil {
+		t.Fatalf("列出图书失败: %v", err)
+	}
+	if !truncated || len(books) != 1 || total != 5 {
+		t.Errorf("期望返回被截断的1本图书，实际为: %d 本, 总数 %d, 截断 %v", len(books), total, truncated)
+	}
+}
+
+// TestFormatPrice 测试按币种格式化价格
+func TestFormatPrice(t *testing.T) {
+	tests := []struct {
+		book *pb.Book
+		want string
+	}{
+		{&pb.Book{Price: proto.Float32(29.99)}, "¥29.99"},
+		{&pb.Book{Price: proto.Float32(29.99), Currency: "CNY"}, "¥29.99"},
+		{&pb.Book{Price: proto.Float32(10), Currency: "USD"}, "10.00 USD"},
+	}
+	for _, tt := range tests {
+		if got := formatPrice(tt.book); got != tt.want {
+			t.Errorf("期望 %s，实际为: %s", tt.want, got)
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	// 导入gRPC相关包
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// DeadlineError 调用超时的错误，区分超时发生在客户端还是服务端，
+// 便于重试逻辑和可用性统计分别处理。可以通过 errors.As 获取，
+// 原始的 gRPC 错误仍可通过 status.Code 读取
+type DeadlineError struct {
+	// ServerSide 为 true 表示客户端的截止时间尚未到达，是服务端返回了 DeadlineExceeded
+	// （如服务端的下游调用超时）；为 false 表示客户端的上下文已超时，请求可能根本没有发出
+	ServerSide bool
+
+	err error
+}
+
+// Error 返回错误信息
+func (e *DeadlineError) Error() string {
+	if e.ServerSide {
+		return fmt.Sprintf("服务端处理超时: %v", e.err)
+	}
+	return fmt.Sprintf("客户端等待超时: %v", e.err)
+}
+
+// Unwrap 返回原始错误
+func (e *DeadlineError) Unwrap() error {
+	return e.err
+}
+
+// classifyDeadline 将超时错误包装为 DeadlineError，其他错误原样返回。
+// ctx 为发起调用时使用的上下文：它已超时说明超时发生在客户端
+func classifyDeadline(ctx context.Context, err error) error {
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(ctx.Err(), context.DeadlineExceeded):
+		return &DeadlineError{ServerSide: false, err: err}
+	case status.Code(err) == codes.DeadlineExceeded:
+		return &DeadlineError{ServerSide: true, err: err}
+	default:
+		return err
+	}
+}
+
+// LocalizedMessage 返回错误的本地化描述：服务端开启 -rich-errors 时读取错误详情中的
+// LocalizedMessage（语言由 WithLanguage 指定），没有时返回 gRPC 错误信息（不含客户端添加的前缀）；
+// 不是 gRPC 错误时返回 err.Error()
+func LocalizedMessage(err error) string {
+	if err == nil {
+		return ""
+	}
+	var grpcErr interface{ GRPCStatus() *status.Status }
+	if !errors.As(err, &grpcErr) {
+		return err.Error()
+	}
+	st := grpcErr.GRPCStatus()
+	for _, detail := range st.Details() {
+		if localized, ok := detail.(*errdetails.LocalizedMessage); ok {
+			return localized.GetMessage()
+		}
+	}
+	return st.Message()
+}
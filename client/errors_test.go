@@ -0,0 +1,96 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	// 导入生成的protobuf代码
+	pb "grpc-basic-client/pb"
+
+	// 导入gRPC相关包
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// deadlineServer 测试用的服务端，模拟服务端自身的处理超时
+type deadlineServer struct {
+	pb.UnimplementedBookServiceServer
+}
+
+// GetBook 立即返回 DeadlineExceeded，模拟服务端下游调用超时
+func (s *deadlineServer) GetBook(ctx context.Context, req *pb.GetBookRequest) (*pb.GetBookResponse, error) {
+	return nil, status.Errorf(codes.DeadlineExceeded, "下游调用超时")
+}
+
+// TestClientSideDeadline 测试客户端上下文超时时返回客户端超时错误
+func TestClientSideDeadline(t *testing.T) {
+	server := &blockingServer{started: make(chan struct{}, 1)}
+	client := startTestClient(t, server)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err := client.GetBook(ctx, "book-1")
+	var deadlineErr *DeadlineError
+	if !errors.As(err, &deadlineErr) || deadlineErr.ServerSide {
+		t.Fatalf("期望返回客户端超时错误，实际为: %v", err)
+	}
+	if status.Code(err) != codes.DeadlineExceeded {
+		t.Errorf("期望仍能读取错误码DeadlineExceeded，实际为: %v", status.Code(err))
+	}
+}
+
+// TestServerSideDeadline 测试服务端返回 DeadlineExceeded 时返回服务端超时错误
+func TestServerSideDeadline(t *testing.T) {
+	client := startTestClient(t, &deadlineServer{})
+
+	_, err := client.GetBook(context.Background(), "book-1")
+	var deadlineErr *DeadlineError
+	if !errors.As(err, &deadlineErr) || !deadlineErr.ServerSide {
+		t.Fatalf("期望返回服务端超时错误，实际为: %v", err)
+	}
+	if status.Code(err) != codes.DeadlineExceeded {
+		t.Errorf("期望仍能读取错误码DeadlineExceeded，实际为: %v", status.Code(err))
+	}
+}
+
+// TestClassifyDeadlineOtherErrors 测试其他错误不被包装
+func TestClassifyDeadlineOtherErrors(t *testing.T) {
+	err := status.Errorf(codes.NotFound, "图书不存在")
+	if got := classifyDeadline(context.Background(), err); got != err {
+		t.Errorf("期望其他错误原样返回，实际为: %v", got)
+	}
+}
+
+// localizedServer 测试用的服务端，按 accept-language 返回带有本地化描述的错误
+type localizedServer struct {
+	pb.UnimplementedBookServiceServer
+}
+
+// GetBook 返回 NotFound，英文描述放在 LocalizedMessage 中
+func (s *localizedServer) GetBook(ctx context.Context, req *pb.GetBookRequest) (*pb.GetBookResponse, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	st := status.New(codes.NotFound, "图书不存在")
+	if languages := md.Get("accept-language"); len(languages) == 1 && languages[0] == "en" {
+		st, _ = st.WithDetails(&errdetails.LocalizedMessage{Locale: "en", Message: "book not found"})
+	}
+	return nil, st.Err()
+}
+
+// TestLocalizedMessage 测试 WithLanguage 发送 accept-language，LocalizedMessage 读取本地化描述
+func TestLocalizedMessage(t *testing.T) {
+	_, err := startTestClient(t, &localizedServer{}, WithLanguage("en")).GetBook(context.Background(), "book-1")
+	if got := LocalizedMessage(err); got != "book not found" {
+		t.Errorf("期望英文描述，实际为: %q", got)
+	}
+
+	// 没有本地化描述时返回错误信息
+	_, err = startTestClient(t, &localizedServer{}).GetBook(context.Background(), "book-1")
+	if got := LocalizedMessage(err); got != "图书不存在" {
+		t.Errorf("期望返回错误信息，实际为: %q", got)
+	}
+}
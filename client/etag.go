@@ -0,0 +1,109 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	// 导入生成的protobuf代码
+	pb "grpc-basic-client/pb"
+
+	// 导入gRPC相关包
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// 条件请求使用的元数据键，与服务端一致
+const (
+	etagHeader        = "etag"
+	ifNoneMatchHeader = "if-none-match"
+	ifMatchHeader     = "if-match"
+)
+
+// cachedBook 缓存的图书及服务端返回的 etag
+type cachedBook struct {
+	etag string
+	book *pb.Book
+}
+
+// GetBookWithETag 获取图书，缓存每本图书最近一次的版本：
+// 再次获取时携带 if-none-match，图书未修改时服务端不返回内容，直接使用缓存（notModified 为 true）
+func (c *BookClient) GetBookWithETag(ctx context.Context, bookID string) (book *pb.Book, notModified bool, err error) {
+	// 在调用方的上下文上设置超时时间，调用方取消时请求随之取消
+	ctx, cancel := context.WithTimeout(ctx, defaultCallTimeout)
+	defer cancel()
+
+	c.etagMu.Lock()
+	cached, hasCache := c.etags[bookID]
+	c.etagMu.Unlock()
+	if hasCache {
+		ctx = metadata.AppendToOutgoingContext(ctx, ifNoneMatchHeader, cached.etag)
+	}
+
+	var header metadata.MD
+	resp, err := c.client.GetBook(ctx, &pb.GetBookRequest{Id: bookID}, grpc.Header(&header))
+	if err != nil {
+		return nil, false, fmt.Errorf("获取图书失败: %w", classifyDeadline(ctx, err))
+	}
+	if resp.GetNotModified() && hasCache {
+		log.Printf("✅ 图书未修改，使用缓存: %s", cached.book.GetTitle())
+		return cached.book, true, nil
+	}
+
+	// 缓存新的版本；服务端没有返回 etag 时不缓存
+	c.etagMu.Lock()
+	if etags := header.Get(etagHeader); len(etags) > 0 {
+		if c.etags == nil {
+			c.etags = make(map[string]cachedBook)
+		}
+		c.etags[bookID] = cachedBook{etag: etags[0], book: resp.GetBook()}
+	} else {
+		delete(c.etags, bookID)
+	}
+	c.etagMu.Unlock()
+
+	log.Printf("✅ 成功获取图书: %s", resp.GetBook().GetTitle())
+	return resp.GetBook(), false, nil
+}
+
+// GetBookForUpdate 获取图书及其当前 etag，之后通过 UpdateBookIfMatch 更新，
+// 图书在两次调用之间被其他请求修改时更新会失败，而不是覆盖对方的修改
+func (c *BookClient) GetBookForUpdate(ctx context.Context, bookID string) (book *pb.Book, etag string, err error) {
+	// 在调用方的上下文上设置超时时间，调用方取消时请求随之取消
+	ctx, cancel := context.WithTimeout(ctx, defaultCallTimeout)
+	defer cancel()
+
+	var header metadata.MD
+	resp, err := c.client.GetBook(ctx, &pb.GetBookRequest{Id: bookID}, grpc.Header(&header))
+	if err != nil {
+		return nil, "", fmt.Errorf("获取图书失败: %w", classifyDeadline(ctx, err))
+	}
+	if etags := header.Get(etagHeader); len(etags) > 0 {
+		etag = etags[0]
+	}
+	return resp.GetBook(), etag, nil
+}
+
+// UpdateBookIfMatch 更新图书，book 中未设置的可选字段（价格、描述、出版年份）由服务端保留原值。
+// etag 非空时携带 if-match，图书已被其他请求修改时返回 Aborted
+func (c *BookClient) UpdateBookIfMatch(ctx context.Context, book *pb.Book, etag string) error {
+	// 在调用方的上下文上设置超时时间，调用方取消时请求随之取消
+	ctx, cancel := context.WithTimeout(ctx, defaultCallTimeout)
+	defer cancel()
+
+	if etag != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, ifMatchHeader, etag)
+	}
+	resp, err := c.client.UpdateBook(ctx, &pb.UpdateBookRequest{Book: book})
+	if status.Code(err) == codes.Aborted {
+		return fmt.Errorf("更新图书失败: 图书在读取后已被其他请求修改，请重新执行: %w", err)
+	}
+	if err != nil {
+		return fmt.Errorf("更新图书失败: %w", classifyDeadline(ctx, err))
+	}
+
+	log.Printf("✅ 图书更新成功: %s", resp.Message)
+	return nil
+}
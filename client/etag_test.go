@@ -0,0 +1,71 @@
+package main
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"testing"
+
+	// 导入生成的protobuf代码
+	pb "grpc-basic-client/pb"
+
+	// 导入gRPC相关包
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// versionedServer 测试用的服务端，按版本号实现条件获取
+type versionedServer struct {
+	pb.UnimplementedBookServiceServer
+
+	mu      sync.Mutex
+	book    *pb.Book
+	version int
+}
+
+// GetBook 返回 etag 响应头，if-none-match 与当前版本一致时只返回未修改标记
+func (s *versionedServer) GetBook(ctx context.Context, req *pb.GetBookRequest) (*pb.GetBookResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	etag := strconv.Itoa(s.version)
+	grpc.SetHeader(ctx, metadata.Pairs(etagHeader, etag))
+	md, _ := metadata.FromIncomingContext(ctx)
+	if vals := md.Get(ifNoneMatchHeader); len(vals) > 0 && vals[0] == etag {
+		return &pb.GetBookResponse{NotModified: true}, nil
+	}
+	return &pb.GetBookResponse{Book: s.book}, nil
+}
+
+// update 修改图书并递增版本号
+func (s *versionedServer) update(title string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.book = &pb.Book{Id: s.book.GetId(), Title: title}
+	s.version++
+}
+
+// TestGetBookWithETag 测试未修改的图书使用缓存，修改后的图书重新获取
+func TestGetBookWithETag(t *testing.T) {
+	server := &versionedServer{book: &pb.Book{Id: "book-1", Title: "图书"}, version: 1}
+	client := startTestClient(t, server)
+	ctx := context.Background()
+
+	book, notModified, err := client.GetBookWithETag(ctx, "book-1")
+	if err != nil || notModified || book.GetTitle() != "图书" {
+		t.Fatalf("期望首次获取返回完整图书，实际为: %v, %v, %v", book, notModified, err)
+	}
+
+	// 未修改时返回缓存的图书
+	book, notModified, err = client.GetBookWithETag(ctx, "book-1")
+	if err != nil || !notModified || book.GetTitle() != "图书" {
+		t.Errorf("期望返回未修改并使用缓存，实际为: %v, %v, %v", book, notModified, err)
+	}
+
+	// 修改后返回新的图书
+	server.update("新书名")
+	book, notModified, err = client.GetBookWithETag(ctx, "book-1")
+	if err != nil || notModified || book.GetTitle() != "新书名" {
+		t.Errorf("期望修改后返回完整图书，实际为: %v, %v, %v", book, notModified, err)
+	}
+}
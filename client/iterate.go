@@ -0,0 +1,93 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	// 导入生成的protobuf代码
+	pb "grpc-basic-client/pb"
+
+	// 导入gRPC相关包
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// IterateAllBooks 自动翻页逐本返回所有图书：先打开快照保证分页结果一致（服务端不支持时直接分页），
+// 再按 pageSize 调用 ListBooks，服务端限制页大小或响应被截断时改用服务端返回的页大小继续。
+// 图书通过第一个通道逐本发送；出错或 ctx 被取消时停止，错误通过第二个通道发送。两个通道在结束后都会关闭，
+// 调用方应读完图书通道后再读取错误通道
+func (c *BookClient) IterateAllBooks(ctx context.Context, pageSize int32) (<-chan *pb.Book, <-chan error) {
+	books := make(chan *pb.Book)
+	errs := make(chan error, 1)
+
+	go func() {
+		defer close(errs)
+		defer close(books)
+		if err := c.iterateAllBooks(ctx, pageSize, books); err != nil {
+			errs <- err
+		}
+	}()
+	return books, errs
+}
+
+// iterateAllBooks 逐页获取图书并发送到 books，直到取完所有图书
+func (c *BookClient) iterateAllBooks(ctx context.Context, pageSize int32, books chan<- *pb.Book) error {
+	token, err := c.openSnapshot(ctx)
+	if err != nil {
+		return err
+	}
+
+	// offset 为已发送的图书数量；页大小改变后 offset 不一定是页大小的整数倍，需要跳过页开头已发送的图书
+	offset := int32(0)
+	for {
+		if pageSize <= 0 {
+			pageSize = 10
+		}
+		callCtx, cancel := context.WithTimeout(ctx, defaultCallTimeout)
+		resp, err := c.client.ListBooks(callCtx, &pb.ListBooksRequest{
+			Page:          offset/pageSize + 1,
+			PageSize:      pageSize,
+			SnapshotToken: token,
+		})
+		if err != nil {
+			err = classifyDeadline(callCtx, err)
+			cancel()
+			return fmt.Errorf("列出图书失败: %w", err)
+		}
+		cancel()
+
+		page := resp.GetBooks()[min(int(offset%pageSize), len(resp.GetBooks())):]
+		for _, book := range page {
+			select {
+			case books <- book:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+		offset += int32(len(page))
+
+		if len(page) == 0 || offset >= resp.GetTotal() {
+			return nil
+		}
+		// 服务端会限制页大小，被截断时返回更小的页大小，之后都使用服务端实际采用的页大小
+		if resp.GetPageSize() > 0 {
+			pageSize = resp.GetPageSize()
+		}
+	}
+}
+
+// openSnapshot 打开只读快照并返回令牌，服务端不支持快照时返回空令牌
+func (c *BookClient) openSnapshot(ctx context.Context) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, defaultCallTimeout)
+	defer cancel()
+
+	resp, err := c.client.OpenSnapshot(ctx, &emptypb.Empty{})
+	if status.Code(err) == codes.Unimplemented {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("打开快照失败: %w", classifyDeadline(ctx, err))
+	}
+	return resp.GetToken(), nil
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	// 导入生成的protobuf代码
+	pb "grpc-basic-client/pb"
+)
+
+// pagingServer 测试用的服务端：页大小最大为10，第一页被截断为3本图书，不支持快照
+type pagingServer struct {
+	pb.UnimplementedBookServiceServer
+	books []*pb.Book
+}
+
+// ListBooks 按页码和页大小返回图书
+func (s *pagingServer) ListBooks(ctx context.Context, req *pb.ListBooksRequest) (*pb.ListBooksResponse, error) {
+	pageSize := min(req.GetPageSize(), 10)
+	start := min(int(req.GetPage()-1)*int(pageSize), len(s.books))
+	end := min(start+int(pageSize), len(s.books))
+	resp := &pb.ListBooksResponse{Books: s.books[start:end], Total: int32(len(s.books)), PageSize: pageSize}
+	if req.GetPage() == 1 && pageSize > 3 {
+		resp.Books, resp.Truncated, resp.PageSize = resp.Books[:3], true, 3
+	}
+	return resp, nil
+}
+
+// TestIterateAllBooks 测试自动翻页时25本图书都被返回且只返回一次
+func TestIterateAllBooks(t *testing.T) {
+	server := &pagingServer{}
+	for i := 1; i <= 25; i++ {
+		server.books = append(server.books, &pb.Book{Id: fmt.Sprintf("book-%d", i)})
+	}
+	client := startTestClient(t, server)
+
+	for _, pageSize := range []int32{0, 3, 7, 100} {
+		books, errs := client.IterateAllBooks(context.Background(), pageSize)
+		seen := make(map[string]int)
+		for book := range books {
+			seen[book.GetId()]++
+		}
+		if err := <-errs; err != nil {
+			t.Fatalf("页大小%d: 遍历图书失败: %v", pageSize, err)
+		}
+		if len(seen) != 25 {
+			t.Errorf("页大小%d: 期望返回25本图书，实际为%d本", pageSize, len(seen))
+		}
+		for id, count := range seen {
+			if count != 1 {
+				t.Errorf("页大小%d: 图书 %s 被返回了%d次", pageSize, id, count)
+			}
+		}
+	}
+}
+
+// TestIterateAllBooksCancel 测试取消上下文后停止遍历并返回错误
+func TestIterateAllBooksCancel(t *testing.T) {
+	server := &pagingServer{}
+	for i := 1; i <= 25; i++ {
+		server.books = append(server.books, &pb.Book{Id: fmt.Sprintf("book-%d", i)})
+	}
+	client := startTestClient(t, server)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	books, errs := client.IterateAllBooks(ctx, 5)
+	<-books
+	cancel()
+	for range books {
+	}
+	if err := <-errs; err == nil {
+		t.Errorf("期望取消后返回错误")
+	}
+}
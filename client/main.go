@@ -2,8 +2,14 @@ package main
 
 import (
 	"context"
+	"errors"
+	"flag"
 	"fmt"
+	"io"
 	"log"
+	"os"
+	"os/signal"
+	"sync"
 	"time"
 
 	// 导入生成的protobuf代码
@@ -12,20 +18,67 @@ import (
 	// 导入gRPC相关包
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
+	_ "google.golang.org/grpc/encoding/gzip" // 注册 gzip 解压缩，服务端可以压缩较大的响应
+	"google.golang.org/grpc/resolver/manual"
+	"google.golang.org/protobuf/proto"
 )
 
+// defaultCallTimeout 单次调用的默认超时时间，在调用方传入的 ctx 基础上生效
+const defaultCallTimeout = 10 * time.Second
+
 // BookClient 图书管理客户端
 type BookClient struct {
 	client pb.BookServiceClient
 	conn   *grpc.ClientConn
+
+	// GetBookWithETag 缓存的图书及其版本，按图书ID索引
+	etagMu sync.Mutex
+	etags  map[string]cachedBook
 }
 
 // NewBookClient 创建新的图书客户端
-func NewBookClient(serverAddr string) (*BookClient, error) {
+// serverAddr 可以是单个地址、gRPC 目标（如 dns:///books.example.com:50051），
+// 也可以是逗号分隔的多个地址，此时默认使用 round_robin 在各地址间负载均衡
+func NewBookClient(serverAddr string, opts ...ClientOption) (*BookClient, error) {
+	options := &clientOptions{}
+
+	// 多个地址时使用静态解析器，并默认轮询各后端
+	target := serverAddr
+	var dialOptions []grpc.DialOption
+	if addrs := splitAddrs(serverAddr); len(addrs) > 1 {
+		var r *manual.Resolver
+		target, r = staticResolver(addrs)
+		dialOptions = append(dialOptions, grpc.WithResolvers(r))
+		options.loadBalancingPolicy = "round_robin"
+	}
+
+	for _, opt := range opts {
+		opt(options)
+	}
+
 	// 建立到服务器的连接
-	conn, err := grpc.Dial(serverAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	dialOptions = append(dialOptions, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	userAgent := options.userAgent
+	if userAgent == "" {
+		userAgent = defaultUserAgent()
+	}
+	dialOptions = append(dialOptions, grpc.WithUserAgent(userAgent))
+	if serviceConfig := options.serviceConfig(); serviceConfig != "" {
+		dialOptions = append(dialOptions, grpc.WithDefaultServiceConfig(serviceConfig))
+	}
+	if options.debug {
+		dialOptions = append(dialOptions, grpc.WithChainUnaryInterceptor(debugTrailerInterceptor))
+	}
+	if options.language != "" {
+		dialOptions = append(dialOptions,
+			grpc.WithChainUnaryInterceptor(languageInterceptor(options.language)),
+			grpc.WithChainStreamInterceptor(languageStreamInterceptor(options.language)),
+		)
+	}
+	dialOptions = append(dialOptions, options.dialOptions...)
+	conn, err := grpc.Dial(target, dialOptions...)
 	if err != nil {
-		return nil, fmt.Errorf("连接服务器失败: %v", err)
+		return nil, fmt.Errorf("连接服务器失败: %w", err)
 	}
 
 	// 创建客户端
@@ -43,24 +96,30 @@ func (c *BookClient) Close() error {
 }
 
 // CreateBook 创建图书
-func (c *BookClient) CreateBook(title, author string, price float32, description string, publishYear int32) (string, error) {
-	// 创建上下文，设置超时时间
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+func (c *BookClient) CreateBook(ctx context.Context, title, author string, price float32, description string, publishYear int32) (string, error) {
+	// 在调用方的上下文上设置超时时间，调用方取消时请求随之取消
+	ctx, cancel := context.WithTimeout(ctx, defaultCallTimeout)
 	defer cancel()
 
 	// 构建图书信息
 	book := &pb.Book{
-		Title:       title,
-		Author:      author,
-		Price:       price,
-		Description: description,
-		PublishYear: publishYear,
+		Title:  title,
+		Author: author,
+		Price:  proto.Float32(price),
+	}
+
+	// 描述为空、出版年份为0时不设置，由服务端填充默认值
+	if description != "" {
+		book.Description = proto.String(description)
+	}
+	if publishYear != 0 {
+		book.PublishYear = proto.Int32(publishYear)
 	}
 
 	// 发送创建图书请求
 	resp, err := c.client.CreateBook(ctx, &pb.CreateBookRequest{Book: book})
 	if err != nil {
-		return "", fmt.Errorf("创建图书失败: %v", err)
+		return "", fmt.Errorf("创建图书失败: %w", classifyDeadline(ctx, err))
 	}
 
 	log.Printf("✅ 图书创建成功，ID: %s", resp.Id)
@@ -68,15 +127,15 @@ func (c *BookClient) CreateBook(title, author string, price float32, description
 }
 
 // GetBook 获取图书信息
-func (c *BookClient) GetBook(bookID string) (*pb.Book, error) {
-	// 创建上下文，设置超时时间
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+func (c *BookClient) GetBook(ctx context.Context, bookID string) (*pb.Book, error) {
+	// 在调用方的上下文上设置超时时间，调用方取消时请求随之取消
+	ctx, cancel := context.WithTimeout(ctx, defaultCallTimeout)
 	defer cancel()
 
 	// 发送获取图书请求
 	resp, err := c.client.GetBook(ctx, &pb.GetBookRequest{Id: bookID})
 	if err != nil {
-		return nil, fmt.Errorf("获取图书失败: %v", err)
+		return nil, fmt.Errorf("获取图书失败: %w", classifyDeadline(ctx, err))
 	}
 
 	log.Printf("✅ 成功获取图书: %s", resp.Book.Title)
@@ -84,9 +143,9 @@ func (c *BookClient) GetBook(bookID string) (*pb.Book, error) {
 }
 
 // UpdateBook 更新图书信息
-func (c *BookClient) UpdateBook(bookID, title, author string, price float32, description string, publishYear int32) error {
-	// 创建上下文，设置超时时间
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+func (c *BookClient) UpdateBook(ctx context.Context, bookID, title, author string, price float32, description string, publishYear int32) error {
+	// 在调用方的上下文上设置超时时间，调用方取消时请求随之取消
+	ctx, cancel := context.WithTimeout(ctx, defaultCallTimeout)
 	defer cancel()
 
 	// 构建更新的图书信息
@@ -94,15 +153,15 @@ func (c *BookClient) UpdateBook(bookID, title, author string, price float32, des
 		Id:          bookID,
 		Title:       title,
 		Author:      author,
-		Price:       price,
-		Description: description,
-		PublishYear: publishYear,
+		Price:       proto.Float32(price),
+		Description: proto.String(description),
+		PublishYear: proto.Int32(publishYear),
 	}
 
 	// 发送更新图书请求
 	resp, err := c.client.UpdateBook(ctx, &pb.UpdateBookRequest{Book: book})
 	if err != nil {
-		return fmt.Errorf("更新图书失败: %v", err)
+		return fmt.Errorf("更新图书失败: %w", classifyDeadline(ctx, err))
 	}
 
 	log.Printf("✅ 图书更新成功: %s", resp.Message)
@@ -110,15 +169,15 @@ func (c *BookClient) UpdateBook(bookID, title, author string, price float32, des
 }
 
 // DeleteBook 删除图书
-func (c *BookClient) DeleteBook(bookID string) error {
-	// 创建上下文，设置超时时间
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+func (c *BookClient) DeleteBook(ctx context.Context, bookID string) error {
+	// 在调用方的上下文上设置超时时间，调用方取消时请求随之取消
+	ctx, cancel := context.WithTimeout(ctx, defaultCallTimeout)
 	defer cancel()
 
 	// 发送删除图书请求
 	resp, err := c.client.DeleteBook(ctx, &pb.DeleteBookRequest{Id: bookID})
 	if err != nil {
-		return fmt.Errorf("删除图书失败: %v", err)
+		return fmt.Errorf("删除图书失败: %w", classifyDeadline(ctx, err))
 	}
 
 	log.Printf("✅ 图书删除成功: %s", resp.Message)
@@ -126,9 +185,11 @@ func (c *BookClient) DeleteBook(bookID string) error {
 }
 
 // ListBooks 列出所有图书
-func (c *BookClient) ListBooks(page, pageSize int32) ([]*pb.Book, int32, error) {
-	// 创建上下文，设置超时时间
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+// truncated 为 true 表示响应超过服务端消息大小上限，当前页只返回了部分图书，
+// 此时应使用更小的 pageSize（即返回的图书数量）重新分页
+func (c *BookClient) ListBooks(ctx context.Context, page, pageSize int32) (books []*pb.Book, total int32, truncated bool, err error) {
+	// 在调用方的上下文上设置超时时间，调用方取消时请求随之取消
+	ctx, cancel := context.WithTimeout(ctx, defaultCallTimeout)
 	defer cancel()
 
 	// 发送列出图书请求
@@ -137,17 +198,20 @@ func (c *BookClient) ListBooks(page, pageSize int32) ([]*pb.Book, int32, error)
 		PageSize: pageSize,
 	})
 	if err != nil {
-		return nil, 0, fmt.Errorf("列出图书失败: %v", err)
+		return nil, 0, false, fmt.Errorf("列出图书失败: %w", classifyDeadline(ctx, err))
 	}
 
+	if resp.Truncated {
+		log.Printf("⚠️ 列出图书结果被截断，当前页只返回 %d 本图书", len(resp.Books))
+	}
 	log.Printf("✅ 成功列出图书，总数: %d, 当前页: %d", resp.Total, page)
-	return resp.Books, resp.Total, nil
+	return resp.Books, resp.Total, resp.Truncated, nil
 }
 
 // SearchBooksByPrice 按价格区间查询图书
-func (c *BookClient) SearchBooksByPrice(minPrice, maxPrice float32) ([]*pb.Book, error) {
-	// 创建上下文，设置超时时间
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+func (c *BookClient) SearchBooksByPrice(ctx context.Context, minPrice, maxPrice float32) ([]*pb.Book, error) {
+	// 在调用方的上下文上设置超时时间，调用方取消时请求随之取消
+	ctx, cancel := context.WithTimeout(ctx, defaultCallTimeout)
 	defer cancel()
 
 	// 发送按价格查询请求
@@ -156,150 +220,134 @@ func (c *BookClient) SearchBooksByPrice(minPrice, maxPrice float32) ([]*pb.Book,
 		MaxPrice: maxPrice,
 	})
 	if err != nil {
-		return nil, fmt.Errorf("按价格查询图书失败: %v", err)
+		return nil, fmt.Errorf("按价格查询图书失败: %w", classifyDeadline(ctx, err))
 	}
 
 	log.Printf("✅ 按价格查询完成，找到 %d 本图书", len(resp.Books))
 	return resp.Books, nil
 }
 
-// printBookInfo 打印图书信息
-func printBookInfo(book *pb.Book) {
-	fmt.Printf("📚 图书信息:\n")
-	fmt.Printf("   ID: %s\n", book.Id)
-	fmt.Printf("   标题: %s\n", book.Title)
-	fmt.Printf("   作者: %s\n", book.Author)
-	fmt.Printf("   价格: ¥%.2f\n", book.Price)
-	fmt.Printf("   描述: %s\n", book.Description)
-	fmt.Printf("   出版年份: %d\n", book.PublishYear)
-	fmt.Println()
-}
+// StreamBooks 流式获取所有图书
+// allowPartial 为 true 时，服务端在截止时间临近时会提前结束，truncated 表示结果不完整
+func (c *BookClient) StreamBooks(ctx context.Context, allowPartial bool) (books []*pb.Book, truncated bool, err error) {
+	// 在调用方的上下文上设置超时时间，调用方取消时请求随之取消
+	ctx, cancel := context.WithTimeout(ctx, defaultCallTimeout)
+	defer cancel()
 
-// printBookList 打印图书列表
-func printBookList(books []*pb.Book) {
-	if len(books) == 0 {
-		fmt.Println("📚 暂无图书")
-		return
+	// 发送流式获取图书请求
+	stream, err := c.client.StreamBooks(ctx, &pb.StreamBooksRequest{
+		AllowPartial: allowPartial,
+	})
+	if err != nil {
+		return nil, false, fmt.Errorf("流式获取图书失败: %w", classifyDeadline(ctx, err))
 	}
 
-	fmt.Printf("📚 图书列表 (共 %d 本):\n", len(books))
-	for i, book := range books {
-		fmt.Printf("%d. %s - %s (¥%.2f)\n", i+1, book.Title, book.Author, book.Price)
+	// 接收图书直到流结束
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, false, fmt.Errorf("流式获取图书失败: %w", classifyDeadline(ctx, err))
+		}
+		if resp.GetTruncated() {
+			truncated = true
+			continue
+		}
+		books = append(books, resp.GetBook())
 	}
-	fmt.Println()
-}
 
-func main() {
-	// 创建客户端
-	client, err := NewBookClient("localhost:50051")
-	if err != nil {
-		log.Fatalf("创建客户端失败: %v", err)
+	if truncated {
+		log.Printf("⚠️ 流式获取图书被截断，已收到 %d 本图书", len(books))
+	} else {
+		log.Printf("✅ 流式获取图书完成，共 %d 本图书", len(books))
 	}
-	defer client.Close()
+	return books, truncated, nil
+}
 
-	log.Println("🚀 开始演示图书管理服务...")
-	log.Println("==================================================")
-
-	// 演示1: 创建图书
-	log.Println("📝 演示1: 创建图书")
-	bookID1, err := client.CreateBook(
-		"The Go Programming Language",
-		"Alan A. A. Donovan",
-		45.99,
-		"Go语言的权威指南，适合初学者和有经验的开发者",
-		2015,
-	)
+// ExportBooks 按过滤条件流式导出图书，并将数据块依次写入 w，返回写入的字节数
+// 导出可能耗时较长，不设置默认超时，由调用方通过 ctx 控制
+func (c *BookClient) ExportBooks(ctx context.Context, filter *pb.BookFilter, format pb.ExportFormat, w io.Writer) (int64, error) {
+	// 发送流式导出请求
+	stream, err := c.client.StreamExport(ctx, &pb.StreamExportRequest{
+		Filter: filter,
+		Format: format,
+	})
 	if err != nil {
-		log.Printf("❌ 创建图书失败: %v", err)
+		return 0, fmt.Errorf("导出图书失败: %w", classifyDeadline(ctx, err))
 	}
 
-	_, err = client.CreateBook(
-		"Design Patterns",
-		"Erich Gamma",
-		39.99,
-		"面向对象设计模式的经典著作",
-		1994,
-	)
-	if err != nil {
-		log.Printf("❌ 创建图书失败: %v", err)
+	// 逐块写入，写入慢时接收随之变慢，由 gRPC 流控向服务端施加背压
+	var written int64
+	for {
+		chunk, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return written, fmt.Errorf("导出图书失败: %w", classifyDeadline(ctx, err))
+		}
+		n, err := w.Write(chunk.GetData())
+		written += int64(n)
+		if err != nil {
+			return written, fmt.Errorf("写入导出数据失败: %w", err)
+		}
 	}
 
-	bookID3, err := client.CreateBook(
-		"Clean Code",
-		"Robert C. Martin",
-		29.99,
-		"编写可维护代码的最佳实践",
-		2008,
-	)
-	if err != nil {
-		log.Printf("❌ 创建图书失败: %v", err)
-	}
+	log.Printf("✅ 导出图书完成，共 %d 字节", written)
+	return written, nil
+}
 
-	// 演示2: 获取图书信息
-	log.Println("📖 演示2: 获取图书信息")
-	book, err := client.GetBook(bookID1)
+func main() {
+	addr := flag.String("addr", "localhost:50051", "服务端地址，多个地址用逗号分隔，也可以是 unix:///path/to/socket 形式的 Unix 域套接字")
+	flag.StringVar(addr, "server", "localhost:50051", "同 -addr")
+	debug := flag.Bool("debug", false, "记录每次调用时服务端返回的响应尾部元数据")
+	lang := flag.String("lang", "", "错误信息的语言（如 en、zh-CN），服务端开启 -rich-errors 时生效")
+	format := flag.String("output", formatTable, "结果的输出格式：table、json 或 csv")
+	userAgent := flag.String("user-agent", "", "发送给服务端的 user-agent，默认为 "+clientName+"/"+clientVersion+" 及构建信息")
+	flag.Usage = usage
+	flag.Parse()
+
+	// 先解析子命令和输出格式，参数错误时不必连接服务端
+	cmd, err := parseCommand(flag.Args())
 	if err != nil {
-		log.Printf("❌ 获取图书失败: %v", err)
-	} else {
-		printBookInfo(book)
+		if !errors.Is(err, flag.ErrHelp) {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+		}
+		os.Exit(2)
 	}
-
-	// 演示3: 更新图书信息
-	log.Println("✏️ 演示3: 更新图书信息")
-	err = client.UpdateBook(
-		bookID1,
-		"The Go Programming Language (Updated)",
-		"Alan A. A. Donovan",
-		49.99,
-		"Go语言的权威指南，适合初学者和有经验的开发者 (更新版)",
-		2015,
-	)
+	out, err := newOutput(os.Stdout, *format)
 	if err != nil {
-		log.Printf("❌ 更新图书失败: %v", err)
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		os.Exit(2)
 	}
 
-	// 验证更新结果
-	updatedBook, err := client.GetBook(bookID1)
-	if err != nil {
-		log.Printf("❌ 获取更新后的图书失败: %v", err)
-	} else {
-		printBookInfo(updatedBook)
-	}
+	// 根上下文：收到 Ctrl-C 时取消所有进行中的调用
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
 
-	// 演示4: 列出所有图书
-	log.Println("📋 演示4: 列出所有图书")
-	books, total, err := client.ListBooks(1, 10)
-	if err != nil {
-		log.Printf("❌ 列出图书失败: %v", err)
-	} else {
-		fmt.Printf("总共有 %d 本图书\n", total)
-		printBookList(books)
+	// 创建客户端
+	var opts []ClientOption
+	if *debug {
+		opts = append(opts, WithDebug())
 	}
-
-	// 演示5: 按价格区间查询
-	log.Println("🔍 演示5: 按价格区间查询 (¥30-50)")
-	priceBooks, err := client.SearchBooksByPrice(30, 50)
-	if err != nil {
-		log.Printf("❌ 按价格查询失败: %v", err)
-	} else {
-		printBookList(priceBooks)
+	if *lang != "" {
+		opts = append(opts, WithLanguage(*lang))
 	}
-
-	// 演示6: 删除图书
-	log.Println("🗑️ 演示6: 删除图书")
-	err = client.DeleteBook(bookID3)
-	if err != nil {
-		log.Printf("❌ 删除图书失败: %v", err)
+	if *userAgent != "" {
+		opts = append(opts, WithUserAgent(*userAgent))
 	}
-
-	// 验证删除结果
-	log.Println("📋 删除后的图书列表:")
-	booksAfterDelete, _, err := client.ListBooks(1, 10)
+	client, err := NewBookClient(*addr, opts...)
 	if err != nil {
-		log.Printf("❌ 列出图书失败: %v", err)
-	} else {
-		printBookList(booksAfterDelete)
+		log.Fatalf("创建客户端失败: %v", err)
 	}
+	defer client.Close()
 
-	log.Println("🎉 演示完成!")
+	if err := cmd(ctx, client, out); err != nil {
+		log.Printf("❌ %v", err)
+		client.Close()
+		stop()
+		os.Exit(1)
+	}
 }
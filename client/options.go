@@ -0,0 +1,216 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"runtime/debug"
+	"sort"
+	"strings"
+	"time"
+
+	// 导入gRPC相关包
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+)
+
+// bookServiceName 图书服务的完整名称，用于服务配置
+const bookServiceName = "bookstore.BookService"
+
+// 客户端名称和版本号，默认的 user-agent 为 grpc-basic-client/版本号
+const (
+	clientName    = "grpc-basic-client"
+	clientVersion = "1.0.0"
+)
+
+// 重试退避参数
+const (
+	retryInitialBackoff = 100 * time.Millisecond
+	retryMaxBackoff     = time.Second
+)
+
+// clientOptions 客户端的可选配置
+type clientOptions struct {
+	dialOptions []grpc.DialOption
+
+	// 重试策略，maxAttempts 小于 2 时不重试
+	retryMaxAttempts int
+	retryableCodes   []codes.Code
+
+	// 重试预算（令牌桶），maxTokens 为 0 时不限制
+	retryMaxTokens  float64
+	retryTokenRatio float64
+
+	// 负载均衡策略，为空时使用 gRPC 默认策略（多地址时默认 round_robin）
+	loadBalancingPolicy string
+
+	// 是否记录服务端返回的响应尾部元数据
+	debug bool
+
+	// 通过 accept-language 元数据请求的错误信息语言，为空时不发送
+	language string
+
+	// 发送给服务端的 user-agent，为空时使用 defaultUserAgent
+	userAgent string
+}
+
+// ClientOption 图书客户端的可选配置
+type ClientOption func(*clientOptions)
+
+// WithDialOptions 追加建立连接时使用的 gRPC 选项
+func WithDialOptions(opts ...grpc.DialOption) ClientOption {
+	return func(o *clientOptions) {
+		o.dialOptions = append(o.dialOptions, opts...)
+	}
+}
+
+// WithRetry 开启自动重试，maxAttempts 为包含首次调用在内的最大尝试次数，
+// 仅在返回 retryableCodes 中的错误码时重试（默认 Unavailable）
+func WithRetry(maxAttempts int, retryableCodes ...codes.Code) ClientOption {
+	return func(o *clientOptions) {
+		o.retryMaxAttempts = maxAttempts
+		o.retryableCodes = retryableCodes
+		if len(o.retryableCodes) == 0 {
+			o.retryableCodes = []codes.Code{codes.Unavailable}
+		}
+	}
+}
+
+// WithRetryBudget 设置重试预算，避免服务端故障时形成重试风暴。
+// 与 gRPC 内置的重试限流一致：每次失败消耗 1 个令牌，每次成功归还 tokenRatio 个令牌，
+// 令牌数不高于 maxTokens/2 时停止重试，失败直接返回给调用方
+func WithRetryBudget(maxTokens, tokenRatio float64) ClientOption {
+	return func(o *clientOptions) {
+		o.retryMaxTokens = maxTokens
+		o.retryTokenRatio = tokenRatio
+	}
+}
+
+// WithLoadBalancingPolicy 设置负载均衡策略，如 round_robin、pick_first
+func WithLoadBalancingPolicy(policy string) ClientOption {
+	return func(o *clientOptions) {
+		o.loadBalancingPolicy = policy
+	}
+}
+
+// WithDebug 开启调试模式，记录每次一元调用时服务端返回的响应尾部元数据
+// （服务端版本、请求ID、处理耗时等）
+func WithDebug() ClientOption {
+	return func(o *clientOptions) {
+		o.debug = true
+	}
+}
+
+// WithLanguage 设置错误信息的语言（如 en、zh-CN），每次调用都在 accept-language 元数据中发送。
+// 服务端开启 -rich-errors 时按该语言返回错误信息，可以通过 LocalizedMessage 读取
+func WithLanguage(language string) ClientOption {
+	return func(o *clientOptions) {
+		o.language = language
+	}
+}
+
+// WithUserAgent 设置发送给服务端的 user-agent（gRPC 会在其后追加 grpc-go 的版本），
+// 服务端按调用记录在日志中，用于统计客户端版本；默认为 defaultUserAgent 的返回值
+func WithUserAgent(userAgent string) ClientOption {
+	return func(o *clientOptions) {
+		o.userAgent = userAgent
+	}
+}
+
+// defaultUserAgent 返回包含客户端版本和构建信息的 user-agent，如 grpc-basic-client/1.0.0 (go1.23.2; rev 1a2b3c4)。
+// 构建信息来自 Go 工具链嵌入的模块信息，不可用时（如 go run）只包含版本号
+func defaultUserAgent() string {
+	userAgent := clientName + "/" + clientVersion
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return userAgent
+	}
+
+	details := []string{info.GoVersion}
+	for _, setting := range info.Settings {
+		if setting.Key == "vcs.revision" && setting.Value != "" {
+			revision := setting.Value
+			if len(revision) > 7 {
+				revision = revision[:7]
+			}
+			details = append(details, "rev "+revision)
+		}
+	}
+	return fmt.Sprintf("%s (%s)", userAgent, strings.Join(details, "; "))
+}
+
+// languageInterceptor 在一元调用的元数据中附加 accept-language
+func languageInterceptor(language string) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		return invoker(metadata.AppendToOutgoingContext(ctx, "accept-language", language), method, req, reply, cc, opts...)
+	}
+}
+
+// languageStreamInterceptor 在流式调用的元数据中附加 accept-language
+func languageStreamInterceptor(language string) grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		return streamer(metadata.AppendToOutgoingContext(ctx, "accept-language", language), desc, cc, method, opts...)
+	}
+}
+
+// debugTrailerInterceptor 记录服务端响应尾部元数据的客户端拦截器
+func debugTrailerInterceptor(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	var trailer metadata.MD
+	err := invoker(ctx, method, req, reply, cc, append(opts, grpc.Trailer(&trailer))...)
+
+	keys := make([]string, 0, len(trailer))
+	for key := range trailer {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	pairs := make([]string, 0, len(keys))
+	for _, key := range keys {
+		pairs = append(pairs, key+"="+strings.Join(trailer[key], ","))
+	}
+	log.Printf("🔍 %s 响应尾部: %s", method, strings.Join(pairs, " "))
+
+	return err
+}
+
+// serviceConfig 根据配置生成 gRPC 服务配置 JSON，没有需要配置的内容时返回空字符串
+func (o *clientOptions) serviceConfig() string {
+	config := map[string]interface{}{}
+
+	if o.retryMaxAttempts > 1 {
+		config["methodConfig"] = []map[string]interface{}{{
+			"name": []map[string]string{{"service": bookServiceName}},
+			"retryPolicy": map[string]interface{}{
+				"maxAttempts":          o.retryMaxAttempts,
+				"initialBackoff":       formatDuration(retryInitialBackoff),
+				"maxBackoff":           formatDuration(retryMaxBackoff),
+				"backoffMultiplier":    2,
+				"retryableStatusCodes": o.retryableCodes,
+			},
+		}}
+	}
+	if o.retryMaxTokens > 0 {
+		config["retryThrottling"] = map[string]float64{
+			"maxTokens":  o.retryMaxTokens,
+			"tokenRatio": o.retryTokenRatio,
+		}
+	}
+
+	if o.loadBalancingPolicy != "" {
+		config["loadBalancingConfig"] = []map[string]interface{}{
+			{o.loadBalancingPolicy: map[string]interface{}{}},
+		}
+	}
+
+	if len(config) == 0 {
+		return ""
+	}
+	data, _ := json.Marshal(config)
+	return string(data)
+}
+
+// formatDuration 按服务配置要求的格式（如 "0.1s"）输出时长
+func formatDuration(d time.Duration) string {
+	return fmt.Sprintf("%gs", d.Seconds())
+}
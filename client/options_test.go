@@ -0,0 +1,138 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"os"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	// 导入生成的protobuf代码
+	pb "grpc-basic-client/pb"
+
+	// 导入gRPC相关包
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// unavailableServer 测试用的服务端，GetBook 总是返回 Unavailable 并统计调用次数
+type unavailableServer struct {
+	pb.UnimplementedBookServiceServer
+
+	attempts atomic.Int32
+}
+
+// GetBook 模拟持续故障
+func (s *unavailableServer) GetBook(ctx context.Context, req *pb.GetBookRequest) (*pb.GetBookResponse, error) {
+	s.attempts.Add(1)
+	return nil, status.Errorf(codes.Unavailable, "服务不可用")
+}
+
+// TestRetryBudget 测试持续失败时重试预算耗尽后不再重试
+func TestRetryBudget(t *testing.T) {
+	server := &unavailableServer{}
+	client := startTestClient(t, server, WithRetry(3), WithRetryBudget(10, 0.1))
+
+	// 持续调用，记录每次调用在服务端产生的尝试次数
+	var perCall []int32
+	for i := 0; i < 10; i++ {
+		before := server.attempts.Load()
+		_, err := client.GetBook(context.Background(), "book-1")
+		if status.Code(err) != codes.Unavailable {
+			t.Fatalf("期望错误码为Unavailable，实际为: %v", err)
+		}
+		perCall = append(perCall, server.attempts.Load()-before)
+	}
+
+	// 预算充足时会重试
+	if perCall[0] != 3 {
+		t.Errorf("第一次调用期望尝试3次，实际为: %d", perCall[0])
+	}
+
+	// 预算耗尽后每次调用只尝试一次
+	for i, n := range perCall[2:] {
+		if n != 1 {
+			t.Errorf("第 %d 次调用期望只尝试1次，实际为: %d", i+3, n)
+		}
+	}
+}
+
+// TestRetryWithoutBudget 测试未设置预算时每次调用都会重试
+func TestRetryWithoutBudget(t *testing.T) {
+	server := &unavailableServer{}
+	client := startTestClient(t, server, WithRetry(2))
+
+	for i := 0; i < 5; i++ {
+		client.GetBook(context.Background(), "book-1")
+	}
+	if n := server.attempts.Load(); n != 10 {
+		t.Errorf("期望共尝试10次，实际为: %d", n)
+	}
+}
+
+// trailerServer 测试用的服务端，GetBook 返回固定的响应尾部元数据
+type trailerServer struct {
+	pb.UnimplementedBookServiceServer
+}
+
+// GetBook 设置响应尾部元数据并返回图书
+func (s *trailerServer) GetBook(ctx context.Context, req *pb.GetBookRequest) (*pb.GetBookResponse, error) {
+	grpc.SetTrailer(ctx, metadata.Pairs("x-server-version", "1.0.0", "x-request-id", "req-1"))
+	return &pb.GetBookResponse{Book: &pb.Book{Id: req.GetId()}}, nil
+}
+
+// TestDebugLogsTrailer 测试调试模式下记录服务端的响应尾部元数据
+func TestDebugLogsTrailer(t *testing.T) {
+	client := startTestClient(t, &trailerServer{}, WithDebug())
+
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	defer log.SetOutput(os.Stderr)
+
+	if _, err := client.GetBook(context.Background(), "book-1"); err != nil {
+		t.Fatalf("获取图书失败: %v", err)
+	}
+	if out := buf.String(); !strings.Contains(out, "x-server-version=1.0.0") || !strings.Contains(out, "x-request-id=req-1") {
+		t.Errorf("日志中缺少响应尾部元数据: %s", out)
+	}
+}
+
+// userAgentServer 测试用的服务端，记录 GetBook 收到的 user-agent
+type userAgentServer struct {
+	pb.UnimplementedBookServiceServer
+
+	userAgent atomic.Value
+}
+
+// GetBook 记录调用方的 user-agent
+func (s *userAgentServer) GetBook(ctx context.Context, req *pb.GetBookRequest) (*pb.GetBookResponse, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	s.userAgent.Store(strings.Join(md.Get("user-agent"), ","))
+	return &pb.GetBookResponse{Book: &pb.Book{Id: req.GetId()}}, nil
+}
+
+// TestUserAgent 测试默认和自定义的 user-agent 都发送给服务端
+func TestUserAgent(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		opts   []ClientOption
+		prefix string
+	}{
+		{"默认", nil, clientName + "/" + clientVersion},
+		{"自定义", []ClientOption{WithUserAgent("books-app/2.3.0")}, "books-app/2.3.0"},
+	} {
+		server := &userAgentServer{}
+		client := startTestClient(t, server, tc.opts...)
+		if _, err := client.GetBook(context.Background(), "book-1"); err != nil {
+			t.Fatalf("获取图书失败: %v", err)
+		}
+		got, _ := server.userAgent.Load().(string)
+		if !strings.HasPrefix(got, tc.prefix) || !strings.Contains(got, "grpc-go/") {
+			t.Errorf("%s: 期望 user-agent 以 %q 开头并包含 grpc-go 版本，实际为: %q", tc.name, tc.prefix, got)
+		}
+	}
+}
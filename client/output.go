@@ -0,0 +1,152 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"text/tabwriter"
+
+	// 导入生成的protobuf代码
+	pb "grpc-basic-client/pb"
+
+	// 导入gRPC相关包
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// 命令行支持的输出格式
+const (
+	// formatTable 便于阅读的表格，默认格式
+	formatTable = "table"
+	// formatJSON 每本图书按 protojson 输出，列表输出为 JSON 数组
+	formatJSON = "json"
+	// formatCSV 带表头的 CSV，列名与服务端 -seed-file 的 CSV 相同，可以直接导入
+	formatCSV = "csv"
+)
+
+// outputFormats 支持的输出格式
+var outputFormats = []string{formatTable, formatJSON, formatCSV}
+
+// csvHeader CSV 输出的表头
+var csvHeader = []string{"id", "title", "author", "price", "currency", "description", "publish_year"}
+
+// output 命令结果的输出目标和格式
+type output struct {
+	w      io.Writer
+	format string
+}
+
+// newOutput 创建输出，format 不受支持时返回错误
+func newOutput(w io.Writer, format string) (*output, error) {
+	for _, f := range outputFormats {
+		if f == format {
+			return &output{w: w, format: format}, nil
+		}
+	}
+	return nil, fmt.Errorf("不支持的输出格式: %s，可用的格式: table, json, csv", format)
+}
+
+// message 输出提示信息。只有表格格式输出提示，JSON/CSV 格式的输出只包含图书数据，便于交给其他工具处理
+func (o *output) message(format string, args ...interface{}) {
+	if o.format == formatTable {
+		fmt.Fprintf(o.w, format, args...)
+	}
+}
+
+// printBookInfo 按输出格式打印单本图书
+func printBookInfo(out *output, book *pb.Book) error {
+	switch out.format {
+	case formatJSON:
+		data, err := protojson.Marshal(book)
+		if err != nil {
+			return fmt.Errorf("序列化图书失败: %w", err)
+		}
+		return writeJSON(out.w, json.RawMessage(data))
+	case formatCSV:
+		return writeCSV(out.w, []*pb.Book{book})
+	}
+
+	fmt.Fprintf(out.w, "📚 图书信息:\n")
+	fmt.Fprintf(out.w, "   ID: %s\n", book.Id)
+	fmt.Fprintf(out.w, "   标题: %s\n", book.Title)
+	fmt.Fprintf(out.w, "   作者: %s\n", book.Author)
+	fmt.Fprintf(out.w, "   价格: %s\n", formatPrice(book))
+	fmt.Fprintf(out.w, "   描述: %s\n", book.GetDescription())
+	fmt.Fprintf(out.w, "   出版年份: %d\n", book.GetPublishYear())
+	fmt.Fprintln(out.w)
+	return nil
+}
+
+// printBookList 按输出格式打印图书列表
+func printBookList(out *output, books []*pb.Book) error {
+	switch out.format {
+	case formatJSON:
+		items := make([]json.RawMessage, 0, len(books))
+		for _, book := range books {
+			data, err := protojson.Marshal(book)
+			if err != nil {
+				return fmt.Errorf("序列化图书失败: %w", err)
+			}
+			items = append(items, data)
+		}
+		return writeJSON(out.w, items)
+	case formatCSV:
+		return writeCSV(out.w, books)
+	}
+
+	if len(books) == 0 {
+		fmt.Fprintln(out.w, "📚 暂无图书")
+		return nil
+	}
+
+	fmt.Fprintf(out.w, "📚 图书列表 (共 %d 本):\n", len(books))
+	tw := tabwriter.NewWriter(out.w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "#\tID\t标题\t作者\t价格\t出版年份")
+	for i, book := range books {
+		fmt.Fprintf(tw, "%d\t%s\t%s\t%s\t%s\t%d\n", i+1, book.Id, book.Title, book.Author, formatPrice(book), book.GetPublishYear())
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	fmt.Fprintln(out.w)
+	return nil
+}
+
+// formatPrice 格式化图书价格：人民币（或未设置币种）显示为 ¥29.99，其他币种显示为 29.99 USD
+func formatPrice(book *pb.Book) string {
+	if book.GetCurrency() == "" || book.GetCurrency() == "CNY" {
+		return fmt.Sprintf("¥%.2f", book.GetPrice())
+	}
+	return fmt.Sprintf("%.2f %s", book.GetPrice(), book.GetCurrency())
+}
+
+// writeJSON 以缩进格式写出 JSON，末尾带换行
+func writeJSON(w io.Writer, v interface{}) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(v)
+}
+
+// writeCSV 写出带表头的 CSV，未设置的描述和出版年份输出为空
+func writeCSV(w io.Writer, books []*pb.Book) error {
+	cw := csv.NewWriter(w)
+	cw.Write(csvHeader)
+	for _, book := range books {
+		year := ""
+		if book.PublishYear != nil {
+			year = strconv.Itoa(int(book.GetPublishYear()))
+		}
+		cw.Write([]string{
+			book.GetId(),
+			book.GetTitle(),
+			book.GetAuthor(),
+			strconv.FormatFloat(float64(book.GetPrice()), 'f', -1, 32),
+			book.GetCurrency(),
+			book.GetDescription(),
+			year,
+		})
+	}
+	cw.Flush()
+	return cw.Error()
+}
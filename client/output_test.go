@@ -0,0 +1,95 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"testing"
+
+	// 导入生成的protobuf代码
+	pb "grpc-basic-client/pb"
+
+	// 导入gRPC相关包
+	"google.golang.org/protobuf/proto"
+)
+
+// TestListJSONOutput 测试 list 子命令的 JSON 输出是合法的 JSON 数组，并包含图书的各个字段
+func TestListJSONOutput(t *testing.T) {
+	server := &memoryServer{books: map[string]*pb.Book{}}
+	client := startTestClient(t, server)
+	ctx := context.Background()
+
+	if _, err := client.CreateBook(ctx, "Clean Code", "Robert C. Martin", 29.99, "最佳实践", 2008); err != nil {
+		t.Fatalf("创建图书失败: %v", err)
+	}
+
+	cmd, err := parseCommand([]string{"list"})
+	if err != nil {
+		t.Fatalf("解析失败: %v", err)
+	}
+	var buf bytes.Buffer
+	out, err := newOutput(&buf, "json")
+	if err != nil {
+		t.Fatalf("创建输出失败: %v", err)
+	}
+	if err := cmd(ctx, client, out); err != nil {
+		t.Fatalf("执行失败: %v", err)
+	}
+
+	// 输出只包含图书数据，没有提示信息
+	var books []map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &books); err != nil {
+		t.Fatalf("输出不是合法的JSON数组: %v\n%s", err, buf.String())
+	}
+	if len(books) != 1 {
+		t.Fatalf("期望1本图书，实际为: %d", len(books))
+	}
+	book := books[0]
+	if book["id"] != "book-1" || book["title"] != "Clean Code" || book["author"] != "Robert C. Martin" || book["description"] != "最佳实践" {
+		t.Errorf("图书字段不正确: %v", book)
+	}
+	if price, ok := book["price"].(float64); !ok || float32(price) != 29.99 {
+		t.Errorf("价格不正确: %v", book["price"])
+	}
+	if year, ok := book["publishYear"].(float64); !ok || year != 2008 {
+		t.Errorf("出版年份不正确: %v", book["publishYear"])
+	}
+}
+
+// TestCSVOutput 测试 CSV 输出带表头，未设置的出版年份输出为空
+func TestCSVOutput(t *testing.T) {
+	var buf bytes.Buffer
+	out, err := newOutput(&buf, "csv")
+	if err != nil {
+		t.Fatalf("创建输出失败: %v", err)
+	}
+	books := []*pb.Book{
+		{Id: "book-1", Title: "图书, 第一卷", Author: "作者", Price: proto.Float32(10.5), PublishYear: proto.Int32(2001)},
+		{Id: "book-2", Title: "图书2", Author: "作者", Price: proto.Float32(20), Currency: "USD"},
+	}
+	if err := printBookList(out, books); err != nil {
+		t.Fatalf("输出失败: %v", err)
+	}
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("输出不是合法的CSV: %v", err)
+	}
+	if len(records) != 3 || records[0][0] != "id" {
+		t.Fatalf("期望表头和2行数据，实际为: %v", records)
+	}
+	if records[1][1] != "图书, 第一卷" || records[1][3] != "10.5" || records[1][6] != "2001" {
+		t.Errorf("第一行数据不正确: %v", records[1])
+	}
+	if records[2][4] != "USD" || records[2][6] != "" {
+		t.Errorf("第二行数据不正确: %v", records[2])
+	}
+}
+
+// TestNewOutputUnknownFormat 测试不支持的输出格式返回错误
+func TestNewOutputUnknownFormat(t *testing.T) {
+	if _, err := newOutput(&bytes.Buffer{}, "xml"); err == nil {
+		t.Error("期望不支持的输出格式返回错误")
+	}
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	// 导入生成的protobuf代码
+	pb "grpc-basic-client/pb"
+)
+
+// GetBooksParallel 并发获取多本图书，最多同时进行 maxConcurrency 个调用（小于1时按1处理）。
+// 返回成功获取的图书和每个失败ID的错误，重复的ID只获取一次；
+// ctx 取消后不再发起新的调用，尚未获取的ID记录为上下文错误
+func (c *BookClient) GetBooksParallel(ctx context.Context, ids []string, maxConcurrency int) (map[string]*pb.Book, map[string]error) {
+	if maxConcurrency < 1 {
+		maxConcurrency = 1
+	}
+
+	books := make(map[string]*pb.Book, len(ids))
+	errs := make(map[string]error)
+	var mu sync.Mutex
+
+	// 工作协程从 pending 中领取ID，数量即为最大并发数
+	pending := make(chan string)
+	var wg sync.WaitGroup
+	for i := 0; i < maxConcurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for id := range pending {
+				book, err := c.GetBook(ctx, id)
+
+				mu.Lock()
+				if err != nil {
+					errs[id] = err
+				} else {
+					books[id] = book
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+	seen := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+
+		select {
+		case pending <- id:
+		case <-ctx.Done():
+			mu.Lock()
+			errs[id] = fmt.Errorf("获取图书失败: %w", ctx.Err())
+			mu.Unlock()
+		}
+	}
+	close(pending)
+	wg.Wait()
+
+	return books, errs
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	// 导入生成的protobuf代码
+	pb "grpc-basic-client/pb"
+
+	// 导入gRPC相关包
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// delayedServer 测试用的服务端，GetBook 等待一小段时间后返回以请求ID命名的图书
+type delayedServer struct {
+	pb.UnimplementedBookServiceServer
+}
+
+// GetBook 返回图书，ID 为 missing 时返回 NotFound
+func (s *delayedServer) GetBook(ctx context.Context, req *pb.GetBookRequest) (*pb.GetBookResponse, error) {
+	time.Sleep(10 * time.Millisecond)
+	if req.GetId() == "missing" {
+		return nil, status.Errorf(codes.NotFound, "图书不存在")
+	}
+	return &pb.GetBookResponse{Book: &pb.Book{Id: req.GetId(), Title: "图书" + req.GetId()}}, nil
+}
+
+// TestGetBooksParallel 测试并发获取图书的结果和并发上限
+func TestGetBooksParallel(t *testing.T) {
+	// 统计同时进行中的调用数量的最大值
+	var inFlight, peak atomic.Int32
+	counter := grpc.WithUnaryInterceptor(func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		return invoker(ctx, method, req, reply, cc, opts...)
+	})
+	client := startTestClient(t, &delayedServer{}, WithDialOptions(counter))
+
+	ids := []string{"book-1", "book-2", "book-3", "book-4", "book-5", "book-6", "book-1", "missing"}
+	books, errs := client.GetBooksParallel(context.Background(), ids, 2)
+
+	if len(books) != 6 {
+		t.Errorf("期望获取6本图书，实际为: %d", len(books))
+	}
+	if books["book-3"].GetTitle() != "图书book-3" {
+		t.Errorf("期望图书book-3的书名正确，实际为: %v", books["book-3"])
+	}
+	if len(errs) != 1 || status.Code(errs["missing"]) != codes.NotFound {
+		t.Errorf("期望只有missing返回NotFound，实际为: %v", errs)
+	}
+	if p := peak.Load(); p > 2 {
+		t.Errorf("期望最多2个并发调用，实际为: %d", p)
+	}
+}
+
+// TestGetBooksParallelCanceled 测试上下文已取消时所有ID都返回错误
+func TestGetBooksParallelCanceled(t *testing.T) {
+	client := startTestClient(t, &delayedServer{})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	books, errs := client.GetBooksParallel(ctx, []string{"book-1", "book-2", "book-3"}, 1)
+	if len(books) != 0 || len(errs) != 3 {
+		t.Errorf("期望没有获取到图书且3个ID都返回错误，实际为: %d 本图书, %v", len(books), errs)
+	}
+}
@@ -15,6 +15,7 @@ import (
 	grpc "google.golang.org/grpc"
 	codes "google.golang.org/grpc/codes"
 	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
 )
 
 // This is a compile-time assertion to ensure that this generated file
@@ -23,12 +24,37 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	BookService_CreateBook_FullMethodName         = "/bookstore.BookService/CreateBook"
-	BookService_GetBook_FullMethodName            = "/bookstore.BookService/GetBook"
-	BookService_UpdateBook_FullMethodName         = "/bookstore.BookService/UpdateBook"
-	BookService_DeleteBook_FullMethodName         = "/bookstore.BookService/DeleteBook"
-	BookService_ListBooks_FullMethodName          = "/bookstore.BookService/ListBooks"
-	BookService_SearchBooksByPrice_FullMethodName = "/bookstore.BookService/SearchBooksByPrice"
+	BookService_CreateBook_FullMethodName               = "/bookstore.BookService/CreateBook"
+	BookService_GetBook_FullMethodName                  = "/bookstore.BookService/GetBook"
+	BookService_UpdateBook_FullMethodName               = "/bookstore.BookService/UpdateBook"
+	BookService_DeleteBook_FullMethodName               = "/bookstore.BookService/DeleteBook"
+	BookService_ListBooks_FullMethodName                = "/bookstore.BookService/ListBooks"
+	BookService_SearchBooksByPrice_FullMethodName       = "/bookstore.BookService/SearchBooksByPrice"
+	BookService_GetBooksByExactPrice_FullMethodName     = "/bookstore.BookService/GetBooksByExactPrice"
+	BookService_GetPriceStats_FullMethodName            = "/bookstore.BookService/GetPriceStats"
+	BookService_StreamPriceHistogram_FullMethodName     = "/bookstore.BookService/StreamPriceHistogram"
+	BookService_OpenSnapshot_FullMethodName             = "/bookstore.BookService/OpenSnapshot"
+	BookService_GetStats_FullMethodName                 = "/bookstore.BookService/GetStats"
+	BookService_AdjustPrices_FullMethodName             = "/bookstore.BookService/AdjustPrices"
+	BookService_RenameAuthor_FullMethodName             = "/bookstore.BookService/RenameAuthor"
+	BookService_FindDuplicates_FullMethodName           = "/bookstore.BookService/FindDuplicates"
+	BookService_GetRandomBook_FullMethodName            = "/bookstore.BookService/GetRandomBook"
+	BookService_ReplaceCatalog_FullMethodName           = "/bookstore.BookService/ReplaceCatalog"
+	BookService_SetFeatured_FullMethodName              = "/bookstore.BookService/SetFeatured"
+	BookService_UnsetFeatured_FullMethodName            = "/bookstore.BookService/UnsetFeatured"
+	BookService_ListFeaturedBooks_FullMethodName        = "/bookstore.BookService/ListFeaturedBooks"
+	BookService_PurchaseBook_FullMethodName             = "/bookstore.BookService/PurchaseBook"
+	BookService_RestockBook_FullMethodName              = "/bookstore.BookService/RestockBook"
+	BookService_ReserveBook_FullMethodName              = "/bookstore.BookService/ReserveBook"
+	BookService_ConfirmReservation_FullMethodName       = "/bookstore.BookService/ConfirmReservation"
+	BookService_CancelReservation_FullMethodName        = "/bookstore.BookService/CancelReservation"
+	BookService_StreamBooks_FullMethodName              = "/bookstore.BookService/StreamBooks"
+	BookService_SearchBooksByPriceRanges_FullMethodName = "/bookstore.BookService/SearchBooksByPriceRanges"
+	BookService_GetBooksByTitles_FullMethodName         = "/bookstore.BookService/GetBooksByTitles"
+	BookService_StreamExport_FullMethodName             = "/bookstore.BookService/StreamExport"
+	BookService_GetBooksBatchStream_FullMethodName      = "/bookstore.BookService/GetBooksBatchStream"
+	BookService_ValidateBooks_FullMethodName            = "/bookstore.BookService/ValidateBooks"
+	BookService_ListChangedSince_FullMethodName         = "/bookstore.BookService/ListChangedSince"
 )
 
 // BookServiceClient is the client API for BookService service.
@@ -47,8 +73,60 @@ type BookServiceClient interface {
 	DeleteBook(ctx context.Context, in *DeleteBookRequest, opts ...grpc.CallOption) (*DeleteBookResponse, error)
 	// 列出所有图书 - 一元RPC
 	ListBooks(ctx context.Context, in *ListBooksRequest, opts ...grpc.CallOption) (*ListBooksResponse, error)
-	// 按价格区间查询图书 - 一元RPC
+	// 按价格区间查询图书，匹配的图书超过 -max-search-results 时返回 FailedPrecondition，应改用 StreamBooks - 一元RPC
 	SearchBooksByPrice(ctx context.Context, in *SearchBooksByPriceRequest, opts ...grpc.CallOption) (*SearchBooksByPriceResponse, error)
+	// 按精确价格查询图书，等同于最低和最高价格相等的 SearchBooksByPrice - 一元RPC
+	GetBooksByExactPrice(ctx context.Context, in *GetBooksByExactPriceRequest, opts ...grpc.CallOption) (*SearchBooksByPriceResponse, error)
+	// 价格统计（数量、最低、最高、平均、中位数） - 一元RPC
+	GetPriceStats(ctx context.Context, in *GetPriceStatsRequest, opts ...grpc.CallOption) (*PriceStatsResponse, error)
+	// 先发送当前的价格分布，之后每当图书修改使分布变化时发送新的分布 - 服务端流式RPC
+	StreamPriceHistogram(ctx context.Context, in *StreamPriceHistogramRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[PriceHistogram], error)
+	// 打开只读快照，用于稳定分页 - 一元RPC
+	OpenSnapshot(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*SnapshotResponse, error)
+	// 获取服务运行状态 - 一元RPC
+	GetStats(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*StatsResponse, error)
+	// 按过滤条件批量调整价格 - 一元RPC
+	AdjustPrices(ctx context.Context, in *AdjustPricesRequest, opts ...grpc.CallOption) (*AdjustPricesResponse, error)
+	// 将匹配的作者名统一修改为新名称，用于合并不同写法的作者 - 一元RPC
+	RenameAuthor(ctx context.Context, in *RenameAuthorRequest, opts ...grpc.CallOption) (*RenameAuthorResponse, error)
+	// 查找疑似重复的图书，只读 - 一元RPC
+	FindDuplicates(ctx context.Context, in *FindDuplicatesRequest, opts ...grpc.CallOption) (*FindDuplicatesResponse, error)
+	// 从所有图书（或符合过滤条件的图书）中等概率随机返回一本 - 一元RPC
+	GetRandomBook(ctx context.Context, in *GetRandomBookRequest, opts ...grpc.CallOption) (*GetRandomBookResponse, error)
+	// 用客户端流式发送的完整目录替换当前图书：按标题和作者匹配已有图书，
+	// 新建、更新并删除不在新目录中的图书，所有修改一次性生效 - 客户端流式RPC
+	ReplaceCatalog(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[Book, ReplaceCatalogResponse], error)
+	// 设置推荐图书及其排序 - 一元RPC
+	SetFeatured(ctx context.Context, in *SetFeaturedRequest, opts ...grpc.CallOption) (*FeaturedResponse, error)
+	// 取消推荐图书 - 一元RPC
+	UnsetFeatured(ctx context.Context, in *UnsetFeaturedRequest, opts ...grpc.CallOption) (*FeaturedResponse, error)
+	// 按排序列出推荐图书 - 一元RPC
+	ListFeaturedBooks(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListFeaturedBooksResponse, error)
+	// 购买图书，扣减库存 - 一元RPC
+	PurchaseBook(ctx context.Context, in *PurchaseBookRequest, opts ...grpc.CallOption) (*PurchaseBookResponse, error)
+	// 补充图书库存 - 一元RPC
+	RestockBook(ctx context.Context, in *RestockBookRequest, opts ...grpc.CallOption) (*RestockBookResponse, error)
+	// 在有效期内预留库存 - 一元RPC
+	ReserveBook(ctx context.Context, in *ReserveBookRequest, opts ...grpc.CallOption) (*ReserveResponse, error)
+	// 确认预留，扣减库存 - 一元RPC
+	ConfirmReservation(ctx context.Context, in *ReservationRequest, opts ...grpc.CallOption) (*ReservationResponse, error)
+	// 取消预留，释放库存 - 一元RPC
+	CancelReservation(ctx context.Context, in *ReservationRequest, opts ...grpc.CallOption) (*ReservationResponse, error)
+	// 按ID顺序流式返回所有（或符合过滤条件的）图书 - 服务端流式RPC
+	StreamBooks(ctx context.Context, in *StreamBooksRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[StreamBooksResponse], error)
+	// 一次查询多个价格区间的图书 - 一元RPC
+	SearchBooksByPriceRanges(ctx context.Context, in *SearchBooksByPriceRangesRequest, opts ...grpc.CallOption) (*SearchBooksByPriceRangesResponse, error)
+	// 一次查询多个标题对应的图书 - 一元RPC
+	GetBooksByTitles(ctx context.Context, in *GetBooksByTitlesRequest, opts ...grpc.CallOption) (*GetBooksByTitlesResponse, error)
+	// 按过滤条件流式导出图书（JSON Lines 或 CSV） - 服务端流式RPC
+	StreamExport(ctx context.Context, in *StreamExportRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExportChunk], error)
+	// 流式批量获取图书，客户端分批发送ID，服务端返回找到的图书，
+	// 不存在的图书被跳过并通过响应尾部元数据报告 - 双向流式RPC
+	GetBooksBatchStream(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[GetBooksBatchRequest, Book], error)
+	// 按创建图书的规则校验客户端发送的每本图书，返回每本无效图书的原因，不修改存储 - 客户端流式RPC
+	ValidateBooks(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[Book, ValidateBooksResponse], error)
+	// 返回某个变更序号之后被修改和删除的图书，用于断开后的增量同步 - 一元RPC
+	ListChangedSince(ctx context.Context, in *ListChangedSinceRequest, opts ...grpc.CallOption) (*ListChangedSinceResponse, error)
 }
 
 type bookServiceClient struct {
@@ -119,6 +197,292 @@ func (c *bookServiceClient) SearchBooksByPrice(ctx context.Context, in *SearchBo
 	return out, nil
 }
 
+func (c *bookServiceClient) GetBooksByExactPrice(ctx context.Context, in *GetBooksByExactPriceRequest, opts ...grpc.CallOption) (*SearchBooksByPriceResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SearchBooksByPriceResponse)
+	err := c.cc.Invoke(ctx, BookService_GetBooksByExactPrice_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookServiceClient) GetPriceStats(ctx context.Context, in *GetPriceStatsRequest, opts ...grpc.CallOption) (*PriceStatsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PriceStatsResponse)
+	err := c.cc.Invoke(ctx, BookService_GetPriceStats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookServiceClient) StreamPriceHistogram(ctx context.Context, in *StreamPriceHistogramRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[PriceHistogram], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &BookService_ServiceDesc.Streams[0], BookService_StreamPriceHistogram_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[StreamPriceHistogramRequest, PriceHistogram]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type BookService_StreamPriceHistogramClient = grpc.ServerStreamingClient[PriceHistogram]
+
+func (c *bookServiceClient) OpenSnapshot(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*SnapshotResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SnapshotResponse)
+	err := c.cc.Invoke(ctx, BookService_OpenSnapshot_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookServiceClient) GetStats(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*StatsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StatsResponse)
+	err := c.cc.Invoke(ctx, BookService_GetStats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookServiceClient) AdjustPrices(ctx context.Context, in *AdjustPricesRequest, opts ...grpc.CallOption) (*AdjustPricesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AdjustPricesResponse)
+	err := c.cc.Invoke(ctx, BookService_AdjustPrices_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookServiceClient) RenameAuthor(ctx context.Context, in *RenameAuthorRequest, opts ...grpc.CallOption) (*RenameAuthorResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RenameAuthorResponse)
+	err := c.cc.Invoke(ctx, BookService_RenameAuthor_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookServiceClient) FindDuplicates(ctx context.Context, in *FindDuplicatesRequest, opts ...grpc.CallOption) (*FindDuplicatesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FindDuplicatesResponse)
+	err := c.cc.Invoke(ctx, BookService_FindDuplicates_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookServiceClient) GetRandomBook(ctx context.Context, in *GetRandomBookRequest, opts ...grpc.CallOption) (*GetRandomBookResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetRandomBookResponse)
+	err := c.cc.Invoke(ctx, BookService_GetRandomBook_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookServiceClient) ReplaceCatalog(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[Book, ReplaceCatalogResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &BookService_ServiceDesc.Streams[1], BookService_ReplaceCatalog_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[Book, ReplaceCatalogResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type BookService_ReplaceCatalogClient = grpc.ClientStreamingClient[Book, ReplaceCatalogResponse]
+
+func (c *bookServiceClient) SetFeatured(ctx context.Context, in *SetFeaturedRequest, opts ...grpc.CallOption) (*FeaturedResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FeaturedResponse)
+	err := c.cc.Invoke(ctx, BookService_SetFeatured_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookServiceClient) UnsetFeatured(ctx context.Context, in *UnsetFeaturedRequest, opts ...grpc.CallOption) (*FeaturedResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FeaturedResponse)
+	err := c.cc.Invoke(ctx, BookService_UnsetFeatured_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookServiceClient) ListFeaturedBooks(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListFeaturedBooksResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListFeaturedBooksResponse)
+	err := c.cc.Invoke(ctx, BookService_ListFeaturedBooks_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookServiceClient) PurchaseBook(ctx context.Context, in *PurchaseBookRequest, opts ...grpc.CallOption) (*PurchaseBookResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PurchaseBookResponse)
+	err := c.cc.Invoke(ctx, BookService_PurchaseBook_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookServiceClient) RestockBook(ctx context.Context, in *RestockBookRequest, opts ...grpc.CallOption) (*RestockBookResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RestockBookResponse)
+	err := c.cc.Invoke(ctx, BookService_RestockBook_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookServiceClient) ReserveBook(ctx context.Context, in *ReserveBookRequest, opts ...grpc.CallOption) (*ReserveResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReserveResponse)
+	err := c.cc.Invoke(ctx, BookService_ReserveBook_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookServiceClient) ConfirmReservation(ctx context.Context, in *ReservationRequest, opts ...grpc.CallOption) (*ReservationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReservationResponse)
+	err := c.cc.Invoke(ctx, BookService_ConfirmReservation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookServiceClient) CancelReservation(ctx context.Context, in *ReservationRequest, opts ...grpc.CallOption) (*ReservationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReservationResponse)
+	err := c.cc.Invoke(ctx, BookService_CancelReservation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookServiceClient) StreamBooks(ctx context.Context, in *StreamBooksRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[StreamBooksResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &BookService_ServiceDesc.Streams[2], BookService_StreamBooks_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[StreamBooksRequest, StreamBooksResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type BookService_StreamBooksClient = grpc.ServerStreamingClient[StreamBooksResponse]
+
+func (c *bookServiceClient) SearchBooksByPriceRanges(ctx context.Context, in *SearchBooksByPriceRangesRequest, opts ...grpc.CallOption) (*SearchBooksByPriceRangesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SearchBooksByPriceRangesResponse)
+	err := c.cc.Invoke(ctx, BookService_SearchBooksByPriceRanges_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookServiceClient) GetBooksByTitles(ctx context.Context, in *GetBooksByTitlesRequest, opts ...grpc.CallOption) (*GetBooksByTitlesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetBooksByTitlesResponse)
+	err := c.cc.Invoke(ctx, BookService_GetBooksByTitles_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookServiceClient) StreamExport(ctx context.Context, in *StreamExportRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExportChunk], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &BookService_ServiceDesc.Streams[3], BookService_StreamExport_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[StreamExportRequest, ExportChunk]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type BookService_StreamExportClient = grpc.ServerStreamingClient[ExportChunk]
+
+func (c *bookServiceClient) GetBooksBatchStream(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[GetBooksBatchRequest, Book], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &BookService_ServiceDesc.Streams[4], BookService_GetBooksBatchStream_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[GetBooksBatchRequest, Book]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type BookService_GetBooksBatchStreamClient = grpc.BidiStreamingClient[GetBooksBatchRequest, Book]
+
+func (c *bookServiceClient) ValidateBooks(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[Book, ValidateBooksResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &BookService_ServiceDesc.Streams[5], BookService_ValidateBooks_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[Book, ValidateBooksResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type BookService_ValidateBooksClient = grpc.ClientStreamingClient[Book, ValidateBooksResponse]
+
+func (c *bookServiceClient) ListChangedSince(ctx context.Context, in *ListChangedSinceRequest, opts ...grpc.CallOption) (*ListChangedSinceResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListChangedSinceResponse)
+	err := c.cc.Invoke(ctx, BookService_ListChangedSince_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // BookServiceServer is the server API for BookService service.
 // All implementations must embed UnimplementedBookServiceServer
 // for forward compatibility.
@@ -135,8 +499,60 @@ type BookServiceServer interface {
 	DeleteBook(context.Context, *DeleteBookRequest) (*DeleteBookResponse, error)
 	// 列出所有图书 - 一元RPC
 	ListBooks(context.Context, *ListBooksRequest) (*ListBooksResponse, error)
-	// 按价格区间查询图书 - 一元RPC
+	// 按价格区间查询图书，匹配的图书超过 -max-search-results 时返回 FailedPrecondition，应改用 StreamBooks - 一元RPC
 	SearchBooksByPrice(context.Context, *SearchBooksByPriceRequest) (*SearchBooksByPriceResponse, error)
+	// 按精确价格查询图书，等同于最低和最高价格相等的 SearchBooksByPrice - 一元RPC
+	GetBooksByExactPrice(context.Context, *GetBooksByExactPriceRequest) (*SearchBooksByPriceResponse, error)
+	// 价格统计（数量、最低、最高、平均、中位数） - 一元RPC
+	GetPriceStats(context.Context, *GetPriceStatsRequest) (*PriceStatsResponse, error)
+	// 先发送当前的价格分布，之后每当图书修改使分布变化时发送新的分布 - 服务端流式RPC
+	StreamPriceHistogram(*StreamPriceHistogramRequest, grpc.ServerStreamingServer[PriceHistogram]) error
+	// 打开只读快照，用于稳定分页 - 一元RPC
+	OpenSnapshot(context.Context, *emptypb.Empty) (*SnapshotResponse, error)
+	// 获取服务运行状态 - 一元RPC
+	GetStats(context.Context, *emptypb.Empty) (*StatsResponse, error)
+	// 按过滤条件批量调整价格 - 一元RPC
+	AdjustPrices(context.Context, *AdjustPricesRequest) (*AdjustPricesResponse, error)
+	// 将匹配的作者名统一修改为新名称，用于合并不同写法的作者 - 一元RPC
+	RenameAuthor(context.Context, *RenameAuthorRequest) (*RenameAuthorResponse, error)
+	// 查找疑似重复的图书，只读 - 一元RPC
+	FindDuplicates(context.Context, *FindDuplicatesRequest) (*FindDuplicatesResponse, error)
+	// 从所有图书（或符合过滤条件的图书）中等概率随机返回一本 - 一元RPC
+	GetRandomBook(context.Context, *GetRandomBookRequest) (*GetRandomBookResponse, error)
+	// 用客户端流式发送的完整目录替换当前图书：按标题和作者匹配已有图书，
+	// 新建、更新并删除不在新目录中的图书，所有修改一次性生效 - 客户端流式RPC
+	ReplaceCatalog(grpc.ClientStreamingServer[Book, ReplaceCatalogResponse]) error
+	// 设置推荐图书及其排序 - 一元RPC
+	SetFeatured(context.Context, *SetFeaturedRequest) (*FeaturedResponse, error)
+	// 取消推荐图书 - 一元RPC
+	UnsetFeatured(context.Context, *UnsetFeaturedRequest) (*FeaturedResponse, error)
+	// 按排序列出推荐图书 - 一元RPC
+	ListFeaturedBooks(context.Context, *emptypb.Empty) (*ListFeaturedBooksResponse, error)
+	// 购买图书，扣减库存 - 一元RPC
+	PurchaseBook(context.Context, *PurchaseBookRequest) (*PurchaseBookResponse, error)
+	// 补充图书库存 - 一元RPC
+	RestockBook(context.Context, *RestockBookRequest) (*RestockBookResponse, error)
+	// 在有效期内预留库存 - 一元RPC
+	ReserveBook(context.Context, *ReserveBookRequest) (*ReserveResponse, error)
+	// 确认预留，扣减库存 - 一元RPC
+	ConfirmReservation(context.Context, *ReservationRequest) (*ReservationResponse, error)
+	// 取消预留，释放库存 - 一元RPC
+	CancelReservation(context.Context, *ReservationRequest) (*ReservationResponse, error)
+	// 按ID顺序流式返回所有（或符合过滤条件的）图书 - 服务端流式RPC
+	StreamBooks(*StreamBooksRequest, grpc.ServerStreamingServer[StreamBooksResponse]) error
+	// 一次查询多个价格区间的图书 - 一元RPC
+	SearchBooksByPriceRanges(context.Context, *SearchBooksByPriceRangesRequest) (*SearchBooksByPriceRangesResponse, error)
+	// 一次查询多个标题对应的图书 - 一元RPC
+	GetBooksByTitles(context.Context, *GetBooksByTitlesRequest) (*GetBooksByTitlesResponse, error)
+	// 按过滤条件流式导出图书（JSON Lines 或 CSV） - 服务端流式RPC
+	StreamExport(*StreamExportRequest, grpc.ServerStreamingServer[ExportChunk]) error
+	// 流式批量获取图书，客户端分批发送ID，服务端返回找到的图书，
+	// 不存在的图书被跳过并通过响应尾部元数据报告 - 双向流式RPC
+	GetBooksBatchStream(grpc.BidiStreamingServer[GetBooksBatchRequest, Book]) error
+	// 按创建图书的规则校验客户端发送的每本图书，返回每本无效图书的原因，不修改存储 - 客户端流式RPC
+	ValidateBooks(grpc.ClientStreamingServer[Book, ValidateBooksResponse]) error
+	// 返回某个变更序号之后被修改和删除的图书，用于断开后的增量同步 - 一元RPC
+	ListChangedSince(context.Context, *ListChangedSinceRequest) (*ListChangedSinceResponse, error)
 	mustEmbedUnimplementedBookServiceServer()
 }
 
@@ -165,6 +581,81 @@ func (UnimplementedBookServiceServer) ListBooks(context.Context, *ListBooksReque
 func (UnimplementedBookServiceServer) SearchBooksByPrice(context.Context, *SearchBooksByPriceRequest) (*SearchBooksByPriceResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SearchBooksByPrice not implemented")
 }
+func (UnimplementedBookServiceServer) GetBooksByExactPrice(context.Context, *GetBooksByExactPriceRequest) (*SearchBooksByPriceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBooksByExactPrice not implemented")
+}
+func (UnimplementedBookServiceServer) GetPriceStats(context.Context, *GetPriceStatsRequest) (*PriceStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPriceStats not implemented")
+}
+func (UnimplementedBookServiceServer) StreamPriceHistogram(*StreamPriceHistogramRequest, grpc.ServerStreamingServer[PriceHistogram]) error {
+	return status.Errorf(codes.Unimplemented, "method StreamPriceHistogram not implemented")
+}
+func (UnimplementedBookServiceServer) OpenSnapshot(context.Context, *emptypb.Empty) (*SnapshotResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method OpenSnapshot not implemented")
+}
+func (UnimplementedBookServiceServer) GetStats(context.Context, *emptypb.Empty) (*StatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStats not implemented")
+}
+func (UnimplementedBookServiceServer) AdjustPrices(context.Context, *AdjustPricesRequest) (*AdjustPricesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AdjustPrices not implemented")
+}
+func (UnimplementedBookServiceServer) RenameAuthor(context.Context, *RenameAuthorRequest) (*RenameAuthorResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RenameAuthor not implemented")
+}
+func (UnimplementedBookServiceServer) FindDuplicates(context.Context, *FindDuplicatesRequest) (*FindDuplicatesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FindDuplicates not implemented")
+}
+func (UnimplementedBookServiceServer) GetRandomBook(context.Context, *GetRandomBookRequest) (*GetRandomBookResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRandomBook not implemented")
+}
+func (UnimplementedBookServiceServer) ReplaceCatalog(grpc.ClientStreamingServer[Book, ReplaceCatalogResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method ReplaceCatalog not implemented")
+}
+func (UnimplementedBookServiceServer) SetFeatured(context.Context, *SetFeaturedRequest) (*FeaturedResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetFeatured not implemented")
+}
+func (UnimplementedBookServiceServer) UnsetFeatured(context.Context, *UnsetFeaturedRequest) (*FeaturedResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnsetFeatured not implemented")
+}
+func (UnimplementedBookServiceServer) ListFeaturedBooks(context.Context, *emptypb.Empty) (*ListFeaturedBooksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListFeaturedBooks not implemented")
+}
+func (UnimplementedBookServiceServer) PurchaseBook(context.Context, *PurchaseBookRequest) (*PurchaseBookResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PurchaseBook not implemented")
+}
+func (UnimplementedBookServiceServer) RestockBook(context.Context, *RestockBookRequest) (*RestockBookResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestockBook not implemented")
+}
+func (UnimplementedBookServiceServer) ReserveBook(context.Context, *ReserveBookRequest) (*ReserveResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReserveBook not implemented")
+}
+func (UnimplementedBookServiceServer) ConfirmReservation(context.Context, *ReservationRequest) (*ReservationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ConfirmReservation not implemented")
+}
+func (UnimplementedBookServiceServer) CancelReservation(context.Context, *ReservationRequest) (*ReservationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelReservation not implemented")
+}
+func (UnimplementedBookServiceServer) StreamBooks(*StreamBooksRequest, grpc.ServerStreamingServer[StreamBooksResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method StreamBooks not implemented")
+}
+func (UnimplementedBookServiceServer) SearchBooksByPriceRanges(context.Context, *SearchBooksByPriceRangesRequest) (*SearchBooksByPriceRangesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchBooksByPriceRanges not implemented")
+}
+func (UnimplementedBookServiceServer) GetBooksByTitles(context.Context, *GetBooksByTitlesRequest) (*GetBooksByTitlesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBooksByTitles not implemented")
+}
+func (UnimplementedBookServiceServer) StreamExport(*StreamExportRequest, grpc.ServerStreamingServer[ExportChunk]) error {
+	return status.Errorf(codes.Unimplemented, "method StreamExport not implemented")
+}
+func (UnimplementedBookServiceServer) GetBooksBatchStream(grpc.BidiStreamingServer[GetBooksBatchRequest, Book]) error {
+	return status.Errorf(codes.Unimplemented, "method GetBooksBatchStream not implemented")
+}
+func (UnimplementedBookServiceServer) ValidateBooks(grpc.ClientStreamingServer[Book, ValidateBooksResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method ValidateBooks not implemented")
+}
+func (UnimplementedBookServiceServer) ListChangedSince(context.Context, *ListChangedSinceRequest) (*ListChangedSinceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListChangedSince not implemented")
+}
 func (UnimplementedBookServiceServer) mustEmbedUnimplementedBookServiceServer() {}
 func (UnimplementedBookServiceServer) testEmbeddedByValue()                     {}
 
@@ -294,38 +785,542 @@ func _BookService_SearchBooksByPrice_Handler(srv interface{}, ctx context.Contex
 	return interceptor(ctx, in, info, handler)
 }
 
-// BookService_ServiceDesc is the grpc.ServiceDesc for BookService service.
-// It's only intended for direct use with grpc.RegisterService,
-// and not to be introspected or modified (even as a copy)
-var BookService_ServiceDesc = grpc.ServiceDesc{
-	ServiceName: "bookstore.BookService",
-	HandlerType: (*BookServiceServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "CreateBook",
-			Handler:    _BookService_CreateBook_Handler,
-		},
-		{
-			MethodName: "GetBook",
-			Handler:    _BookService_GetBook_Handler,
-		},
-		{
-			MethodName: "UpdateBook",
-			Handler:    _BookService_UpdateBook_Handler,
-		},
-		{
-			MethodName: "DeleteBook",
-			Handler:    _BookService_DeleteBook_Handler,
-		},
-		{
-			MethodName: "ListBooks",
-			Handler:    _BookService_ListBooks_Handler,
-		},
-		{
-			MethodName: "SearchBooksByPrice",
-			Handler:    _BookService_SearchBooksByPrice_Handler,
+func _BookService_GetBooksByExactPrice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBooksByExactPriceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookServiceServer).GetBooksByExactPrice(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BookService_GetBooksByExactPrice_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookServiceServer).GetBooksByExactPrice(ctx, req.(*GetBooksByExactPriceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookService_GetPriceStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPriceStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookServiceServer).GetPriceStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BookService_GetPriceStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookServiceServer).GetPriceStats(ctx, req.(*GetPriceStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookService_StreamPriceHistogram_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamPriceHistogramRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(BookServiceServer).StreamPriceHistogram(m, &grpc.GenericServerStream[StreamPriceHistogramRequest, PriceHistogram]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type BookService_StreamPriceHistogramServer = grpc.ServerStreamingServer[PriceHistogram]
+
+func _BookService_OpenSnapshot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookServiceServer).OpenSnapshot(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BookService_OpenSnapshot_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookServiceServer).OpenSnapshot(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookService_GetStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookServiceServer).GetStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BookService_GetStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookServiceServer).GetStats(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookService_AdjustPrices_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AdjustPricesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookServiceServer).AdjustPrices(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BookService_AdjustPrices_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookServiceServer).AdjustPrices(ctx, req.(*AdjustPricesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookService_RenameAuthor_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RenameAuthorRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookServiceServer).RenameAuthor(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BookService_RenameAuthor_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookServiceServer).RenameAuthor(ctx, req.(*RenameAuthorRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookService_FindDuplicates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FindDuplicatesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookServiceServer).FindDuplicates(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BookService_FindDuplicates_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookServiceServer).FindDuplicates(ctx, req.(*FindDuplicatesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookService_GetRandomBook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRandomBookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookServiceServer).GetRandomBook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BookService_GetRandomBook_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookServiceServer).GetRandomBook(ctx, req.(*GetRandomBookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookService_ReplaceCatalog_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(BookServiceServer).ReplaceCatalog(&grpc.GenericServerStream[Book, ReplaceCatalogResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type BookService_ReplaceCatalogServer = grpc.ClientStreamingServer[Book, ReplaceCatalogResponse]
+
+func _BookService_SetFeatured_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetFeaturedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookServiceServer).SetFeatured(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BookService_SetFeatured_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookServiceServer).SetFeatured(ctx, req.(*SetFeaturedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookService_UnsetFeatured_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnsetFeaturedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookServiceServer).UnsetFeatured(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BookService_UnsetFeatured_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookServiceServer).UnsetFeatured(ctx, req.(*UnsetFeaturedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookService_ListFeaturedBooks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookServiceServer).ListFeaturedBooks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BookService_ListFeaturedBooks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookServiceServer).ListFeaturedBooks(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookService_PurchaseBook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PurchaseBookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookServiceServer).PurchaseBook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BookService_PurchaseBook_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookServiceServer).PurchaseBook(ctx, req.(*PurchaseBookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookService_RestockBook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestockBookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookServiceServer).RestockBook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BookService_RestockBook_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookServiceServer).RestockBook(ctx, req.(*RestockBookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookService_ReserveBook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReserveBookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookServiceServer).ReserveBook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BookService_ReserveBook_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookServiceServer).ReserveBook(ctx, req.(*ReserveBookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookService_ConfirmReservation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReservationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookServiceServer).ConfirmReservation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BookService_ConfirmReservation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookServiceServer).ConfirmReservation(ctx, req.(*ReservationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookService_CancelReservation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReservationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookServiceServer).CancelReservation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BookService_CancelReservation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookServiceServer).CancelReservation(ctx, req.(*ReservationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookService_StreamBooks_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamBooksRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(BookServiceServer).StreamBooks(m, &grpc.GenericServerStream[StreamBooksRequest, StreamBooksResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type BookService_StreamBooksServer = grpc.ServerStreamingServer[StreamBooksResponse]
+
+func _BookService_SearchBooksByPriceRanges_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchBooksByPriceRangesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookServiceServer).SearchBooksByPriceRanges(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BookService_SearchBooksByPriceRanges_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookServiceServer).SearchBooksByPriceRanges(ctx, req.(*SearchBooksByPriceRangesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookService_GetBooksByTitles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBooksByTitlesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookServiceServer).GetBooksByTitles(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BookService_GetBooksByTitles_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookServiceServer).GetBooksByTitles(ctx, req.(*GetBooksByTitlesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookService_StreamExport_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamExportRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(BookServiceServer).StreamExport(m, &grpc.GenericServerStream[StreamExportRequest, ExportChunk]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type BookService_StreamExportServer = grpc.ServerStreamingServer[ExportChunk]
+
+func _BookService_GetBooksBatchStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(BookServiceServer).GetBooksBatchStream(&grpc.GenericServerStream[GetBooksBatchRequest, Book]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type BookService_GetBooksBatchStreamServer = grpc.BidiStreamingServer[GetBooksBatchRequest, Book]
+
+func _BookService_ValidateBooks_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(BookServiceServer).ValidateBooks(&grpc.GenericServerStream[Book, ValidateBooksResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type BookService_ValidateBooksServer = grpc.ClientStreamingServer[Book, ValidateBooksResponse]
+
+func _BookService_ListChangedSince_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListChangedSinceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookServiceServer).ListChangedSince(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BookService_ListChangedSince_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookServiceServer).ListChangedSince(ctx, req.(*ListChangedSinceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// BookService_ServiceDesc is the grpc.ServiceDesc for BookService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var BookService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "bookstore.BookService",
+	HandlerType: (*BookServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateBook",
+			Handler:    _BookService_CreateBook_Handler,
+		},
+		{
+			MethodName: "GetBook",
+			Handler:    _BookService_GetBook_Handler,
+		},
+		{
+			MethodName: "UpdateBook",
+			Handler:    _BookService_UpdateBook_Handler,
+		},
+		{
+			MethodName: "DeleteBook",
+			Handler:    _BookService_DeleteBook_Handler,
+		},
+		{
+			MethodName: "ListBooks",
+			Handler:    _BookService_ListBooks_Handler,
+		},
+		{
+			MethodName: "SearchBooksByPrice",
+			Handler:    _BookService_SearchBooksByPrice_Handler,
+		},
+		{
+			MethodName: "GetBooksByExactPrice",
+			Handler:    _BookService_GetBooksByExactPrice_Handler,
+		},
+		{
+			MethodName: "GetPriceStats",
+			Handler:    _BookService_GetPriceStats_Handler,
+		},
+		{
+			MethodName: "OpenSnapshot",
+			Handler:    _BookService_OpenSnapshot_Handler,
+		},
+		{
+			MethodName: "GetStats",
+			Handler:    _BookService_GetStats_Handler,
+		},
+		{
+			MethodName: "AdjustPrices",
+			Handler:    _BookService_AdjustPrices_Handler,
+		},
+		{
+			MethodName: "RenameAuthor",
+			Handler:    _BookService_RenameAuthor_Handler,
+		},
+		{
+			MethodName: "FindDuplicates",
+			Handler:    _BookService_FindDuplicates_Handler,
+		},
+		{
+			MethodName: "GetRandomBook",
+			Handler:    _BookService_GetRandomBook_Handler,
+		},
+		{
+			MethodName: "SetFeatured",
+			Handler:    _BookService_SetFeatured_Handler,
+		},
+		{
+			MethodName: "UnsetFeatured",
+			Handler:    _BookService_UnsetFeatured_Handler,
+		},
+		{
+			MethodName: "ListFeaturedBooks",
+			Handler:    _BookService_ListFeaturedBooks_Handler,
+		},
+		{
+			MethodName: "PurchaseBook",
+			Handler:    _BookService_PurchaseBook_Handler,
+		},
+		{
+			MethodName: "RestockBook",
+			Handler:    _BookService_RestockBook_Handler,
+		},
+		{
+			MethodName: "ReserveBook",
+			Handler:    _BookService_ReserveBook_Handler,
+		},
+		{
+			MethodName: "ConfirmReservation",
+			Handler:    _BookService_ConfirmReservation_Handler,
+		},
+		{
+			MethodName: "CancelReservation",
+			Handler:    _BookService_CancelReservation_Handler,
+		},
+		{
+			MethodName: "SearchBooksByPriceRanges",
+			Handler:    _BookService_SearchBooksByPriceRanges_Handler,
+		},
+		{
+			MethodName: "GetBooksByTitles",
+			Handler:    _BookService_GetBooksByTitles_Handler,
+		},
+		{
+			MethodName: "ListChangedSince",
+			Handler:    _BookService_ListChangedSince_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamPriceHistogram",
+			Handler:       _BookService_StreamPriceHistogram_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ReplaceCatalog",
+			Handler:       _BookService_ReplaceCatalog_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "StreamBooks",
+			Handler:       _BookService_StreamBooks_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "StreamExport",
+			Handler:       _BookService_StreamExport_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "GetBooksBatchStream",
+			Handler:       _BookService_GetBooksBatchStream_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "ValidateBooks",
+			Handler:       _BookService_ValidateBooks_Handler,
+			ClientStreams: true,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "protos/bookstore.proto",
 }
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"strings"
+
+	// 导入gRPC相关包
+	"google.golang.org/grpc/resolver"
+	"google.golang.org/grpc/resolver/manual"
+)
+
+// staticScheme 静态地址列表使用的解析器 scheme
+const staticScheme = "bookstatic"
+
+// splitAddrs 拆分逗号分隔的服务端地址列表
+func splitAddrs(serverAddr string) []string {
+	var addrs []string
+	for _, addr := range strings.Split(serverAddr, ",") {
+		if addr = strings.TrimSpace(addr); addr != "" {
+			addrs = append(addrs, addr)
+		}
+	}
+	return addrs
+}
+
+// staticResolver 创建返回固定地址列表的解析器，返回对应的拨号目标
+func staticResolver(addrs []string) (string, *manual.Resolver) {
+	r := manual.NewBuilderWithScheme(staticScheme)
+
+	state := resolver.State{}
+	for _, addr := range addrs {
+		state.Addresses = append(state.Addresses, resolver.Address{Addr: addr})
+	}
+	r.InitialState(state)
+
+	return staticScheme + ":///" + strings.Join(addrs, ","), r
+}
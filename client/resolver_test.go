@@ -0,0 +1,69 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sync/atomic"
+	"testing"
+
+	// 导入生成的protobuf代码
+	pb "grpc-basic-client/pb"
+
+	// 导入gRPC相关包
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// countingServer 测试用的服务端，统计收到的 GetBook 调用次数
+type countingServer struct {
+	pb.UnimplementedBookServiceServer
+
+	name  string
+	calls atomic.Int32
+}
+
+// GetBook 返回带有后端名称的图书
+func (s *countingServer) GetBook(ctx context.Context, req *pb.GetBookRequest) (*pb.GetBookResponse, error) {
+	s.calls.Add(1)
+	return &pb.GetBookResponse{Book: &pb.Book{Id: req.Id, Title: s.name}}, nil
+}
+
+// TestRoundRobinAcrossBackends 测试多地址时请求在各后端间轮询
+func TestRoundRobinAcrossBackends(t *testing.T) {
+	backends := map[string]*countingServer{}
+	listeners := map[string]*bufconn.Listener{}
+	for i := 1; i <= 2; i++ {
+		addr := fmt.Sprintf("backend-%d", i)
+		backends[addr] = &countingServer{name: addr}
+		listeners[addr] = bufconn.Listen(1 << 20)
+
+		s := grpc.NewServer()
+		pb.RegisterBookServiceServer(s, backends[addr])
+		go s.Serve(listeners[addr])
+		t.Cleanup(s.Stop)
+	}
+
+	// 按地址拨号到对应的 bufconn 后端
+	dialer := grpc.WithContextDialer(func(ctx context.Context, addr string) (net.Conn, error) {
+		return listeners[addr].DialContext(ctx)
+	})
+	client, err := NewBookClient("backend-1,backend-2", WithDialOptions(dialer))
+	if err != nil {
+		t.Fatalf("创建客户端失败: %v", err)
+	}
+	defer client.Close()
+
+	for i := 0; i < 20; i++ {
+		if _, err := client.GetBook(context.Background(), "book-1"); err != nil {
+			t.Fatalf("获取图书失败: %v", err)
+		}
+	}
+
+	// 两个后端都应收到请求
+	for addr, backend := range backends {
+		if backend.calls.Load() == 0 {
+			t.Errorf("后端 %s 没有收到请求", addr)
+		}
+	}
+}
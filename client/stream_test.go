@@ -0,0 +1,85 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	// 导入生成的protobuf代码
+	pb "grpc-basic-client/pb"
+
+	// 导入gRPC相关包
+	"google.golang.org/grpc"
+)
+
+// truncatingServer 测试用的服务端，StreamBooks 返回部分图书后发送截断标记
+type truncatingServer struct {
+	pb.UnimplementedBookServiceServer
+}
+
+// StreamBooks 发送两本图书和截断标记
+func (s *truncatingServer) StreamBooks(req *pb.StreamBooksRequest, stream grpc.ServerStreamingServer[pb.StreamBooksResponse]) error {
+	for _, id := range []string{"book-1", "book-2"} {
+		if err := stream.Send(&pb.StreamBooksResponse{Book: &pb.Book{Id: id}}); err != nil {
+			return err
+		}
+	}
+	if req.GetAllowPartial() {
+		return stream.Send(&pb.StreamBooksResponse{Truncated: true})
+	}
+	return nil
+}
+
+// TestStreamBooksTruncated 测试客户端返回部分结果和截断标记
+func TestStreamBooksTruncated(t *testing.T) {
+	client := startTestClient(t, &truncatingServer{})
+
+	books, truncated, err := client.StreamBooks(context.Background(), true)
+	if err != nil {
+		t.Fatalf("流式获取图书失败: %v", err)
+	}
+	if !truncated {
+		t.Errorf("期望结果被截断")
+	}
+	if len(books) != 2 {
+		t.Errorf("期望收到2本图书，实际为: %d", len(books))
+	}
+
+	_, truncated, err = client.StreamBooks(context.Background(), false)
+	if err != nil {
+		t.Fatalf("流式获取图书失败: %v", err)
+	}
+	if truncated {
+		t.Errorf("未允许部分结果时不应被截断")
+	}
+}
+
+// exportServer 测试用的服务端，StreamExport 分多个数据块返回固定内容
+type exportServer struct {
+	pb.UnimplementedBookServiceServer
+}
+
+// StreamExport 分块发送 CSV 内容
+func (s *exportServer) StreamExport(req *pb.StreamExportRequest, stream grpc.ServerStreamingServer[pb.ExportChunk]) error {
+	for _, chunk := range []string{"id,title\n", "book-1,图书1\n", "book-2,图书2\n"} {
+		if err := stream.Send(&pb.ExportChunk{Data: []byte(chunk)}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// TestExportBooks 测试客户端将导出的数据块按顺序写入 io.Writer
+func TestExportBooks(t *testing.T) {
+	client := startTestClient(t, &exportServer{})
+
+	var buf bytes.Buffer
+	n, err := client.ExportBooks(context.Background(), nil, pb.ExportFormat_EXPORT_FORMAT_CSV, &buf)
+	if err != nil {
+		t.Fatalf("导出图书失败: %v", err)
+	}
+	want := "id,title\nbook-1,图书1\nbook-2,图书2\n"
+	if buf.String() != want || n != int64(len(want)) {
+		t.Errorf("导出内容错误: %q（%d 字节）", buf.String(), n)
+	}
+}
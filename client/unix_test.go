@@ -0,0 +1,89 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"testing"
+
+	// 导入生成的protobuf代码
+	pb "grpc-basic-client/pb"
+
+	// 导入gRPC相关包
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// memoryServer 测试用的服务端，在内存中保存创建的图书
+type memoryServer struct {
+	pb.UnimplementedBookServiceServer
+
+	mu     sync.Mutex
+	books  map[string]*pb.Book
+	nextID int
+
+	// 每本图书被更新的次数，作为 etag
+	versions map[string]int
+}
+
+// CreateBook 保存图书并按创建顺序分配ID（book-1、book-2……）
+func (s *memoryServer) CreateBook(ctx context.Context, req *pb.CreateBookRequest) (*pb.CreateBookResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.nextID++
+	book := req.GetBook()
+	book.Id = fmt.Sprintf("book-%d", s.nextID)
+	s.books[book.Id] = book
+	return &pb.CreateBookResponse{Id: book.Id}, nil
+}
+
+// GetBook 返回保存的图书，响应头中的 etag 为图书的版本
+func (s *memoryServer) GetBook(ctx context.Context, req *pb.GetBookRequest) (*pb.GetBookResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	book, exists := s.books[req.GetId()]
+	if !exists {
+		return nil, status.Errorf(codes.NotFound, "图书不存在")
+	}
+	grpc.SetHeader(ctx, metadata.Pairs(etagHeader, strconv.Itoa(s.versions[req.GetId()])))
+	return &pb.GetBookResponse{Book: book}, nil
+}
+
+// TestUnixSocketTarget 测试客户端通过 unix:// 目标连接服务端
+func TestUnixSocketTarget(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bookstore.sock")
+	lis, err := net.Listen("unix", path)
+	if err != nil {
+		t.Fatalf("监听Unix域套接字失败: %v", err)
+	}
+	s := grpc.NewServer()
+	pb.RegisterBookServiceServer(s, &memoryServer{books: map[string]*pb.Book{}})
+	go s.Serve(lis)
+	t.Cleanup(s.Stop)
+
+	client, err := NewBookClient("unix://" + path)
+	if err != nil {
+		t.Fatalf("创建客户端失败: %v", err)
+	}
+	defer client.Close()
+
+	ctx := context.Background()
+	id, err := client.CreateBook(ctx, "图书", "作者", 10, "", 2020)
+	if err != nil {
+		t.Fatalf("创建图书失败: %v", err)
+	}
+	book, err := client.GetBook(ctx, id)
+	if err != nil {
+		t.Fatalf("获取图书失败: %v", err)
+	}
+	if book.GetTitle() != "图书" {
+		t.Errorf("期望书名为%q，实际为: %q", "图书", book.GetTitle())
+	}
+}
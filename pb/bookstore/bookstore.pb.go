@@ -13,6 +13,8 @@ package bookstore
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -25,17 +27,117 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// 重复图书的判定方式
+type DuplicateStrategy int32
+
+const (
+	DuplicateStrategy_DUPLICATE_STRATEGY_TITLE_AUTHOR DuplicateStrategy = 0 // 规范化后的标题和作者相同
+	DuplicateStrategy_DUPLICATE_STRATEGY_ISBN         DuplicateStrategy = 1 // 规范化后的 ISBN 相同，未设置 ISBN 的图书不参与分组
+)
+
+// Enum value maps for DuplicateStrategy.
+var (
+	DuplicateStrategy_name = map[int32]string{
+		0: "DUPLICATE_STRATEGY_TITLE_AUTHOR",
+		1: "DUPLICATE_STRATEGY_ISBN",
+	}
+	DuplicateStrategy_value = map[string]int32{
+		"DUPLICATE_STRATEGY_TITLE_AUTHOR": 0,
+		"DUPLICATE_STRATEGY_ISBN":         1,
+	}
+)
+
+func (x DuplicateStrategy) Enum() *DuplicateStrategy {
+	p := new(DuplicateStrategy)
+	*p = x
+	return p
+}
+
+func (x DuplicateStrategy) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DuplicateStrategy) Descriptor() protoreflect.EnumDescriptor {
+	return file_protos_bookstore_proto_enumTypes[0].Descriptor()
+}
+
+func (DuplicateStrategy) Type() protoreflect.EnumType {
+	return &file_protos_bookstore_proto_enumTypes[0]
+}
+
+func (x DuplicateStrategy) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use DuplicateStrategy.Descriptor instead.
+func (DuplicateStrategy) EnumDescriptor() ([]byte, []int) {
+	return file_protos_bookstore_proto_rawDescGZIP(), []int{0}
+}
+
+// 导出格式
+type ExportFormat int32
+
+const (
+	ExportFormat_EXPORT_FORMAT_JSONL ExportFormat = 0 // 每行一个 JSON 对象，字段名与 proto 定义一致
+	ExportFormat_EXPORT_FORMAT_CSV   ExportFormat = 1 // 带表头的 CSV
+)
+
+// Enum value maps for ExportFormat.
+var (
+	ExportFormat_name = map[int32]string{
+		0: "EXPORT_FORMAT_JSONL",
+		1: "EXPORT_FORMAT_CSV",
+	}
+	ExportFormat_value = map[string]int32{
+		"EXPORT_FORMAT_JSONL": 0,
+		"EXPORT_FORMAT_CSV":   1,
+	}
+)
+
+func (x ExportFormat) Enum() *ExportFormat {
+	p := new(ExportFormat)
+	*p = x
+	return p
+}
+
+func (x ExportFormat) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ExportFormat) Descriptor() protoreflect.EnumDescriptor {
+	return file_protos_bookstore_proto_enumTypes[1].Descriptor()
+}
+
+func (ExportFormat) Type() protoreflect.EnumType {
+	return &file_protos_bookstore_proto_enumTypes[1]
+}
+
+func (x ExportFormat) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ExportFormat.Descriptor instead.
+func (ExportFormat) EnumDescriptor() ([]byte, []int) {
+	return file_protos_bookstore_proto_rawDescGZIP(), []int{1}
+}
+
 // 图书信息消息定义
 type Book struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`                                       // 图书唯一标识符
-	Title         string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`                                 // 图书标题
-	Author        string                 `protobuf:"bytes,3,opt,name=author,proto3" json:"author,omitempty"`                               // 作者
-	Price         float32                `protobuf:"fixed32,4,opt,name=price,proto3" json:"price,omitempty"`                               // 价格
-	Description   string                 `protobuf:"bytes,5,opt,name=description,proto3" json:"description,omitempty"`                     // 图书描述
-	PublishYear   int32                  `protobuf:"varint,6,opt,name=publish_year,json=publishYear,proto3" json:"publish_year,omitempty"` // 出版年份
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`                                                      // 图书唯一标识符
+	Title           string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`                                                // 图书标题
+	Author          string                 `protobuf:"bytes,3,opt,name=author,proto3" json:"author,omitempty"`                                              // 作者
+	Price           *float32               `protobuf:"fixed32,4,opt,name=price,proto3,oneof" json:"price,omitempty"`                                        // 价格，更新时未设置则保留原值
+	Description     *string                `protobuf:"bytes,5,opt,name=description,proto3,oneof" json:"description,omitempty"`                              // 图书描述，创建时未设置则使用默认描述，更新时未设置则保留原值
+	PublishYear     *int32                 `protobuf:"varint,6,opt,name=publish_year,json=publishYear,proto3,oneof" json:"publish_year,omitempty"`          // 出版年份，创建时未设置则使用默认年份，更新时未设置则保留原值
+	Featured        bool                   `protobuf:"varint,7,opt,name=featured,proto3" json:"featured,omitempty"`                                         // 是否为推荐图书，仅能通过 SetFeatured/UnsetFeatured 修改
+	FeaturedRank    int32                  `protobuf:"varint,8,opt,name=featured_rank,json=featuredRank,proto3" json:"featured_rank,omitempty"`             // 推荐排序，数值越小越靠前
+	Stock           int32                  `protobuf:"varint,9,opt,name=stock,proto3" json:"stock,omitempty"`                                               // 库存数量，创建后仅能通过 PurchaseBook/RestockBook 修改
+	Isbn            string                 `protobuf:"bytes,14,opt,name=isbn,proto3" json:"isbn,omitempty"`                                                 // ISBN，设置后不可修改（见 -immutable-fields）
+	Currency        string                 `protobuf:"bytes,15,opt,name=currency,proto3" json:"currency,omitempty"`                                         // 价格的币种，ISO 4217 代码（如 CNY、USD），创建时为空则使用默认币种
+	LastModifiedSeq int64                  `protobuf:"varint,16,opt,name=last_modified_seq,json=lastModifiedSeq,proto3" json:"last_modified_seq,omitempty"` // 最后一次修改时所在存储的变更序号，由服务端设置，见 ListChangedSince
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
 func (x *Book) Reset() {
@@ -90,22 +192,64 @@ func (x *Book) GetAuthor() string {
 }
 
 func (x *Book) GetPrice() float32 {
-	if x != nil {
-		return x.Price
+	if x != nil && x.Price != nil {
+		return *x.Price
 	}
 	return 0
 }
 
 func (x *Book) GetDescription() string {
-	if x != nil {
-		return x.Description
+	if x != nil && x.Description != nil {
+		return *x.Description
 	}
 	return ""
 }
 
 func (x *Book) GetPublishYear() int32 {
+	if x != nil && x.PublishYear != nil {
+		return *x.PublishYear
+	}
+	return 0
+}
+
+func (x *Book) GetFeatured() bool {
 	if x != nil {
-		return x.PublishYear
+		return x.Featured
+	}
+	return false
+}
+
+func (x *Book) GetFeaturedRank() int32 {
+	if x != nil {
+		return x.FeaturedRank
+	}
+	return 0
+}
+
+func (x *Book) GetStock() int32 {
+	if x != nil {
+		return x.Stock
+	}
+	return 0
+}
+
+func (x *Book) GetIsbn() string {
+	if x != nil {
+		return x.Isbn
+	}
+	return ""
+}
+
+func (x *Book) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *Book) GetLastModifiedSeq() int64 {
+	if x != nil {
+		return x.LastModifiedSeq
 	}
 	return 0
 }
@@ -256,7 +400,8 @@ func (x *GetBookRequest) GetId() string {
 // 获取图书响应消息
 type GetBookResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Book          *Book                  `protobuf:"bytes,1,opt,name=book,proto3" json:"book,omitempty"` // 图书信息
+	Book          *Book                  `protobuf:"bytes,1,opt,name=book,proto3" json:"book,omitempty"`                                   // 图书信息
+	NotModified   bool                   `protobuf:"varint,2,opt,name=not_modified,json=notModified,proto3" json:"not_modified,omitempty"` // 请求携带的 if-none-match 与当前版本一致，图书未修改，book 为空
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -298,6 +443,13 @@ func (x *GetBookResponse) GetBook() *Book {
 	return nil
 }
 
+func (x *GetBookResponse) GetNotModified() bool {
+	if x != nil {
+		return x.NotModified
+	}
+	return false
+}
+
 // 更新图书请求消息
 type UpdateBookRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -390,10 +542,11 @@ func (x *UpdateBookResponse) GetMessage() string {
 
 // 删除图书请求消息
 type DeleteBookRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"` // 要删除的图书ID
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`                                                  // 要删除的图书ID
+	IgnoreNotFound bool                   `protobuf:"varint,2,opt,name=ignore_not_found,json=ignoreNotFound,proto3" json:"ignore_not_found,omitempty"` // 图书不存在时返回成功（deleted 为 false）而不是 NotFound，便于重试
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *DeleteBookRequest) Reset() {
@@ -433,10 +586,18 @@ func (x *DeleteBookRequest) GetId() string {
 	return ""
 }
 
+func (x *DeleteBookRequest) GetIgnoreNotFound() bool {
+	if x != nil {
+		return x.IgnoreNotFound
+	}
+	return false
+}
+
 // 删除图书响应消息
 type DeleteBookResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"` // 操作结果消息
+	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`  // 操作结果消息
+	Deleted       bool                   `protobuf:"varint,2,opt,name=deleted,proto3" json:"deleted,omitempty"` // 本次请求是否实际删除了图书
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -478,11 +639,19 @@ func (x *DeleteBookResponse) GetMessage() string {
 	return ""
 }
 
+func (x *DeleteBookResponse) GetDeleted() bool {
+	if x != nil {
+		return x.Deleted
+	}
+	return false
+}
+
 // 列出所有图书请求消息
 type ListBooksRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Page          int32                  `protobuf:"varint,1,opt,name=page,proto3" json:"page,omitempty"`                         // 页码
-	PageSize      int32                  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"` // 每页大小
+	Page          int32                  `protobuf:"varint,1,opt,name=page,proto3" json:"page,omitempty"`                                       // 页码
+	PageSize      int32                  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`               // 每页大小
+	SnapshotToken string                 `protobuf:"bytes,3,opt,name=snapshot_token,json=snapshotToken,proto3" json:"snapshot_token,omitempty"` // 可选的快照令牌，设置后基于快照分页
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -531,11 +700,20 @@ func (x *ListBooksRequest) GetPageSize() int32 {
 	return 0
 }
 
+func (x *ListBooksRequest) GetSnapshotToken() string {
+	if x != nil {
+		return x.SnapshotToken
+	}
+	return ""
+}
+
 // 列出所有图书响应消息
 type ListBooksResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Books         []*Book                `protobuf:"bytes,1,rep,name=books,proto3" json:"books,omitempty"`  // 图书列表
-	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"` // 总数量
+	Books         []*Book                `protobuf:"bytes,1,rep,name=books,proto3" json:"books,omitempty"`                        // 图书列表
+	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`                       // 总数量
+	Truncated     bool                   `protobuf:"varint,3,opt,name=truncated,proto3" json:"truncated,omitempty"`               // 响应超过消息大小上限，当前页只返回了部分图书
+	PageSize      int32                  `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"` // 实际返回的图书数量上限；被截断时客户端应使用该值重新分页
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -584,11 +762,28 @@ func (x *ListBooksResponse) GetTotal() int32 {
 	return 0
 }
 
-// 按价格区间查询图书请求
+func (x *ListBooksResponse) GetTruncated() bool {
+	if x != nil {
+		return x.Truncated
+	}
+	return false
+}
+
+func (x *ListBooksResponse) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+// 按价格区间查询图书请求，区间为闭区间 [min_price, max_price]，
+// 两者相等时只返回价格恰好等于该值的图书（见 GetBooksByExactPrice）
 type SearchBooksByPriceRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	MinPrice      float32                `protobuf:"fixed32,1,opt,name=min_price,json=minPrice,proto3" json:"min_price,omitempty"` // 最低价格
-	MaxPrice      float32                `protobuf:"fixed32,2,opt,name=max_price,json=maxPrice,proto3" json:"max_price,omitempty"` // 最高价格
+	MinPrice      float32                `protobuf:"fixed32,1,opt,name=min_price,json=minPrice,proto3" json:"min_price,omitempty"`              // 最低价格
+	MaxPrice      float32                `protobuf:"fixed32,2,opt,name=max_price,json=maxPrice,proto3" json:"max_price,omitempty"`              // 最高价格
+	SnapshotToken string                 `protobuf:"bytes,3,opt,name=snapshot_token,json=snapshotToken,proto3" json:"snapshot_token,omitempty"` // 可选的快照令牌，设置后基于快照查询
+	Currency      string                 `protobuf:"bytes,4,opt,name=currency,proto3" json:"currency,omitempty"`                                // 可选的币种，设置后只返回该币种的图书
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -637,6 +832,20 @@ func (x *SearchBooksByPriceRequest) GetMaxPrice() float32 {
 	return 0
 }
 
+func (x *SearchBooksByPriceRequest) GetSnapshotToken() string {
+	if x != nil {
+		return x.SnapshotToken
+	}
+	return ""
+}
+
+func (x *SearchBooksByPriceRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
 // 按价格区间查询图书响应
 type SearchBooksByPriceResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -682,127 +891,2999 @@ func (x *SearchBooksByPriceResponse) GetBooks() []*Book {
 	return nil
 }
 
-var File_protos_bookstore_proto protoreflect.FileDescriptor
+// 按精确价格查询图书请求
+type GetBooksByExactPriceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Price         float32                `protobuf:"fixed32,1,opt,name=price,proto3" json:"price,omitempty"`     // 价格，与图书价格按 float 精确比较
+	Currency      string                 `protobuf:"bytes,2,opt,name=currency,proto3" json:"currency,omitempty"` // 可选的币种，设置后只返回该币种的图书
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
 
-const file_protos_bookstore_proto_rawDesc = "" +
-	"\n" +
-	"\x16protos/bookstore.proto\x12\tbookstore\"\x9f\x01\n" +
-	"\x04Book\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
-	"\x05title\x18\x02 \x01(\tR\x05title\x12\x16\n" +
-	"\x06author\x18\x03 \x01(\tR\x06author\x12\x14\n" +
-	"\x05price\x18\x04 \x01(\x02R\x05price\x12 \n" +
-	"\vdescription\x18\x05 \x01(\tR\vdescription\x12!\n" +
-	"\fpublish_year\x18\x06 \x01(\x05R\vpublishYear\"8\n" +
-	"\x11CreateBookRequest\x12#\n" +
-	"\x04book\x18\x01 \x01(\v2\x0f.bookstore.BookR\x04book\">\n" +
-	"\x12CreateBookResponse\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\x12\x18\n" +
-	"\amessage\x18\x02 \x01(\tR\amessage\" \n" +
-	"\x0eGetBookRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\"6\n" +
-	"\x0fGetBookResponse\x12#\n" +
-	"\x04book\x18\x01 \x01(\v2\x0f.bookstore.BookR\x04book\"8\n" +
-	"\x11UpdateBookRequest\x12#\n" +
-	"\x04book\x18\x01 \x01(\v2\x0f.bookstore.BookR\x04book\".\n" +
-	"\x12UpdateBookResponse\x12\x18\n" +
-	"\amessage\x18\x01 \x01(\tR\amessage\"#\n" +
-	"\x11DeleteBookRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\".\n" +
-	"\x12DeleteBookResponse\x12\x18\n" +
-	"\amessage\x18\x01 \x01(\tR\amessage\"C\n" +
-	"\x10ListBooksRequest\x12\x12\n" +
-	"\x04page\x18\x01 \x01(\x05R\x04page\x12\x1b\n" +
-	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\"P\n" +
-	"\x11ListBooksResponse\x12%\n" +
-	"\x05books\x18\x01 \x03(\v2\x0f.bookstore.BookR\x05books\x12\x14\n" +
-	"\x05total\x18\x02 \x01(\x05R\x05total\"U\n" +
-	"\x19SearchBooksByPriceRequest\x12\x1b\n" +
-	"\tmin_price\x18\x01 \x01(\x02R\bminPrice\x12\x1b\n" +
-	"\tmax_price\x18\x02 \x01(\x02R\bmaxPrice\"C\n" +
-	"\x1aSearchBooksByPriceResponse\x12%\n" +
-	"\x05books\x18\x01 \x03(\v2\x0f.bookstore.BookR\x05books2\xdb\x03\n" +
-	"\vBookService\x12I\n" +
-	"\n" +
-	"CreateBook\x12\x1c.bookstore.CreateBookRequest\x1a\x1d.bookstore.CreateBookResponse\x12@\n" +
-	"\aGetBook\x12\x19.bookstore.GetBookRequest\x1a\x1a.bookstore.GetBookResponse\x12I\n" +
-	"\n" +
-	"UpdateBook\x12\x1c.bookstore.UpdateBookRequest\x1a\x1d.bookstore.UpdateBookResponse\x12I\n" +
-	"\n" +
-	"DeleteBook\x12\x1c.bookstore.DeleteBookRequest\x1a\x1d.bookstore.DeleteBookResponse\x12F\n" +
-	"\tListBooks\x12\x1b.bookstore.ListBooksRequest\x1a\x1c.bookstore.ListBooksResponse\x12a\n" +
-	"\x12SearchBooksByPrice\x12$.bookstore.SearchBooksByPriceRequest\x1a%.bookstore.SearchBooksByPriceResponseB\x0eZ\fpb/bookstoreb\x06proto3"
+func (x *GetBooksByExactPriceRequest) Reset() {
+	*x = GetBooksByExactPriceRequest{}
+	mi := &file_protos_bookstore_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
 
-var (
-	file_protos_bookstore_proto_rawDescOnce sync.Once
-	file_protos_bookstore_proto_rawDescData []byte
-)
+func (x *GetBooksByExactPriceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
 
-func file_protos_bookstore_proto_rawDescGZIP() []byte {
-	file_protos_bookstore_proto_rawDescOnce.Do(func() {
-		file_protos_bookstore_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_protos_bookstore_proto_rawDesc), len(file_protos_bookstore_proto_rawDesc)))
-	})
-	return file_protos_bookstore_proto_rawDescData
+func (*GetBooksByExactPriceRequest) ProtoMessage() {}
+
+func (x *GetBooksByExactPriceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_bookstore_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-var file_protos_bookstore_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
-var file_protos_bookstore_proto_goTypes = []any{
-	(*Book)(nil),                       // 0: bookstore.Book
-	(*CreateBookRequest)(nil),          // 1: bookstore.CreateBookRequest
-	(*CreateBookResponse)(nil),         // 2: bookstore.CreateBookResponse
-	(*GetBookRequest)(nil),             // 3: bookstore.GetBookRequest
-	(*GetBookResponse)(nil),            // 4: bookstore.GetBookResponse
-	(*UpdateBookRequest)(nil),          // 5: bookstore.UpdateBookRequest
-	(*UpdateBookResponse)(nil),         // 6: bookstore.UpdateBookResponse
-	(*DeleteBookRequest)(nil),          // 7: bookstore.DeleteBookRequest
-	(*DeleteBookResponse)(nil),         // 8: bookstore.DeleteBookResponse
-	(*ListBooksRequest)(nil),           // 9: bookstore.ListBooksRequest
-	(*ListBooksResponse)(nil),          // 10: bookstore.ListBooksResponse
-	(*SearchBooksByPriceRequest)(nil),  // 11: bookstore.SearchBooksByPriceRequest
-	(*SearchBooksByPriceResponse)(nil), // 12: bookstore.SearchBooksByPriceResponse
+// Deprecated: Use GetBooksByExactPriceRequest.ProtoReflect.Descriptor instead.
+func (*GetBooksByExactPriceRequest) Descriptor() ([]byte, []int) {
+	return file_protos_bookstore_proto_rawDescGZIP(), []int{13}
 }
-var file_protos_bookstore_proto_depIdxs = []int32{
-	0,  // 0: bookstore.CreateBookRequest.book:type_name -> bookstore.Book
-	0,  // 1: bookstore.GetBookResponse.book:type_name -> bookstore.Book
-	0,  // 2: bookstore.UpdateBookRequest.book:type_name -> bookstore.Book
-	0,  // 3: bookstore.ListBooksResponse.books:type_name -> bookstore.Book
-	0,  // 4: bookstore.SearchBooksByPriceResponse.books:type_name -> bookstore.Book
-	1,  // 5: bookstore.BookService.CreateBook:input_type -> bookstore.CreateBookRequest
-	3,  // 6: bookstore.BookService.GetBook:input_type -> bookstore.GetBookRequest
-	5,  // 7: bookstore.BookService.UpdateBook:input_type -> bookstore.UpdateBookRequest
-	7,  // 8: bookstore.BookService.DeleteBook:input_type -> bookstore.DeleteBookRequest
-	9,  // 9: bookstore.BookService.ListBooks:input_type -> bookstore.ListBooksRequest
-	11, // 10: bookstore.BookService.SearchBooksByPrice:input_type -> bookstore.SearchBooksByPriceRequest
-	2,  // 11: bookstore.BookService.CreateBook:output_type -> bookstore.CreateBookResponse
-	4,  // 12: bookstore.BookService.GetBook:output_type -> bookstore.GetBookResponse
-	6,  // 13: bookstore.BookService.UpdateBook:output_type -> bookstore.UpdateBookResponse
-	8,  // 14: bookstore.BookService.DeleteBook:output_type -> bookstore.DeleteBookResponse
-	10, // 15: bookstore.BookService.ListBooks:output_type -> bookstore.ListBooksResponse
-	12, // 16: bookstore.BookService.SearchBooksByPrice:output_type -> bookstore.SearchBooksByPriceResponse
-	11, // [11:17] is the sub-list for method output_type
-	5,  // [5:11] is the sub-list for method input_type
-	5,  // [5:5] is the sub-list for extension type_name
-	5,  // [5:5] is the sub-list for extension extendee
-	0,  // [0:5] is the sub-list for field type_name
+
+func (x *GetBooksByExactPriceRequest) GetPrice() float32 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
 }
 
-func init() { file_protos_bookstore_proto_init() }
-func file_protos_bookstore_proto_init() {
-	if File_protos_bookstore_proto != nil {
-		return
+func (x *GetBooksByExactPriceRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+// 图书过滤条件，供统计等查询复用
+type BookFilter struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MinPrice      float32                `protobuf:"fixed32,1,opt,name=min_price,json=minPrice,proto3" json:"min_price,omitempty"`         // 最低价格，0 表示不限
+	MaxPrice      float32                `protobuf:"fixed32,2,opt,name=max_price,json=maxPrice,proto3" json:"max_price,omitempty"`         // 最高价格，0 表示不限
+	Author        string                 `protobuf:"bytes,3,opt,name=author,proto3" json:"author,omitempty"`                               // 作者，为空表示不限（不区分大小写）
+	PublishYear   int32                  `protobuf:"varint,4,opt,name=publish_year,json=publishYear,proto3" json:"publish_year,omitempty"` // 出版年份，0 表示不限
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BookFilter) Reset() {
+	*x = BookFilter{}
+	mi := &file_protos_bookstore_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BookFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BookFilter) ProtoMessage() {}
+
+func (x *BookFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_bookstore_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BookFilter.ProtoReflect.Descriptor instead.
+func (*BookFilter) Descriptor() ([]byte, []int) {
+	return file_protos_bookstore_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *BookFilter) GetMinPrice() float32 {
+	if x != nil {
+		return x.MinPrice
+	}
+	return 0
+}
+
+func (x *BookFilter) GetMaxPrice() float32 {
+	if x != nil {
+		return x.MaxPrice
+	}
+	return 0
+}
+
+func (x *BookFilter) GetAuthor() string {
+	if x != nil {
+		return x.Author
+	}
+	return ""
+}
+
+func (x *BookFilter) GetPublishYear() int32 {
+	if x != nil {
+		return x.PublishYear
+	}
+	return 0
+}
+
+// 价格统计请求
+type GetPriceStatsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Filter        *BookFilter            `protobuf:"bytes,1,opt,name=filter,proto3" json:"filter,omitempty"` // 可选的过滤条件，为空时统计所有图书
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPriceStatsRequest) Reset() {
+	*x = GetPriceStatsRequest{}
+	mi := &file_protos_bookstore_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPriceStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPriceStatsRequest) ProtoMessage() {}
+
+func (x *GetPriceStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_bookstore_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPriceStatsRequest.ProtoReflect.Descriptor instead.
+func (*GetPriceStatsRequest) Descriptor() ([]byte, []int) {
+	return file_protos_bookstore_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *GetPriceStatsRequest) GetFilter() *BookFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+// 价格统计响应
+type PriceStatsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Count         int32                  `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`    // 参与统计的图书数量
+	Min           float32                `protobuf:"fixed32,2,opt,name=min,proto3" json:"min,omitempty"`       // 最低价格
+	Max           float32                `protobuf:"fixed32,3,opt,name=max,proto3" json:"max,omitempty"`       // 最高价格
+	Avg           float32                `protobuf:"fixed32,4,opt,name=avg,proto3" json:"avg,omitempty"`       // 平均价格
+	Median        float32                `protobuf:"fixed32,5,opt,name=median,proto3" json:"median,omitempty"` // 价格中位数
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PriceStatsResponse) Reset() {
+	*x = PriceStatsResponse{}
+	mi := &file_protos_bookstore_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PriceStatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PriceStatsResponse) ProtoMessage() {}
+
+func (x *PriceStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_bookstore_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PriceStatsResponse.ProtoReflect.Descriptor instead.
+func (*PriceStatsResponse) Descriptor() ([]byte, []int) {
+	return file_protos_bookstore_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *PriceStatsResponse) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *PriceStatsResponse) GetMin() float32 {
+	if x != nil {
+		return x.Min
+	}
+	return 0
+}
+
+func (x *PriceStatsResponse) GetMax() float32 {
+	if x != nil {
+		return x.Max
+	}
+	return 0
+}
+
+func (x *PriceStatsResponse) GetAvg() float32 {
+	if x != nil {
+		return x.Avg
+	}
+	return 0
+}
+
+func (x *PriceStatsResponse) GetMedian() float32 {
+	if x != nil {
+		return x.Median
+	}
+	return 0
+}
+
+// 流式价格分布请求
+type StreamPriceHistogramRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BucketWidth   float32                `protobuf:"fixed32,1,opt,name=bucket_width,json=bucketWidth,proto3" json:"bucket_width,omitempty"` // 价格区间宽度，必须大于0，且不能小到使区间序号超出 int64 范围
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamPriceHistogramRequest) Reset() {
+	*x = StreamPriceHistogramRequest{}
+	mi := &file_protos_bookstore_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamPriceHistogramRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamPriceHistogramRequest) ProtoMessage() {}
+
+func (x *StreamPriceHistogramRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_bookstore_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamPriceHistogramRequest.ProtoReflect.Descriptor instead.
+func (*StreamPriceHistogramRequest) Descriptor() ([]byte, []int) {
+	return file_protos_bookstore_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *StreamPriceHistogramRequest) GetBucketWidth() float32 {
+	if x != nil {
+		return x.BucketWidth
+	}
+	return 0
+}
+
+// 价格区间及其中的图书数量，区间为 [lower, upper)
+type PriceBucket struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Lower         float32                `protobuf:"fixed32,1,opt,name=lower,proto3" json:"lower,omitempty"` // 区间下限（包含）
+	Upper         float32                `protobuf:"fixed32,2,opt,name=upper,proto3" json:"upper,omitempty"` // 区间上限（不包含）
+	Count         int32                  `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`  // 区间内的图书数量
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PriceBucket) Reset() {
+	*x = PriceBucket{}
+	mi := &file_protos_bookstore_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PriceBucket) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PriceBucket) ProtoMessage() {}
+
+func (x *PriceBucket) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_bookstore_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PriceBucket.ProtoReflect.Descriptor instead.
+func (*PriceBucket) Descriptor() ([]byte, []int) {
+	return file_protos_bookstore_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *PriceBucket) GetLower() float32 {
+	if x != nil {
+		return x.Lower
+	}
+	return 0
+}
+
+func (x *PriceBucket) GetUpper() float32 {
+	if x != nil {
+		return x.Upper
+	}
+	return 0
+}
+
+func (x *PriceBucket) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+// 价格分布
+type PriceHistogram struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Buckets       []*PriceBucket         `protobuf:"bytes,1,rep,name=buckets,proto3" json:"buckets,omitempty"` // 非空的价格区间，按下限排序
+	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`    // 图书总数
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PriceHistogram) Reset() {
+	*x = PriceHistogram{}
+	mi := &file_protos_bookstore_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PriceHistogram) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PriceHistogram) ProtoMessage() {}
+
+func (x *PriceHistogram) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_bookstore_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PriceHistogram.ProtoReflect.Descriptor instead.
+func (*PriceHistogram) Descriptor() ([]byte, []int) {
+	return file_protos_bookstore_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *PriceHistogram) GetBuckets() []*PriceBucket {
+	if x != nil {
+		return x.Buckets
+	}
+	return nil
+}
+
+func (x *PriceHistogram) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+// 打开快照响应
+type SnapshotResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"` // 快照令牌，用于 ListBooks/SearchBooksByPrice
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SnapshotResponse) Reset() {
+	*x = SnapshotResponse{}
+	mi := &file_protos_bookstore_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SnapshotResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SnapshotResponse) ProtoMessage() {}
+
+func (x *SnapshotResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_bookstore_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SnapshotResponse.ProtoReflect.Descriptor instead.
+func (*SnapshotResponse) Descriptor() ([]byte, []int) {
+	return file_protos_bookstore_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *SnapshotResponse) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+// 服务运行状态响应
+type StatsResponse struct {
+	state            protoimpl.MessageState  `protogen:"open.v1"`
+	InFlightRequests int64                   `protobuf:"varint,1,opt,name=in_flight_requests,json=inFlightRequests,proto3" json:"in_flight_requests,omitempty"`                                                          // 正在处理中的请求数量（包含本次请求）
+	BookCount        int32                   `protobuf:"varint,2,opt,name=book_count,json=bookCount,proto3" json:"book_count,omitempty"`                                                                                 // 当前图书数量（所有租户合计）
+	RequestSizes     []*RequestSizeHistogram `protobuf:"bytes,3,rep,name=request_sizes,json=requestSizes,proto3" json:"request_sizes,omitempty"`                                                                         // 各方法的请求消息大小分布，按方法名排序
+	PanicsTotal      map[string]int64        `protobuf:"bytes,4,rep,name=panics_total,json=panicsTotal,proto3" json:"panics_total,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"` // 各方法处理时发生并被恢复的 panic 次数
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *StatsResponse) Reset() {
+	*x = StatsResponse{}
+	mi := &file_protos_bookstore_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatsResponse) ProtoMessage() {}
+
+func (x *StatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_bookstore_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StatsResponse.ProtoReflect.Descriptor instead.
+func (*StatsResponse) Descriptor() ([]byte, []int) {
+	return file_protos_bookstore_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *StatsResponse) GetInFlightRequests() int64 {
+	if x != nil {
+		return x.InFlightRequests
+	}
+	return 0
+}
+
+func (x *StatsResponse) GetBookCount() int32 {
+	if x != nil {
+		return x.BookCount
+	}
+	return 0
+}
+
+func (x *StatsResponse) GetRequestSizes() []*RequestSizeHistogram {
+	if x != nil {
+		return x.RequestSizes
+	}
+	return nil
+}
+
+func (x *StatsResponse) GetPanicsTotal() map[string]int64 {
+	if x != nil {
+		return x.PanicsTotal
+	}
+	return nil
+}
+
+// 单个方法的请求消息大小分布（一元方法）
+type RequestSizeHistogram struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Method        string                 `protobuf:"bytes,1,opt,name=method,proto3" json:"method,omitempty"`                                         // 完整方法名
+	BucketBounds  []int64                `protobuf:"varint,2,rep,packed,name=bucket_bounds,json=bucketBounds,proto3" json:"bucket_bounds,omitempty"` // 各桶的上限（字节，包含），最后一个桶没有上限
+	BucketCounts  []int64                `protobuf:"varint,3,rep,packed,name=bucket_counts,json=bucketCounts,proto3" json:"bucket_counts,omitempty"` // 各桶的请求数量，比 bucket_bounds 多一个
+	Count         int64                  `protobuf:"varint,4,opt,name=count,proto3" json:"count,omitempty"`                                          // 请求总数
+	Sum           int64                  `protobuf:"varint,5,opt,name=sum,proto3" json:"sum,omitempty"`                                              // 请求大小总和（字节）
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RequestSizeHistogram) Reset() {
+	*x = RequestSizeHistogram{}
+	mi := &file_protos_bookstore_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequestSizeHistogram) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestSizeHistogram) ProtoMessage() {}
+
+func (x *RequestSizeHistogram) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_bookstore_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestSizeHistogram.ProtoReflect.Descriptor instead.
+func (*RequestSizeHistogram) Descriptor() ([]byte, []int) {
+	return file_protos_bookstore_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *RequestSizeHistogram) GetMethod() string {
+	if x != nil {
+		return x.Method
+	}
+	return ""
+}
+
+func (x *RequestSizeHistogram) GetBucketBounds() []int64 {
+	if x != nil {
+		return x.BucketBounds
+	}
+	return nil
+}
+
+func (x *RequestSizeHistogram) GetBucketCounts() []int64 {
+	if x != nil {
+		return x.BucketCounts
+	}
+	return nil
+}
+
+func (x *RequestSizeHistogram) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *RequestSizeHistogram) GetSum() int64 {
+	if x != nil {
+		return x.Sum
+	}
+	return 0
+}
+
+// 批量调整价格请求，percent 与 fixed_delta 必须且只能设置一个
+type AdjustPricesRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Filter *BookFilter            `protobuf:"bytes,1,opt,name=filter,proto3" json:"filter,omitempty"` // 需要调整的图书范围，为空时调整所有图书
+	// Types that are valid to be assigned to Adjustment:
+	//
+	//	*AdjustPricesRequest_Percent
+	//	*AdjustPricesRequest_FixedDelta
+	Adjustment    isAdjustPricesRequest_Adjustment `protobuf_oneof:"adjustment"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AdjustPricesRequest) Reset() {
+	*x = AdjustPricesRequest{}
+	mi := &file_protos_bookstore_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdjustPricesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdjustPricesRequest) ProtoMessage() {}
+
+func (x *AdjustPricesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_bookstore_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdjustPricesRequest.ProtoReflect.Descriptor instead.
+func (*AdjustPricesRequest) Descriptor() ([]byte, []int) {
+	return file_protos_bookstore_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *AdjustPricesRequest) GetFilter() *BookFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+func (x *AdjustPricesRequest) GetAdjustment() isAdjustPricesRequest_Adjustment {
+	if x != nil {
+		return x.Adjustment
+	}
+	return nil
+}
+
+func (x *AdjustPricesRequest) GetPercent() float32 {
+	if x != nil {
+		if x, ok := x.Adjustment.(*AdjustPricesRequest_Percent); ok {
+			return x.Percent
+		}
+	}
+	return 0
+}
+
+func (x *AdjustPricesRequest) GetFixedDelta() float32 {
+	if x != nil {
+		if x, ok := x.Adjustment.(*AdjustPricesRequest_FixedDelta); ok {
+			return x.FixedDelta
+		}
+	}
+	return 0
+}
+
+type isAdjustPricesRequest_Adjustment interface {
+	isAdjustPricesRequest_Adjustment()
+}
+
+type AdjustPricesRequest_Percent struct {
+	Percent float32 `protobuf:"fixed32,2,opt,name=percent,proto3,oneof"` // 按百分比调整，如 -10 表示降价10%
+}
+
+type AdjustPricesRequest_FixedDelta struct {
+	FixedDelta float32 `protobuf:"fixed32,3,opt,name=fixed_delta,json=fixedDelta,proto3,oneof"` // 按固定金额调整，如 -5 表示每本降价5元
+}
+
+func (*AdjustPricesRequest_Percent) isAdjustPricesRequest_Adjustment() {}
+
+func (*AdjustPricesRequest_FixedDelta) isAdjustPricesRequest_Adjustment() {}
+
+// 批量调整价格响应
+type AdjustPricesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UpdatedCount  int32                  `protobuf:"varint,1,opt,name=updated_count,json=updatedCount,proto3" json:"updated_count,omitempty"` // 成功调整的图书数量
+	SkippedIds    []string               `protobuf:"bytes,2,rep,name=skipped_ids,json=skippedIds,proto3" json:"skipped_ids,omitempty"`        // 调整后价格不为正而跳过的图书ID
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AdjustPricesResponse) Reset() {
+	*x = AdjustPricesResponse{}
+	mi := &file_protos_bookstore_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdjustPricesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdjustPricesResponse) ProtoMessage() {}
+
+func (x *AdjustPricesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_bookstore_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdjustPricesResponse.ProtoReflect.Descriptor instead.
+func (*AdjustPricesResponse) Descriptor() ([]byte, []int) {
+	return file_protos_bookstore_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *AdjustPricesResponse) GetUpdatedCount() int32 {
+	if x != nil {
+		return x.UpdatedCount
+	}
+	return 0
+}
+
+func (x *AdjustPricesResponse) GetSkippedIds() []string {
+	if x != nil {
+		return x.SkippedIds
+	}
+	return nil
+}
+
+// 重命名作者请求
+type RenameAuthorRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	From          string                 `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"` // 原作者名，不区分大小写完全匹配
+	To            string                 `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`     // 新作者名
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RenameAuthorRequest) Reset() {
+	*x = RenameAuthorRequest{}
+	mi := &file_protos_bookstore_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RenameAuthorRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RenameAuthorRequest) ProtoMessage() {}
+
+func (x *RenameAuthorRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_bookstore_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RenameAuthorRequest.ProtoReflect.Descriptor instead.
+func (*RenameAuthorRequest) Descriptor() ([]byte, []int) {
+	return file_protos_bookstore_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *RenameAuthorRequest) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *RenameAuthorRequest) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+// 重命名作者响应
+type RenameAuthorResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UpdatedCount  int32                  `protobuf:"varint,1,opt,name=updated_count,json=updatedCount,proto3" json:"updated_count,omitempty"` // 修改的图书数量
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RenameAuthorResponse) Reset() {
+	*x = RenameAuthorResponse{}
+	mi := &file_protos_bookstore_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RenameAuthorResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RenameAuthorResponse) ProtoMessage() {}
+
+func (x *RenameAuthorResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_bookstore_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RenameAuthorResponse.ProtoReflect.Descriptor instead.
+func (*RenameAuthorResponse) Descriptor() ([]byte, []int) {
+	return file_protos_bookstore_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *RenameAuthorResponse) GetUpdatedCount() int32 {
+	if x != nil {
+		return x.UpdatedCount
+	}
+	return 0
+}
+
+// 查找重复图书请求
+type FindDuplicatesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Strategy      DuplicateStrategy      `protobuf:"varint,1,opt,name=strategy,proto3,enum=bookstore.DuplicateStrategy" json:"strategy,omitempty"` // 判定方式
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FindDuplicatesRequest) Reset() {
+	*x = FindDuplicatesRequest{}
+	mi := &file_protos_bookstore_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FindDuplicatesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FindDuplicatesRequest) ProtoMessage() {}
+
+func (x *FindDuplicatesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_bookstore_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FindDuplicatesRequest.ProtoReflect.Descriptor instead.
+func (*FindDuplicatesRequest) Descriptor() ([]byte, []int) {
+	return file_protos_bookstore_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *FindDuplicatesRequest) GetStrategy() DuplicateStrategy {
+	if x != nil {
+		return x.Strategy
+	}
+	return DuplicateStrategy_DUPLICATE_STRATEGY_TITLE_AUTHOR
+}
+
+// 一组疑似重复的图书
+type DuplicateGroup struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`     // 规范化后的分组键
+	Books         []*Book                `protobuf:"bytes,2,rep,name=books,proto3" json:"books,omitempty"` // 组内的图书，按ID排序
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DuplicateGroup) Reset() {
+	*x = DuplicateGroup{}
+	mi := &file_protos_bookstore_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DuplicateGroup) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DuplicateGroup) ProtoMessage() {}
+
+func (x *DuplicateGroup) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_bookstore_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DuplicateGroup.ProtoReflect.Descriptor instead.
+func (*DuplicateGroup) Descriptor() ([]byte, []int) {
+	return file_protos_bookstore_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *DuplicateGroup) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *DuplicateGroup) GetBooks() []*Book {
+	if x != nil {
+		return x.Books
+	}
+	return nil
+}
+
+// 查找重复图书响应
+type FindDuplicatesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Groups        []*DuplicateGroup      `protobuf:"bytes,1,rep,name=groups,proto3" json:"groups,omitempty"` // 成员多于一本的分组，按分组键排序
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FindDuplicatesResponse) Reset() {
+	*x = FindDuplicatesResponse{}
+	mi := &file_protos_bookstore_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FindDuplicatesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FindDuplicatesResponse) ProtoMessage() {}
+
+func (x *FindDuplicatesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_bookstore_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FindDuplicatesResponse.ProtoReflect.Descriptor instead.
+func (*FindDuplicatesResponse) Descriptor() ([]byte, []int) {
+	return file_protos_bookstore_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *FindDuplicatesResponse) GetGroups() []*DuplicateGroup {
+	if x != nil {
+		return x.Groups
+	}
+	return nil
+}
+
+// 随机获取图书请求
+type GetRandomBookRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Filter        *BookFilter            `protobuf:"bytes,1,opt,name=filter,proto3" json:"filter,omitempty"` // 可选的过滤条件，为空时从所有图书中选择
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRandomBookRequest) Reset() {
+	*x = GetRandomBookRequest{}
+	mi := &file_protos_bookstore_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRandomBookRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRandomBookRequest) ProtoMessage() {}
+
+func (x *GetRandomBookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_bookstore_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRandomBookRequest.ProtoReflect.Descriptor instead.
+func (*GetRandomBookRequest) Descriptor() ([]byte, []int) {
+	return file_protos_bookstore_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *GetRandomBookRequest) GetFilter() *BookFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+// 随机获取图书响应
+type GetRandomBookResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Book          *Book                  `protobuf:"bytes,1,opt,name=book,proto3" json:"book,omitempty"` // 随机选中的图书
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRandomBookResponse) Reset() {
+	*x = GetRandomBookResponse{}
+	mi := &file_protos_bookstore_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRandomBookResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRandomBookResponse) ProtoMessage() {}
+
+func (x *GetRandomBookResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_bookstore_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRandomBookResponse.ProtoReflect.Descriptor instead.
+func (*GetRandomBookResponse) Descriptor() ([]byte, []int) {
+	return file_protos_bookstore_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *GetRandomBookResponse) GetBook() *Book {
+	if x != nil {
+		return x.Book
+	}
+	return nil
+}
+
+// 全量替换图书目录响应
+type ReplaceCatalogResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Created       int32                  `protobuf:"varint,1,opt,name=created,proto3" json:"created,omitempty"` // 新建的图书数量
+	Updated       int32                  `protobuf:"varint,2,opt,name=updated,proto3" json:"updated,omitempty"` // 内容有变化而被更新的图书数量
+	Deleted       int32                  `protobuf:"varint,3,opt,name=deleted,proto3" json:"deleted,omitempty"` // 不在新目录中而被删除的图书数量
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReplaceCatalogResponse) Reset() {
+	*x = ReplaceCatalogResponse{}
+	mi := &file_protos_bookstore_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReplaceCatalogResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReplaceCatalogResponse) ProtoMessage() {}
+
+func (x *ReplaceCatalogResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_bookstore_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReplaceCatalogResponse.ProtoReflect.Descriptor instead.
+func (*ReplaceCatalogResponse) Descriptor() ([]byte, []int) {
+	return file_protos_bookstore_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *ReplaceCatalogResponse) GetCreated() int32 {
+	if x != nil {
+		return x.Created
+	}
+	return 0
+}
+
+func (x *ReplaceCatalogResponse) GetUpdated() int32 {
+	if x != nil {
+		return x.Updated
+	}
+	return 0
+}
+
+func (x *ReplaceCatalogResponse) GetDeleted() int32 {
+	if x != nil {
+		return x.Deleted
+	}
+	return 0
+}
+
+// 一本图书未通过校验的原因
+type BookViolation struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Index         int32                  `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`            // 图书在流中的位置，从0开始
+	Field         string                 `protobuf:"bytes,2,opt,name=field,proto3" json:"field,omitempty"`             // 无效的字段路径，如 book.title
+	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"` // 无效的原因
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BookViolation) Reset() {
+	*x = BookViolation{}
+	mi := &file_protos_bookstore_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BookViolation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BookViolation) ProtoMessage() {}
+
+func (x *BookViolation) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_bookstore_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BookViolation.ProtoReflect.Descriptor instead.
+func (*BookViolation) Descriptor() ([]byte, []int) {
+	return file_protos_bookstore_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *BookViolation) GetIndex() int32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *BookViolation) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *BookViolation) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+// 校验图书目录响应
+type ValidateBooksResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ValidCount    int32                  `protobuf:"varint,1,opt,name=valid_count,json=validCount,proto3" json:"valid_count,omitempty"` // 通过校验的图书数量
+	Violations    []*BookViolation       `protobuf:"bytes,2,rep,name=violations,proto3" json:"violations,omitempty"`                    // 未通过校验的图书，按流中的顺序排列
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ValidateBooksResponse) Reset() {
+	*x = ValidateBooksResponse{}
+	mi := &file_protos_bookstore_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValidateBooksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidateBooksResponse) ProtoMessage() {}
+
+func (x *ValidateBooksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_bookstore_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidateBooksResponse.ProtoReflect.Descriptor instead.
+func (*ValidateBooksResponse) Descriptor() ([]byte, []int) {
+	return file_protos_bookstore_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *ValidateBooksResponse) GetValidCount() int32 {
+	if x != nil {
+		return x.ValidCount
+	}
+	return 0
+}
+
+func (x *ValidateBooksResponse) GetViolations() []*BookViolation {
+	if x != nil {
+		return x.Violations
+	}
+	return nil
+}
+
+// 设置推荐图书请求
+type SetFeaturedRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`      // 图书ID
+	Rank          int32                  `protobuf:"varint,2,opt,name=rank,proto3" json:"rank,omitempty"` // 推荐排序，不能为负数，相同排序按ID排序
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetFeaturedRequest) Reset() {
+	*x = SetFeaturedRequest{}
+	mi := &file_protos_bookstore_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetFeaturedRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetFeaturedRequest) ProtoMessage() {}
+
+func (x *SetFeaturedRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_bookstore_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetFeaturedRequest.ProtoReflect.Descriptor instead.
+func (*SetFeaturedRequest) Descriptor() ([]byte, []int) {
+	return file_protos_bookstore_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *SetFeaturedRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *SetFeaturedRequest) GetRank() int32 {
+	if x != nil {
+		return x.Rank
+	}
+	return 0
+}
+
+// 取消推荐图书请求
+type UnsetFeaturedRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"` // 图书ID
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnsetFeaturedRequest) Reset() {
+	*x = UnsetFeaturedRequest{}
+	mi := &file_protos_bookstore_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnsetFeaturedRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnsetFeaturedRequest) ProtoMessage() {}
+
+func (x *UnsetFeaturedRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_bookstore_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnsetFeaturedRequest.ProtoReflect.Descriptor instead.
+func (*UnsetFeaturedRequest) Descriptor() ([]byte, []int) {
+	return file_protos_bookstore_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *UnsetFeaturedRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+// 推荐图书操作响应
+type FeaturedResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"` // 操作结果消息
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FeaturedResponse) Reset() {
+	*x = FeaturedResponse{}
+	mi := &file_protos_bookstore_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FeaturedResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FeaturedResponse) ProtoMessage() {}
+
+func (x *FeaturedResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_bookstore_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FeaturedResponse.ProtoReflect.Descriptor instead.
+func (*FeaturedResponse) Descriptor() ([]byte, []int) {
+	return file_protos_bookstore_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *FeaturedResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// 推荐图书列表响应
+type ListFeaturedBooksResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Books         []*Book                `protobuf:"bytes,1,rep,name=books,proto3" json:"books,omitempty"` // 按推荐排序排列的图书列表
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListFeaturedBooksResponse) Reset() {
+	*x = ListFeaturedBooksResponse{}
+	mi := &file_protos_bookstore_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListFeaturedBooksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListFeaturedBooksResponse) ProtoMessage() {}
+
+func (x *ListFeaturedBooksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_bookstore_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListFeaturedBooksResponse.ProtoReflect.Descriptor instead.
+func (*ListFeaturedBooksResponse) Descriptor() ([]byte, []int) {
+	return file_protos_bookstore_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *ListFeaturedBooksResponse) GetBooks() []*Book {
+	if x != nil {
+		return x.Books
+	}
+	return nil
+}
+
+// 购买图书请求
+type PurchaseBookRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`              // 图书ID
+	Quantity      int32                  `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"` // 购买数量，必须大于0
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PurchaseBookRequest) Reset() {
+	*x = PurchaseBookRequest{}
+	mi := &file_protos_bookstore_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PurchaseBookRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PurchaseBookRequest) ProtoMessage() {}
+
+func (x *PurchaseBookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_bookstore_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PurchaseBookRequest.ProtoReflect.Descriptor instead.
+func (*PurchaseBookRequest) Descriptor() ([]byte, []int) {
+	return file_protos_bookstore_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *PurchaseBookRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *PurchaseBookRequest) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+// 购买图书响应
+type PurchaseBookResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	RemainingStock int32                  `protobuf:"varint,1,opt,name=remaining_stock,json=remainingStock,proto3" json:"remaining_stock,omitempty"` // 购买后的剩余库存
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *PurchaseBookResponse) Reset() {
+	*x = PurchaseBookResponse{}
+	mi := &file_protos_bookstore_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PurchaseBookResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PurchaseBookResponse) ProtoMessage() {}
+
+func (x *PurchaseBookResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_bookstore_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PurchaseBookResponse.ProtoReflect.Descriptor instead.
+func (*PurchaseBookResponse) Descriptor() ([]byte, []int) {
+	return file_protos_bookstore_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *PurchaseBookResponse) GetRemainingStock() int32 {
+	if x != nil {
+		return x.RemainingStock
+	}
+	return 0
+}
+
+// 补充库存请求
+type RestockBookRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`              // 图书ID
+	Quantity      int32                  `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"` // 补充数量，必须大于0
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestockBookRequest) Reset() {
+	*x = RestockBookRequest{}
+	mi := &file_protos_bookstore_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestockBookRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestockBookRequest) ProtoMessage() {}
+
+func (x *RestockBookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_bookstore_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestockBookRequest.ProtoReflect.Descriptor instead.
+func (*RestockBookRequest) Descriptor() ([]byte, []int) {
+	return file_protos_bookstore_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *RestockBookRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *RestockBookRequest) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+// 补充库存响应
+type RestockBookResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Stock         int32                  `protobuf:"varint,1,opt,name=stock,proto3" json:"stock,omitempty"` // 补充后的库存
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestockBookResponse) Reset() {
+	*x = RestockBookResponse{}
+	mi := &file_protos_bookstore_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestockBookResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestockBookResponse) ProtoMessage() {}
+
+func (x *RestockBookResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_bookstore_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestockBookResponse.ProtoReflect.Descriptor instead.
+func (*RestockBookResponse) Descriptor() ([]byte, []int) {
+	return file_protos_bookstore_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *RestockBookResponse) GetStock() int32 {
+	if x != nil {
+		return x.Stock
+	}
+	return 0
+}
+
+// 预留库存请求
+type ReserveBookRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`              // 图书ID
+	Quantity      int32                  `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"` // 预留数量，必须大于0
+	Ttl           *durationpb.Duration   `protobuf:"bytes,3,opt,name=ttl,proto3" json:"ttl,omitempty"`            // 预留有效期，不设置时使用默认值
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReserveBookRequest) Reset() {
+	*x = ReserveBookRequest{}
+	mi := &file_protos_bookstore_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReserveBookRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReserveBookRequest) ProtoMessage() {}
+
+func (x *ReserveBookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_bookstore_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReserveBookRequest.ProtoReflect.Descriptor instead.
+func (*ReserveBookRequest) Descriptor() ([]byte, []int) {
+	return file_protos_bookstore_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *ReserveBookRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ReserveBookRequest) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *ReserveBookRequest) GetTtl() *durationpb.Duration {
+	if x != nil {
+		return x.Ttl
+	}
+	return nil
+}
+
+// 预留库存响应
+type ReserveResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ReservationId string                 `protobuf:"bytes,1,opt,name=reservation_id,json=reservationId,proto3" json:"reservation_id,omitempty"` // 预留ID，用于确认或取消
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReserveResponse) Reset() {
+	*x = ReserveResponse{}
+	mi := &file_protos_bookstore_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReserveResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReserveResponse) ProtoMessage() {}
+
+func (x *ReserveResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_bookstore_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReserveResponse.ProtoReflect.Descriptor instead.
+func (*ReserveResponse) Descriptor() ([]byte, []int) {
+	return file_protos_bookstore_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *ReserveResponse) GetReservationId() string {
+	if x != nil {
+		return x.ReservationId
+	}
+	return ""
+}
+
+// 确认/取消预留请求
+type ReservationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ReservationId string                 `protobuf:"bytes,1,opt,name=reservation_id,json=reservationId,proto3" json:"reservation_id,omitempty"` // 预留ID
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReservationRequest) Reset() {
+	*x = ReservationRequest{}
+	mi := &file_protos_bookstore_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReservationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReservationRequest) ProtoMessage() {}
+
+func (x *ReservationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_bookstore_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReservationRequest.ProtoReflect.Descriptor instead.
+func (*ReservationRequest) Descriptor() ([]byte, []int) {
+	return file_protos_bookstore_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *ReservationRequest) GetReservationId() string {
+	if x != nil {
+		return x.ReservationId
+	}
+	return ""
+}
+
+// 确认/取消预留响应
+type ReservationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"` // 操作结果消息
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReservationResponse) Reset() {
+	*x = ReservationResponse{}
+	mi := &file_protos_bookstore_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReservationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReservationResponse) ProtoMessage() {}
+
+func (x *ReservationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_bookstore_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReservationResponse.ProtoReflect.Descriptor instead.
+func (*ReservationResponse) Descriptor() ([]byte, []int) {
+	return file_protos_bookstore_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *ReservationResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// 流式获取图书请求
+type StreamBooksRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AllowPartial  bool                   `protobuf:"varint,1,opt,name=allow_partial,json=allowPartial,proto3" json:"allow_partial,omitempty"` // 临近截止时间时是否提前结束并返回部分结果
+	Filter        *BookFilter            `protobuf:"bytes,2,opt,name=filter,proto3" json:"filter,omitempty"`                                  // 可选的过滤条件，为空时返回所有图书；SearchBooksByPrice 结果过多时改用此方法
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamBooksRequest) Reset() {
+	*x = StreamBooksRequest{}
+	mi := &file_protos_bookstore_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamBooksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamBooksRequest) ProtoMessage() {}
+
+func (x *StreamBooksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_bookstore_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamBooksRequest.ProtoReflect.Descriptor instead.
+func (*StreamBooksRequest) Descriptor() ([]byte, []int) {
+	return file_protos_bookstore_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *StreamBooksRequest) GetAllowPartial() bool {
+	if x != nil {
+		return x.AllowPartial
+	}
+	return false
+}
+
+func (x *StreamBooksRequest) GetFilter() *BookFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+// 流式获取图书响应，每条消息包含一本图书；
+// 提前结束时最后一条消息不包含图书，truncated 为 true
+type StreamBooksResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Book          *Book                  `protobuf:"bytes,1,opt,name=book,proto3" json:"book,omitempty"`            // 图书信息
+	Truncated     bool                   `protobuf:"varint,2,opt,name=truncated,proto3" json:"truncated,omitempty"` // 结果因临近截止时间而被截断
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamBooksResponse) Reset() {
+	*x = StreamBooksResponse{}
+	mi := &file_protos_bookstore_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamBooksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamBooksResponse) ProtoMessage() {}
+
+func (x *StreamBooksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_bookstore_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamBooksResponse.ProtoReflect.Descriptor instead.
+func (*StreamBooksResponse) Descriptor() ([]byte, []int) {
+	return file_protos_bookstore_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *StreamBooksResponse) GetBook() *Book {
+	if x != nil {
+		return x.Book
+	}
+	return nil
+}
+
+func (x *StreamBooksResponse) GetTruncated() bool {
+	if x != nil {
+		return x.Truncated
+	}
+	return false
+}
+
+// 价格区间，包含两端
+type PriceRange struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MinPrice      float32                `protobuf:"fixed32,1,opt,name=min_price,json=minPrice,proto3" json:"min_price,omitempty"` // 最低价格
+	MaxPrice      float32                `protobuf:"fixed32,2,opt,name=max_price,json=maxPrice,proto3" json:"max_price,omitempty"` // 最高价格
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PriceRange) Reset() {
+	*x = PriceRange{}
+	mi := &file_protos_bookstore_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PriceRange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PriceRange) ProtoMessage() {}
+
+func (x *PriceRange) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_bookstore_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PriceRange.ProtoReflect.Descriptor instead.
+func (*PriceRange) Descriptor() ([]byte, []int) {
+	return file_protos_bookstore_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *PriceRange) GetMinPrice() float32 {
+	if x != nil {
+		return x.MinPrice
+	}
+	return 0
+}
+
+func (x *PriceRange) GetMaxPrice() float32 {
+	if x != nil {
+		return x.MaxPrice
+	}
+	return 0
+}
+
+// 按多个价格区间查询图书请求
+type SearchBooksByPriceRangesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ranges        []*PriceRange          `protobuf:"bytes,1,rep,name=ranges,proto3" json:"ranges,omitempty"`                            // 价格区间列表，区间可以重叠
+	CountsOnly    bool                   `protobuf:"varint,2,opt,name=counts_only,json=countsOnly,proto3" json:"counts_only,omitempty"` // 为 true 时只返回每个区间的数量，不返回图书
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchBooksByPriceRangesRequest) Reset() {
+	*x = SearchBooksByPriceRangesRequest{}
+	mi := &file_protos_bookstore_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchBooksByPriceRangesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchBooksByPriceRangesRequest) ProtoMessage() {}
+
+func (x *SearchBooksByPriceRangesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_bookstore_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchBooksByPriceRangesRequest.ProtoReflect.Descriptor instead.
+func (*SearchBooksByPriceRangesRequest) Descriptor() ([]byte, []int) {
+	return file_protos_bookstore_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *SearchBooksByPriceRangesRequest) GetRanges() []*PriceRange {
+	if x != nil {
+		return x.Ranges
+	}
+	return nil
+}
+
+func (x *SearchBooksByPriceRangesRequest) GetCountsOnly() bool {
+	if x != nil {
+		return x.CountsOnly
+	}
+	return false
+}
+
+// 单个价格区间的查询结果
+type RangeResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Range         *PriceRange            `protobuf:"bytes,1,opt,name=range,proto3" json:"range,omitempty"`  // 对应的价格区间
+	Books         []*Book                `protobuf:"bytes,2,rep,name=books,proto3" json:"books,omitempty"`  // 符合条件的图书，按ID排序
+	Count         int32                  `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"` // 符合条件的图书数量
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RangeResult) Reset() {
+	*x = RangeResult{}
+	mi := &file_protos_bookstore_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RangeResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RangeResult) ProtoMessage() {}
+
+func (x *RangeResult) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_bookstore_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RangeResult.ProtoReflect.Descriptor instead.
+func (*RangeResult) Descriptor() ([]byte, []int) {
+	return file_protos_bookstore_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *RangeResult) GetRange() *PriceRange {
+	if x != nil {
+		return x.Range
+	}
+	return nil
+}
+
+func (x *RangeResult) GetBooks() []*Book {
+	if x != nil {
+		return x.Books
+	}
+	return nil
+}
+
+func (x *RangeResult) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+// 按多个价格区间查询图书响应
+type SearchBooksByPriceRangesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Results       []*RangeResult         `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"` // 与请求中的区间一一对应
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchBooksByPriceRangesResponse) Reset() {
+	*x = SearchBooksByPriceRangesResponse{}
+	mi := &file_protos_bookstore_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchBooksByPriceRangesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchBooksByPriceRangesResponse) ProtoMessage() {}
+
+func (x *SearchBooksByPriceRangesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_bookstore_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchBooksByPriceRangesResponse.ProtoReflect.Descriptor instead.
+func (*SearchBooksByPriceRangesResponse) Descriptor() ([]byte, []int) {
+	return file_protos_bookstore_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *SearchBooksByPriceRangesResponse) GetResults() []*RangeResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+// 按多个标题查询图书请求
+type GetBooksByTitlesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Titles        []string               `protobuf:"bytes,1,rep,name=titles,proto3" json:"titles,omitempty"` // 标题列表，不区分大小写完全匹配
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBooksByTitlesRequest) Reset() {
+	*x = GetBooksByTitlesRequest{}
+	mi := &file_protos_bookstore_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBooksByTitlesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBooksByTitlesRequest) ProtoMessage() {}
+
+func (x *GetBooksByTitlesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_bookstore_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBooksByTitlesRequest.ProtoReflect.Descriptor instead.
+func (*GetBooksByTitlesRequest) Descriptor() ([]byte, []int) {
+	return file_protos_bookstore_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *GetBooksByTitlesRequest) GetTitles() []string {
+	if x != nil {
+		return x.Titles
+	}
+	return nil
+}
+
+// 单个标题的查询结果
+type TitleResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Title         string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"` // 请求中的标题
+	Books         []*Book                `protobuf:"bytes,2,rep,name=books,proto3" json:"books,omitempty"` // 标题匹配的图书，按ID排序，没有匹配时为空
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TitleResult) Reset() {
+	*x = TitleResult{}
+	mi := &file_protos_bookstore_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TitleResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TitleResult) ProtoMessage() {}
+
+func (x *TitleResult) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_bookstore_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TitleResult.ProtoReflect.Descriptor instead.
+func (*TitleResult) Descriptor() ([]byte, []int) {
+	return file_protos_bookstore_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *TitleResult) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *TitleResult) GetBooks() []*Book {
+	if x != nil {
+		return x.Books
+	}
+	return nil
+}
+
+// 按多个标题查询图书响应
+type GetBooksByTitlesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Results       []*TitleResult         `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"` // 与请求中的标题一一对应
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBooksByTitlesResponse) Reset() {
+	*x = GetBooksByTitlesResponse{}
+	mi := &file_protos_bookstore_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBooksByTitlesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBooksByTitlesResponse) ProtoMessage() {}
+
+func (x *GetBooksByTitlesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_bookstore_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBooksByTitlesResponse.ProtoReflect.Descriptor instead.
+func (*GetBooksByTitlesResponse) Descriptor() ([]byte, []int) {
+	return file_protos_bookstore_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *GetBooksByTitlesResponse) GetResults() []*TitleResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+// 流式导出图书请求
+type StreamExportRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Filter        *BookFilter            `protobuf:"bytes,1,opt,name=filter,proto3" json:"filter,omitempty"`                              // 可选的过滤条件，为空时导出所有图书
+	Format        ExportFormat           `protobuf:"varint,2,opt,name=format,proto3,enum=bookstore.ExportFormat" json:"format,omitempty"` // 导出格式
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamExportRequest) Reset() {
+	*x = StreamExportRequest{}
+	mi := &file_protos_bookstore_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamExportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamExportRequest) ProtoMessage() {}
+
+func (x *StreamExportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_bookstore_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamExportRequest.ProtoReflect.Descriptor instead.
+func (*StreamExportRequest) Descriptor() ([]byte, []int) {
+	return file_protos_bookstore_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *StreamExportRequest) GetFilter() *BookFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+func (x *StreamExportRequest) GetFormat() ExportFormat {
+	if x != nil {
+		return x.Format
+	}
+	return ExportFormat_EXPORT_FORMAT_JSONL
+}
+
+// 流式导出的数据块，按顺序拼接即为完整的导出文件
+type ExportChunk struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Data          []byte                 `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportChunk) Reset() {
+	*x = ExportChunk{}
+	mi := &file_protos_bookstore_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportChunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportChunk) ProtoMessage() {}
+
+func (x *ExportChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_bookstore_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportChunk.ProtoReflect.Descriptor instead.
+func (*ExportChunk) Descriptor() ([]byte, []int) {
+	return file_protos_bookstore_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *ExportChunk) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+// 批量获取图书请求，客户端可以分多条消息发送ID
+type GetBooksBatchRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ids           []string               `protobuf:"bytes,1,rep,name=ids,proto3" json:"ids,omitempty"` // 本批次要获取的图书ID
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBooksBatchRequest) Reset() {
+	*x = GetBooksBatchRequest{}
+	mi := &file_protos_bookstore_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBooksBatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBooksBatchRequest) ProtoMessage() {}
+
+func (x *GetBooksBatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_bookstore_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBooksBatchRequest.ProtoReflect.Descriptor instead.
+func (*GetBooksBatchRequest) Descriptor() ([]byte, []int) {
+	return file_protos_bookstore_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *GetBooksBatchRequest) GetIds() []string {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+// 增量同步请求
+type ListChangedSinceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SinceSeq      int64                  `protobuf:"varint,1,opt,name=since_seq,json=sinceSeq,proto3" json:"since_seq,omitempty"` // 上次同步得到的 current_seq，0 表示从头同步
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListChangedSinceRequest) Reset() {
+	*x = ListChangedSinceRequest{}
+	mi := &file_protos_bookstore_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListChangedSinceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListChangedSinceRequest) ProtoMessage() {}
+
+func (x *ListChangedSinceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_bookstore_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListChangedSinceRequest.ProtoReflect.Descriptor instead.
+func (*ListChangedSinceRequest) Descriptor() ([]byte, []int) {
+	return file_protos_bookstore_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *ListChangedSinceRequest) GetSinceSeq() int64 {
+	if x != nil {
+		return x.SinceSeq
+	}
+	return 0
+}
+
+// 增量同步响应
+type ListChangedSinceResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Books         []*Book                `protobuf:"bytes,1,rep,name=books,proto3" json:"books,omitempty"`                              // since_seq 之后被创建或修改的图书，按 last_modified_seq 排序
+	Deletes       []string               `protobuf:"bytes,2,rep,name=deletes,proto3" json:"deletes,omitempty"`                          // since_seq 之后被删除且目前不存在的图书ID，按删除顺序排列
+	CurrentSeq    int64                  `protobuf:"varint,3,opt,name=current_seq,json=currentSeq,proto3" json:"current_seq,omitempty"` // 当前的变更序号，作为下一次同步的 since_seq
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListChangedSinceResponse) Reset() {
+	*x = ListChangedSinceResponse{}
+	mi := &file_protos_bookstore_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListChangedSinceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListChangedSinceResponse) ProtoMessage() {}
+
+func (x *ListChangedSinceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_bookstore_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListChangedSinceResponse.ProtoReflect.Descriptor instead.
+func (*ListChangedSinceResponse) Descriptor() ([]byte, []int) {
+	return file_protos_bookstore_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *ListChangedSinceResponse) GetBooks() []*Book {
+	if x != nil {
+		return x.Books
+	}
+	return nil
+}
+
+func (x *ListChangedSinceResponse) GetDeletes() []string {
+	if x != nil {
+		return x.Deletes
+	}
+	return nil
+}
+
+func (x *ListChangedSinceResponse) GetCurrentSeq() int64 {
+	if x != nil {
+		return x.CurrentSeq
+	}
+	return 0
+}
+
+var File_protos_bookstore_proto protoreflect.FileDescriptor
+
+const file_protos_bookstore_proto_rawDesc = "" +
+	"\n" +
+	"\x16protos/bookstore.proto\x12\tbookstore\x1a\x1egoogle/protobuf/duration.proto\x1a\x1bgoogle/protobuf/empty.proto\"\x92\x03\n" +
+	"\x04Book\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12\x16\n" +
+	"\x06author\x18\x03 \x01(\tR\x06author\x12\x19\n" +
+	"\x05price\x18\x04 \x01(\x02H\x00R\x05price\x88\x01\x01\x12%\n" +
+	"\vdescription\x18\x05 \x01(\tH\x01R\vdescription\x88\x01\x01\x12&\n" +
+	"\fpublish_year\x18\x06 \x01(\x05H\x02R\vpublishYear\x88\x01\x01\x12\x1a\n" +
+	"\bfeatured\x18\a \x01(\bR\bfeatured\x12#\n" +
+	"\rfeatured_rank\x18\b \x01(\x05R\ffeaturedRank\x12\x14\n" +
+	"\x05stock\x18\t \x01(\x05R\x05stock\x12\x12\n" +
+	"\x04isbn\x18\x0e \x01(\tR\x04isbn\x12\x1a\n" +
+	"\bcurrency\x18\x0f \x01(\tR\bcurrency\x12*\n" +
+	"\x11last_modified_seq\x18\x10 \x01(\x03R\x0flastModifiedSeqB\b\n" +
+	"\x06_priceB\x0e\n" +
+	"\f_descriptionB\x0f\n" +
+	"\r_publish_yearJ\x04\b\n" +
+	"\x10\x0e\"8\n" +
+	"\x11CreateBookRequest\x12#\n" +
+	"\x04book\x18\x01 \x01(\v2\x0f.bookstore.BookR\x04book\">\n" +
+	"\x12CreateBookResponse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\" \n" +
+	"\x0eGetBookRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"Y\n" +
+	"\x0fGetBookResponse\x12#\n" +
+	"\x04book\x18\x01 \x01(\v2\x0f.bookstore.BookR\x04book\x12!\n" +
+	"\fnot_modified\x18\x02 \x01(\bR\vnotModified\"8\n" +
+	"\x11UpdateBookRequest\x12#\n" +
+	"\x04book\x18\x01 \x01(\v2\x0f.bookstore.BookR\x04book\".\n" +
+	"\x12UpdateBookResponse\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\"M\n" +
+	"\x11DeleteBookRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12(\n" +
+	"\x10ignore_not_found\x18\x02 \x01(\bR\x0eignoreNotFound\"H\n" +
+	"\x12DeleteBookResponse\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\x12\x18\n" +
+	"\adeleted\x18\x02 \x01(\bR\adeleted\"j\n" +
+	"\x10ListBooksRequest\x12\x12\n" +
+	"\x04page\x18\x01 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\x12%\n" +
+	"\x0esnapshot_token\x18\x03 \x01(\tR\rsnapshotToken\"\x8b\x01\n" +
+	"\x11ListBooksResponse\x12%\n" +
+	"\x05books\x18\x01 \x03(\v2\x0f.bookstore.BookR\x05books\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total\x12\x1c\n" +
+	"\ttruncated\x18\x03 \x01(\bR\ttruncated\x12\x1b\n" +
+	"\tpage_size\x18\x04 \x01(\x05R\bpageSize\"\x98\x01\n" +
+	"\x19SearchBooksByPriceRequest\x12\x1b\n" +
+	"\tmin_price\x18\x01 \x01(\x02R\bminPrice\x12\x1b\n" +
+	"\tmax_price\x18\x02 \x01(\x02R\bmaxPrice\x12%\n" +
+	"\x0esnapshot_token\x18\x03 \x01(\tR\rsnapshotToken\x12\x1a\n" +
+	"\bcurrency\x18\x04 \x01(\tR\bcurrency\"C\n" +
+	"\x1aSearchBooksByPriceResponse\x12%\n" +
+	"\x05books\x18\x01 \x03(\v2\x0f.bookstore.BookR\x05books\"O\n" +
+	"\x1bGetBooksByExactPriceRequest\x12\x14\n" +
+	"\x05price\x18\x01 \x01(\x02R\x05price\x12\x1a\n" +
+	"\bcurrency\x18\x02 \x01(\tR\bcurrency\"\x81\x01\n" +
+	"\n" +
+	"BookFilter\x12\x1b\n" +
+	"\tmin_price\x18\x01 \x01(\x02R\bminPrice\x12\x1b\n" +
+	"\tmax_price\x18\x02 \x01(\x02R\bmaxPrice\x12\x16\n" +
+	"\x06author\x18\x03 \x01(\tR\x06author\x12!\n" +
+	"\fpublish_year\x18\x04 \x01(\x05R\vpublishYear\"E\n" +
+	"\x14GetPriceStatsRequest\x12-\n" +
+	"\x06filter\x18\x01 \x01(\v2\x15.bookstore.BookFilterR\x06filter\"x\n" +
+	"\x12PriceStatsResponse\x12\x14\n" +
+	"\x05count\x18\x01 \x01(\x05R\x05count\x12\x10\n" +
+	"\x03min\x18\x02 \x01(\x02R\x03min\x12\x10\n" +
+	"\x03max\x18\x03 \x01(\x02R\x03max\x12\x10\n" +
+	"\x03avg\x18\x04 \x01(\x02R\x03avg\x12\x16\n" +
+	"\x06median\x18\x05 \x01(\x02R\x06median\"@\n" +
+	"\x1bStreamPriceHistogramRequest\x12!\n" +
+	"\fbucket_width\x18\x01 \x01(\x02R\vbucketWidth\"O\n" +
+	"\vPriceBucket\x12\x14\n" +
+	"\x05lower\x18\x01 \x01(\x02R\x05lower\x12\x14\n" +
+	"\x05upper\x18\x02 \x01(\x02R\x05upper\x12\x14\n" +
+	"\x05count\x18\x03 \x01(\x05R\x05count\"X\n" +
+	"\x0ePriceHistogram\x120\n" +
+	"\abuckets\x18\x01 \x03(\v2\x16.bookstore.PriceBucketR\abuckets\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total\"(\n" +
+	"\x10SnapshotResponse\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\"\xb0\x02\n" +
+	"\rStatsResponse\x12,\n" +
+	"\x12in_flight_requests\x18\x01 \x01(\x03R\x10inFlightRequests\x12\x1d\n" +
+	"\n" +
+	"book_count\x18\x02 \x01(\x05R\tbookCount\x12D\n" +
+	"\rrequest_sizes\x18\x03 \x03(\v2\x1f.bookstore.RequestSizeHistogramR\frequestSizes\x12L\n" +
+	"\fpanics_total\x18\x04 \x03(\v2).bookstore.StatsResponse.PanicsTotalEntryR\vpanicsTotal\x1a>\n" +
+	"\x10PanicsTotalEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x03R\x05value:\x028\x01\"\xa0\x01\n" +
+	"\x14RequestSizeHistogram\x12\x16\n" +
+	"\x06method\x18\x01 \x01(\tR\x06method\x12#\n" +
+	"\rbucket_bounds\x18\x02 \x03(\x03R\fbucketBounds\x12#\n" +
+	"\rbucket_counts\x18\x03 \x03(\x03R\fbucketCounts\x12\x14\n" +
+	"\x05count\x18\x04 \x01(\x03R\x05count\x12\x10\n" +
+	"\x03sum\x18\x05 \x01(\x03R\x03sum\"\x91\x01\n" +
+	"\x13AdjustPricesRequest\x12-\n" +
+	"\x06filter\x18\x01 \x01(\v2\x15.bookstore.BookFilterR\x06filter\x12\x1a\n" +
+	"\apercent\x18\x02 \x01(\x02H\x00R\apercent\x12!\n" +
+	"\vfixed_delta\x18\x03 \x01(\x02H\x00R\n" +
+	"fixedDeltaB\f\n" +
+	"\n" +
+	"adjustment\"\\\n" +
+	"\x14AdjustPricesResponse\x12#\n" +
+	"\rupdated_count\x18\x01 \x01(\x05R\fupdatedCount\x12\x1f\n" +
+	"\vskipped_ids\x18\x02 \x03(\tR\n" +
+	"skippedIds\"9\n" +
+	"\x13RenameAuthorRequest\x12\x12\n" +
+	"\x04from\x18\x01 \x01(\tR\x04from\x12\x0e\n" +
+	"\x02to\x18\x02 \x01(\tR\x02to\";\n" +
+	"\x14RenameAuthorResponse\x12#\n" +
+	"\rupdated_count\x18\x01 \x01(\x05R\fupdatedCount\"Q\n" +
+	"\x15FindDuplicatesRequest\x128\n" +
+	"\bstrategy\x18\x01 \x01(\x0e2\x1c.bookstore.DuplicateStrategyR\bstrategy\"I\n" +
+	"\x0eDuplicateGroup\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12%\n" +
+	"\x05books\x18\x02 \x03(\v2\x0f.bookstore.BookR\x05books\"K\n" +
+	"\x16FindDuplicatesResponse\x121\n" +
+	"\x06groups\x18\x01 \x03(\v2\x19.bookstore.DuplicateGroupR\x06groups\"E\n" +
+	"\x14GetRandomBookRequest\x12-\n" +
+	"\x06filter\x18\x01 \x01(\v2\x15.bookstore.BookFilterR\x06filter\"<\n" +
+	"\x15GetRandomBookResponse\x12#\n" +
+	"\x04book\x18\x01 \x01(\v2\x0f.bookstore.BookR\x04book\"f\n" +
+	"\x16ReplaceCatalogResponse\x12\x18\n" +
+	"\acreated\x18\x01 \x01(\x05R\acreated\x12\x18\n" +
+	"\aupdated\x18\x02 \x01(\x05R\aupdated\x12\x18\n" +
+	"\adeleted\x18\x03 \x01(\x05R\adeleted\"]\n" +
+	"\rBookViolation\x12\x14\n" +
+	"\x05index\x18\x01 \x01(\x05R\x05index\x12\x14\n" +
+	"\x05field\x18\x02 \x01(\tR\x05field\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\"r\n" +
+	"\x15ValidateBooksResponse\x12\x1f\n" +
+	"\vvalid_count\x18\x01 \x01(\x05R\n" +
+	"validCount\x128\n" +
+	"\n" +
+	"violations\x18\x02 \x03(\v2\x18.bookstore.BookViolationR\n" +
+	"violations\"8\n" +
+	"\x12SetFeaturedRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04rank\x18\x02 \x01(\x05R\x04rank\"&\n" +
+	"\x14UnsetFeaturedRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\",\n" +
+	"\x10FeaturedResponse\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\"B\n" +
+	"\x19ListFeaturedBooksResponse\x12%\n" +
+	"\x05books\x18\x01 \x03(\v2\x0f.bookstore.BookR\x05books\"A\n" +
+	"\x13PurchaseBookRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1a\n" +
+	"\bquantity\x18\x02 \x01(\x05R\bquantity\"?\n" +
+	"\x14PurchaseBookResponse\x12'\n" +
+	"\x0fremaining_stock\x18\x01 \x01(\x05R\x0eremainingStock\"@\n" +
+	"\x12RestockBookRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1a\n" +
+	"\bquantity\x18\x02 \x01(\x05R\bquantity\"+\n" +
+	"\x13RestockBookResponse\x12\x14\n" +
+	"\x05stock\x18\x01 \x01(\x05R\x05stock\"m\n" +
+	"\x12ReserveBookRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1a\n" +
+	"\bquantity\x18\x02 \x01(\x05R\bquantity\x12+\n" +
+	"\x03ttl\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\x03ttl\"8\n" +
+	"\x0fReserveResponse\x12%\n" +
+	"\x0ereservation_id\x18\x01 \x01(\tR\rreservationId\";\n" +
+	"\x12ReservationRequest\x12%\n" +
+	"\x0ereservation_id\x18\x01 \x01(\tR\rreservationId\"/\n" +
+	"\x13ReservationResponse\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\"h\n" +
+	"\x12StreamBooksRequest\x12#\n" +
+	"\rallow_partial\x18\x01 \x01(\bR\fallowPartial\x12-\n" +
+	"\x06filter\x18\x02 \x01(\v2\x15.bookstore.BookFilterR\x06filter\"X\n" +
+	"\x13StreamBooksResponse\x12#\n" +
+	"\x04book\x18\x01 \x01(\v2\x0f.bookstore.BookR\x04book\x12\x1c\n" +
+	"\ttruncated\x18\x02 \x01(\bR\ttruncated\"F\n" +
+	"\n" +
+	"PriceRange\x12\x1b\n" +
+	"\tmin_price\x18\x01 \x01(\x02R\bminPrice\x12\x1b\n" +
+	"\tmax_price\x18\x02 \x01(\x02R\bmaxPrice\"q\n" +
+	"\x1fSearchBooksByPriceRangesRequest\x12-\n" +
+	"\x06ranges\x18\x01 \x03(\v2\x15.bookstore.PriceRangeR\x06ranges\x12\x1f\n" +
+	"\vcounts_only\x18\x02 \x01(\bR\n" +
+	"countsOnly\"w\n" +
+	"\vRangeResult\x12+\n" +
+	"\x05range\x18\x01 \x01(\v2\x15.bookstore.PriceRangeR\x05range\x12%\n" +
+	"\x05books\x18\x02 \x03(\v2\x0f.bookstore.BookR\x05books\x12\x14\n" +
+	"\x05count\x18\x03 \x01(\x05R\x05count\"T\n" +
+	" SearchBooksByPriceRangesResponse\x120\n" +
+	"\aresults\x18\x01 \x03(\v2\x16.bookstore.RangeResultR\aresults\"1\n" +
+	"\x17GetBooksByTitlesRequest\x12\x16\n" +
+	"\x06titles\x18\x01 \x03(\tR\x06titles\"J\n" +
+	"\vTitleResult\x12\x14\n" +
+	"\x05title\x18\x01 \x01(\tR\x05title\x12%\n" +
+	"\x05books\x18\x02 \x03(\v2\x0f.bookstore.BookR\x05books\"L\n" +
+	"\x18GetBooksByTitlesResponse\x120\n" +
+	"\aresults\x18\x01 \x03(\v2\x16.bookstore.TitleResultR\aresults\"u\n" +
+	"\x13StreamExportRequest\x12-\n" +
+	"\x06filter\x18\x01 \x01(\v2\x15.bookstore.BookFilterR\x06filter\x12/\n" +
+	"\x06format\x18\x02 \x01(\x0e2\x17.bookstore.ExportFormatR\x06format\"!\n" +
+	"\vExportChunk\x12\x12\n" +
+	"\x04data\x18\x01 \x01(\fR\x04data\"(\n" +
+	"\x14GetBooksBatchRequest\x12\x10\n" +
+	"\x03ids\x18\x01 \x03(\tR\x03ids\"6\n" +
+	"\x17ListChangedSinceRequest\x12\x1b\n" +
+	"\tsince_seq\x18\x01 \x01(\x03R\bsinceSeq\"|\n" +
+	"\x18ListChangedSinceResponse\x12%\n" +
+	"\x05books\x18\x01 \x03(\v2\x0f.bookstore.BookR\x05books\x12\x18\n" +
+	"\adeletes\x18\x02 \x03(\tR\adeletes\x12\x1f\n" +
+	"\vcurrent_seq\x18\x03 \x01(\x03R\n" +
+	"currentSeq*U\n" +
+	"\x11DuplicateStrategy\x12#\n" +
+	"\x1fDUPLICATE_STRATEGY_TITLE_AUTHOR\x10\x00\x12\x1b\n" +
+	"\x17DUPLICATE_STRATEGY_ISBN\x10\x01*>\n" +
+	"\fExportFormat\x12\x17\n" +
+	"\x13EXPORT_FORMAT_JSONL\x10\x00\x12\x15\n" +
+	"\x11EXPORT_FORMAT_CSV\x10\x012\xe3\x13\n" +
+	"\vBookService\x12I\n" +
+	"\n" +
+	"CreateBook\x12\x1c.bookstore.CreateBookRequest\x1a\x1d.bookstore.CreateBookResponse\x12@\n" +
+	"\aGetBook\x12\x19.bookstore.GetBookRequest\x1a\x1a.bookstore.GetBookResponse\x12I\n" +
+	"\n" +
+	"UpdateBook\x12\x1c.bookstore.UpdateBookRequest\x1a\x1d.bookstore.UpdateBookResponse\x12I\n" +
+	"\n" +
+	"DeleteBook\x12\x1c.bookstore.DeleteBookRequest\x1a\x1d.bookstore.DeleteBookResponse\x12F\n" +
+	"\tListBooks\x12\x1b.bookstore.ListBooksRequest\x1a\x1c.bookstore.ListBooksResponse\x12a\n" +
+	"\x12SearchBooksByPrice\x12$.bookstore.SearchBooksByPriceRequest\x1a%.bookstore.SearchBooksByPriceResponse\x12e\n" +
+	"\x14GetBooksByExactPrice\x12&.bookstore.GetBooksByExactPriceRequest\x1a%.bookstore.SearchBooksByPriceResponse\x12O\n" +
+	"\rGetPriceStats\x12\x1f.bookstore.GetPriceStatsRequest\x1a\x1d.bookstore.PriceStatsResponse\x12[\n" +
+	"\x14StreamPriceHistogram\x12&.bookstore.StreamPriceHistogramRequest\x1a\x19.bookstore.PriceHistogram0\x01\x12C\n" +
+	"\fOpenSnapshot\x12\x16.google.protobuf.Empty\x1a\x1b.bookstore.SnapshotResponse\x12<\n" +
+	"\bGetStats\x12\x16.google.protobuf.Empty\x1a\x18.bookstore.StatsResponse\x12O\n" +
+	"\fAdjustPrices\x12\x1e.bookstore.AdjustPricesRequest\x1a\x1f.bookstore.AdjustPricesResponse\x12O\n" +
+	"\fRenameAuthor\x12\x1e.bookstore.RenameAuthorRequest\x1a\x1f.bookstore.RenameAuthorResponse\x12U\n" +
+	"\x0eFindDuplicates\x12 .bookstore.FindDuplicatesRequest\x1a!.bookstore.FindDuplicatesResponse\x12R\n" +
+	"\rGetRandomBook\x12\x1f.bookstore.GetRandomBookRequest\x1a .bookstore.GetRandomBookResponse\x12F\n" +
+	"\x0eReplaceCatalog\x12\x0f.bookstore.Book\x1a!.bookstore.ReplaceCatalogResponse(\x01\x12I\n" +
+	"\vSetFeatured\x12\x1d.bookstore.SetFeaturedRequest\x1a\x1b.bookstore.FeaturedResponse\x12M\n" +
+	"\rUnsetFeatured\x12\x1f.bookstore.UnsetFeaturedRequest\x1a\x1b.bookstore.FeaturedResponse\x12Q\n" +
+	"\x11ListFeaturedBooks\x12\x16.google.protobuf.Empty\x1a$.bookstore.ListFeaturedBooksResponse\x12O\n" +
+	"\fPurchaseBook\x12\x1e.bookstore.PurchaseBookRequest\x1a\x1f.bookstore.PurchaseBookResponse\x12L\n" +
+	"\vRestockBook\x12\x1d.bookstore.RestockBookRequest\x1a\x1e.bookstore.RestockBookResponse\x12H\n" +
+	"\vReserveBook\x12\x1d.bookstore.ReserveBookRequest\x1a\x1a.bookstore.ReserveResponse\x12S\n" +
+	"\x12ConfirmReservation\x12\x1d.bookstore.ReservationRequest\x1a\x1e.bookstore.ReservationResponse\x12R\n" +
+	"\x11CancelReservation\x12\x1d.bookstore.ReservationRequest\x1a\x1e.bookstore.ReservationResponse\x12N\n" +
+	"\vStreamBooks\x12\x1d.bookstore.StreamBooksRequest\x1a\x1e.bookstore.StreamBooksResponse0\x01\x12s\n" +
+	"\x18SearchBooksByPriceRanges\x12*.bookstore.SearchBooksByPriceRangesRequest\x1a+.bookstore.SearchBooksByPriceRangesResponse\x12[\n" +
+	"\x10GetBooksByTitles\x12\".bookstore.GetBooksByTitlesRequest\x1a#.bookstore.GetBooksByTitlesResponse\x12H\n" +
+	"\fStreamExport\x12\x1e.bookstore.StreamExportRequest\x1a\x16.bookstore.ExportChunk0\x01\x12K\n" +
+	"\x13GetBooksBatchStream\x12\x1f.bookstore.GetBooksBatchRequest\x1a\x0f.bookstore.Book(\x010\x01\x12D\n" +
+	"\rValidateBooks\x12\x0f.bookstore.Book\x1a .bookstore.ValidateBooksResponse(\x01\x12[\n" +
+	"\x10ListChangedSince\x12\".bookstore.ListChangedSinceRequest\x1a#.bookstore.ListChangedSinceResponseB\x0eZ\fpb/bookstoreb\x06proto3"
+
+var (
+	file_protos_bookstore_proto_rawDescOnce sync.Once
+	file_protos_bookstore_proto_rawDescData []byte
+)
+
+func file_protos_bookstore_proto_rawDescGZIP() []byte {
+	file_protos_bookstore_proto_rawDescOnce.Do(func() {
+		file_protos_bookstore_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_protos_bookstore_proto_rawDesc), len(file_protos_bookstore_proto_rawDesc)))
+	})
+	return file_protos_bookstore_proto_rawDescData
+}
+
+var file_protos_bookstore_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_protos_bookstore_proto_msgTypes = make([]protoimpl.MessageInfo, 62)
+var file_protos_bookstore_proto_goTypes = []any{
+	(DuplicateStrategy)(0),                   // 0: bookstore.DuplicateStrategy
+	(ExportFormat)(0),                        // 1: bookstore.ExportFormat
+	(*Book)(nil),                             // 2: bookstore.Book
+	(*CreateBookRequest)(nil),                // 3: bookstore.CreateBookRequest
+	(*CreateBookResponse)(nil),               // 4: bookstore.CreateBookResponse
+	(*GetBookRequest)(nil),                   // 5: bookstore.GetBookRequest
+	(*GetBookResponse)(nil),                  // 6: bookstore.GetBookResponse
+	(*UpdateBookRequest)(nil),                // 7: bookstore.UpdateBookRequest
+	(*UpdateBookResponse)(nil),               // 8: bookstore.UpdateBookResponse
+	(*DeleteBookRequest)(nil),                // 9: bookstore.DeleteBookRequest
+	(*DeleteBookResponse)(nil),               // 10: bookstore.DeleteBookResponse
+	(*ListBooksRequest)(nil),                 // 11: bookstore.ListBooksRequest
+	(*ListBooksResponse)(nil),                // 12: bookstore.ListBooksResponse
+	(*SearchBooksByPriceRequest)(nil),        // 13: bookstore.SearchBooksByPriceRequest
+	(*SearchBooksByPriceResponse)(nil),       // 14: bookstore.SearchBooksByPriceResponse
+	(*GetBooksByExactPriceRequest)(nil),      // 15: bookstore.GetBooksByExactPriceRequest
+	(*BookFilter)(nil),                       // 16: bookstore.BookFilter
+	(*GetPriceStatsRequest)(nil),             // 17: bookstore.GetPriceStatsRequest
+	(*PriceStatsResponse)(nil),               // 18: bookstore.PriceStatsResponse
+	(*StreamPriceHistogramRequest)(nil),      // 19: bookstore.StreamPriceHistogramRequest
+	(*PriceBucket)(nil),                      // 20: bookstore.PriceBucket
+	(*PriceHistogram)(nil),                   // 21: bookstore.PriceHistogram
+	(*SnapshotResponse)(nil),                 // 22: bookstore.SnapshotResponse
+	(*StatsResponse)(nil),                    // 23: bookstore.StatsResponse
+	(*RequestSizeHistogram)(nil),             // 24: bookstore.RequestSizeHistogram
+	(*AdjustPricesRequest)(nil),              // 25: bookstore.AdjustPricesRequest
+	(*AdjustPricesResponse)(nil),             // 26: bookstore.AdjustPricesResponse
+	(*RenameAuthorRequest)(nil),              // 27: bookstore.RenameAuthorRequest
+	(*RenameAuthorResponse)(nil),             // 28: bookstore.RenameAuthorResponse
+	(*FindDuplicatesRequest)(nil),            // 29: bookstore.FindDuplicatesRequest
+	(*DuplicateGroup)(nil),                   // 30: bookstore.DuplicateGroup
+	(*FindDuplicatesResponse)(nil),           // 31: bookstore.FindDuplicatesResponse
+	(*GetRandomBookRequest)(nil),             // 32: bookstore.GetRandomBookRequest
+	(*GetRandomBookResponse)(nil),            // 33: bookstore.GetRandomBookResponse
+	(*ReplaceCatalogResponse)(nil),           // 34: bookstore.ReplaceCatalogResponse
+	(*BookViolation)(nil),                    // 35: bookstore.BookViolation
+	(*ValidateBooksResponse)(nil),            // 36: bookstore.ValidateBooksResponse
+	(*SetFeaturedRequest)(nil),               // 37: bookstore.SetFeaturedRequest
+	(*UnsetFeaturedRequest)(nil),             // 38: bookstore.UnsetFeaturedRequest
+	(*FeaturedResponse)(nil),                 // 39: bookstore.FeaturedResponse
+	(*ListFeaturedBooksResponse)(nil),        // 40: bookstore.ListFeaturedBooksResponse
+	(*PurchaseBookRequest)(nil),              // 41: bookstore.PurchaseBookRequest
+	(*PurchaseBookResponse)(nil),             // 42: bookstore.PurchaseBookResponse
+	(*RestockBookRequest)(nil),               // 43: bookstore.RestockBookRequest
+	(*RestockBookResponse)(nil),              // 44: bookstore.RestockBookResponse
+	(*ReserveBookRequest)(nil),               // 45: bookstore.ReserveBookRequest
+	(*ReserveResponse)(nil),                  // 46: bookstore.ReserveResponse
+	(*ReservationRequest)(nil),               // 47: bookstore.ReservationRequest
+	(*ReservationResponse)(nil),              // 48: bookstore.ReservationResponse
+	(*StreamBooksRequest)(nil),               // 49: bookstore.StreamBooksRequest
+	(*StreamBooksResponse)(nil),              // 50: bookstore.StreamBooksResponse
+	(*PriceRange)(nil),                       // 51: bookstore.PriceRange
+	(*SearchBooksByPriceRangesRequest)(nil),  // 52: bookstore.SearchBooksByPriceRangesRequest
+	(*RangeResult)(nil),                      // 53: bookstore.RangeResult
+	(*SearchBooksByPriceRangesResponse)(nil), // 54: bookstore.SearchBooksByPriceRangesResponse
+	(*GetBooksByTitlesRequest)(nil),          // 55: bookstore.GetBooksByTitlesRequest
+	(*TitleResult)(nil),                      // 56: bookstore.TitleResult
+	(*GetBooksByTitlesResponse)(nil),         // 57: bookstore.GetBooksByTitlesResponse
+	(*StreamExportRequest)(nil),              // 58: bookstore.StreamExportRequest
+	(*ExportChunk)(nil),                      // 59: bookstore.ExportChunk
+	(*GetBooksBatchRequest)(nil),             // 60: bookstore.GetBooksBatchRequest
+	(*ListChangedSinceRequest)(nil),          // 61: bookstore.ListChangedSinceRequest
+	(*ListChangedSinceResponse)(nil),         // 62: bookstore.ListChangedSinceResponse
+	nil,                                      // 63: bookstore.StatsResponse.PanicsTotalEntry
+	(*durationpb.Duration)(nil),              // 64: google.protobuf.Duration
+	(*emptypb.Empty)(nil),                    // 65: google.protobuf.Empty
+}
+var file_protos_bookstore_proto_depIdxs = []int32{
+	2,  // 0: bookstore.CreateBookRequest.book:type_name -> bookstore.Book
+	2,  // 1: bookstore.GetBookResponse.book:type_name -> bookstore.Book
+	2,  // 2: bookstore.UpdateBookRequest.book:type_name -> bookstore.Book
+	2,  // 3: bookstore.ListBooksResponse.books:type_name -> bookstore.Book
+	2,  // 4: bookstore.SearchBooksByPriceResponse.books:type_name -> bookstore.Book
+	16, // 5: bookstore.GetPriceStatsRequest.filter:type_name -> bookstore.BookFilter
+	20, // 6: bookstore.PriceHistogram.buckets:type_name -> bookstore.PriceBucket
+	24, // 7: bookstore.StatsResponse.request_sizes:type_name -> bookstore.RequestSizeHistogram
+	63, // 8: bookstore.StatsResponse.panics_total:type_name -> bookstore.StatsResponse.PanicsTotalEntry
+	16, // 9: bookstore.AdjustPricesRequest.filter:type_name -> bookstore.BookFilter
+	0,  // 10: bookstore.FindDuplicatesRequest.strategy:type_name -> bookstore.DuplicateStrategy
+	2,  // 11: bookstore.DuplicateGroup.books:type_name -> bookstore.Book
+	30, // 12: bookstore.FindDuplicatesResponse.groups:type_name -> bookstore.DuplicateGroup
+	16, // 13: bookstore.GetRandomBookRequest.filter:type_name -> bookstore.BookFilter
+	2,  // 14: bookstore.GetRandomBookResponse.book:type_name -> bookstore.Book
+	35, // 15: bookstore.ValidateBooksResponse.violations:type_name -> bookstore.BookViolation
+	2,  // 16: bookstore.ListFeaturedBooksResponse.books:type_name -> bookstore.Book
+	64, // 17: bookstore.ReserveBookRequest.ttl:type_name -> google.protobuf.Duration
+	16, // 18: bookstore.StreamBooksRequest.filter:type_name -> bookstore.BookFilter
+	2,  // 19: bookstore.StreamBooksResponse.book:type_name -> bookstore.Book
+	51, // 20: bookstore.SearchBooksByPriceRangesRequest.ranges:type_name -> bookstore.PriceRange
+	51, // 21: bookstore.RangeResult.range:type_name -> bookstore.PriceRange
+	2,  // 22: bookstore.RangeResult.books:type_name -> bookstore.Book
+	53, // 23: bookstore.SearchBooksByPriceRangesResponse.results:type_name -> bookstore.RangeResult
+	2,  // 24: bookstore.TitleResult.books:type_name -> bookstore.Book
+	56, // 25: bookstore.GetBooksByTitlesResponse.results:type_name -> bookstore.TitleResult
+	16, // 26: bookstore.StreamExportRequest.filter:type_name -> bookstore.BookFilter
+	1,  // 27: bookstore.StreamExportRequest.format:type_name -> bookstore.ExportFormat
+	2,  // 28: bookstore.ListChangedSinceResponse.books:type_name -> bookstore.Book
+	3,  // 29: bookstore.BookService.CreateBook:input_type -> bookstore.CreateBookRequest
+	5,  // 30: bookstore.BookService.GetBook:input_type -> bookstore.GetBookRequest
+	7,  // 31: bookstore.BookService.UpdateBook:input_type -> bookstore.UpdateBookRequest
+	9,  // 32: bookstore.BookService.DeleteBook:input_type -> bookstore.DeleteBookRequest
+	11, // 33: bookstore.BookService.ListBooks:input_type -> bookstore.ListBooksRequest
+	13, // 34: bookstore.BookService.SearchBooksByPrice:input_type -> bookstore.SearchBooksByPriceRequest
+	15, // 35: bookstore.BookService.GetBooksByExactPrice:input_type -> bookstore.GetBooksByExactPriceRequest
+	17, // 36: bookstore.BookService.GetPriceStats:input_type -> bookstore.GetPriceStatsRequest
+	19, // 37: bookstore.BookService.StreamPriceHistogram:input_type -> bookstore.StreamPriceHistogramRequest
+	65, // 38: bookstore.BookService.OpenSnapshot:input_type -> google.protobuf.Empty
+	65, // 39: bookstore.BookService.GetStats:input_type -> google.protobuf.Empty
+	25, // 40: bookstore.BookService.AdjustPrices:input_type -> bookstore.AdjustPricesRequest
+	27, // 41: bookstore.BookService.RenameAuthor:input_type -> bookstore.RenameAuthorRequest
+	29, // 42: bookstore.BookService.FindDuplicates:input_type -> bookstore.FindDuplicatesRequest
+	32, // 43: bookstore.BookService.GetRandomBook:input_type -> bookstore.GetRandomBookRequest
+	2,  // 44: bookstore.BookService.ReplaceCatalog:input_type -> bookstore.Book
+	37, // 45: bookstore.BookService.SetFeatured:input_type -> bookstore.SetFeaturedRequest
+	38, // 46: bookstore.BookService.UnsetFeatured:input_type -> bookstore.UnsetFeaturedRequest
+	65, // 47: bookstore.BookService.ListFeaturedBooks:input_type -> google.protobuf.Empty
+	41, // 48: bookstore.BookService.PurchaseBook:input_type -> bookstore.PurchaseBookRequest
+	43, // 49: bookstore.BookService.RestockBook:input_type -> bookstore.RestockBookRequest
+	45, // 50: bookstore.BookService.ReserveBook:input_type -> bookstore.ReserveBookRequest
+	47, // 51: bookstore.BookService.ConfirmReservation:input_type -> bookstore.ReservationRequest
+	47, // 52: bookstore.BookService.CancelReservation:input_type -> bookstore.ReservationRequest
+	49, // 53: bookstore.BookService.StreamBooks:input_type -> bookstore.StreamBooksRequest
+	52, // 54: bookstore.BookService.SearchBooksByPriceRanges:input_type -> bookstore.SearchBooksByPriceRangesRequest
+	55, // 55: bookstore.BookService.GetBooksByTitles:input_type -> bookstore.GetBooksByTitlesRequest
+	58, // 56: bookstore.BookService.StreamExport:input_type -> bookstore.StreamExportRequest
+	60, // 57: bookstore.BookService.GetBooksBatchStream:input_type -> bookstore.GetBooksBatchRequest
+	2,  // 58: bookstore.BookService.ValidateBooks:input_type -> bookstore.Book
+	61, // 59: bookstore.BookService.ListChangedSince:input_type -> bookstore.ListChangedSinceRequest
+	4,  // 60: bookstore.BookService.CreateBook:output_type -> bookstore.CreateBookResponse
+	6,  // 61: bookstore.BookService.GetBook:output_type -> bookstore.GetBookResponse
+	8,  // 62: bookstore.BookService.UpdateBook:output_type -> bookstore.UpdateBookResponse
+	10, // 63: bookstore.BookService.DeleteBook:output_type -> bookstore.DeleteBookResponse
+	12, // 64: bookstore.BookService.ListBooks:output_type -> bookstore.ListBooksResponse
+	14, // 65: bookstore.BookService.SearchBooksByPrice:output_type -> bookstore.SearchBooksByPriceResponse
+	14, // 66: bookstore.BookService.GetBooksByExactPrice:output_type -> bookstore.SearchBooksByPriceResponse
+	18, // 67: bookstore.BookService.GetPriceStats:output_type -> bookstore.PriceStatsResponse
+	21, // 68: bookstore.BookService.StreamPriceHistogram:output_type -> bookstore.PriceHistogram
+	22, // 69: bookstore.BookService.OpenSnapshot:output_type -> bookstore.SnapshotResponse
+	23, // 70: bookstore.BookService.GetStats:output_type -> bookstore.StatsResponse
+	26, // 71: bookstore.BookService.AdjustPrices:output_type -> bookstore.AdjustPricesResponse
+	28, // 72: bookstore.BookService.RenameAuthor:output_type -> bookstore.RenameAuthorResponse
+	31, // 73: bookstore.BookService.FindDuplicates:output_type -> bookstore.FindDuplicatesResponse
+	33, // 74: bookstore.BookService.GetRandomBook:output_type -> bookstore.GetRandomBookResponse
+	34, // 75: bookstore.BookService.ReplaceCatalog:output_type -> bookstore.ReplaceCatalogResponse
+	39, // 76: bookstore.BookService.SetFeatured:output_type -> bookstore.FeaturedResponse
+	39, // 77: bookstore.BookService.UnsetFeatured:output_type -> bookstore.FeaturedResponse
+	40, // 78: bookstore.BookService.ListFeaturedBooks:output_type -> bookstore.ListFeaturedBooksResponse
+	42, // 79: bookstore.BookService.PurchaseBook:output_type -> bookstore.PurchaseBookResponse
+	44, // 80: bookstore.BookService.RestockBook:output_type -> bookstore.RestockBookResponse
+	46, // 81: bookstore.BookService.ReserveBook:output_type -> bookstore.ReserveResponse
+	48, // 82: bookstore.BookService.ConfirmReservation:output_type -> bookstore.ReservationResponse
+	48, // 83: bookstore.BookService.CancelReservation:output_type -> bookstore.ReservationResponse
+	50, // 84: bookstore.BookService.StreamBooks:output_type -> bookstore.StreamBooksResponse
+	54, // 85: bookstore.BookService.SearchBooksByPriceRanges:output_type -> bookstore.SearchBooksByPriceRangesResponse
+	57, // 86: bookstore.BookService.GetBooksByTitles:output_type -> bookstore.GetBooksByTitlesResponse
+	59, // 87: bookstore.BookService.StreamExport:output_type -> bookstore.ExportChunk
+	2,  // 88: bookstore.BookService.GetBooksBatchStream:output_type -> bookstore.Book
+	36, // 89: bookstore.BookService.ValidateBooks:output_type -> bookstore.ValidateBooksResponse
+	62, // 90: bookstore.BookService.ListChangedSince:output_type -> bookstore.ListChangedSinceResponse
+	60, // [60:91] is the sub-list for method output_type
+	29, // [29:60] is the sub-list for method input_type
+	29, // [29:29] is the sub-list for extension type_name
+	29, // [29:29] is the sub-list for extension extendee
+	0,  // [0:29] is the sub-list for field type_name
+}
+
+func init() { file_protos_bookstore_proto_init() }
+func file_protos_bookstore_proto_init() {
+	if File_protos_bookstore_proto != nil {
+		return
+	}
+	file_protos_bookstore_proto_msgTypes[0].OneofWrappers = []any{}
+	file_protos_bookstore_proto_msgTypes[23].OneofWrappers = []any{
+		(*AdjustPricesRequest_Percent)(nil),
+		(*AdjustPricesRequest_FixedDelta)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_protos_bookstore_proto_rawDesc), len(file_protos_bookstore_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   13,
+			NumEnums:      2,
+			NumMessages:   62,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_protos_bookstore_proto_goTypes,
 		DependencyIndexes: file_protos_bookstore_proto_depIdxs,
+		EnumInfos:         file_protos_bookstore_proto_enumTypes,
 		MessageInfos:      file_protos_bookstore_proto_msgTypes,
 	}.Build()
 	File_protos_bookstore_proto = out.File
@@ -0,0 +1,168 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	// 导入gRPC相关包
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// 日志输出方式：structured 为默认的结构化日志，access 为每次调用一行的访问日志
+const (
+	logFormatStructured = "structured"
+	logFormatAccess     = "access"
+)
+
+// defaultAccessLogFormat 默认的访问日志格式，与 Apache Common Log Format 相近
+const defaultAccessLogFormat = `%h %I [%t] "%m" %s %b %D`
+
+// accessLogTimeLayout %t 的时间格式，与 Apache 一致
+const accessLogTimeLayout = "02/Jan/2006:15:04:05 -0700"
+
+// accessLogDirectives 访问日志格式支持的占位符：%h 调用方地址，%t 请求开始时间，%m 完整方法名，
+// %s 状态码名称（如 OK、NotFound），%D 处理耗时（微秒），%I 请求ID，%b 响应大小（字节），%u 调用方的 user-agent，%% 百分号
+const accessLogDirectives = "htmsDIbu%"
+
+// accessLogFormat 解析后的访问日志格式
+type accessLogFormat string
+
+// parseAccessLogFormat 检查访问日志格式中的占位符是否都受支持
+func parseAccessLogFormat(format string) (accessLogFormat, error) {
+	for i := 0; i < len(format); i++ {
+		if format[i] != '%' {
+			continue
+		}
+		if i+1 == len(format) {
+			return "", fmt.Errorf("访问日志格式不能以单独的 %% 结尾: %q", format)
+		}
+		i++
+		if strings.IndexByte(accessLogDirectives, format[i]) < 0 {
+			return "", fmt.Errorf("访问日志格式包含不支持的占位符 %%%c: %q", format[i], format)
+		}
+	}
+	return accessLogFormat(format), nil
+}
+
+// accessLogEntry 一次调用的访问日志内容
+type accessLogEntry struct {
+	start     time.Time
+	method    string
+	peer      string
+	status    *status.Status
+	duration  time.Duration
+	requestID string
+	bytes     int
+	userAgent string
+}
+
+// format 按格式生成一行访问日志，空值输出为 -
+func (f accessLogFormat) format(entry accessLogEntry) string {
+	orDash := func(value string) string {
+		if value == "" {
+			return "-"
+		}
+		return value
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(f); i++ {
+		if f[i] != '%' || i+1 == len(f) {
+			b.WriteByte(f[i])
+			continue
+		}
+		i++
+		switch f[i] {
+		case 'h':
+			b.WriteString(orDash(entry.peer))
+		case 't':
+			b.WriteString(entry.start.Format(accessLogTimeLayout))
+		case 'm':
+			b.WriteString(entry.method)
+		case 's':
+			b.WriteString(entry.status.Code().String())
+		case 'D':
+			b.WriteString(strconv.FormatInt(entry.duration.Microseconds(), 10))
+		case 'I':
+			b.WriteString(orDash(entry.requestID))
+		case 'u':
+			b.WriteString(orDash(entry.userAgent))
+		case 'b':
+			if entry.bytes > 0 {
+				b.WriteString(strconv.Itoa(entry.bytes))
+			} else {
+				b.WriteByte('-')
+			}
+		default:
+			b.WriteByte(f[i])
+		}
+	}
+	return b.String()
+}
+
+// accessLogger 把访问日志逐行写入输出，可被多个调用并发使用
+type accessLogger struct {
+	mu     sync.Mutex
+	w      io.Writer
+	format accessLogFormat
+}
+
+// WithAccessLogOutput 设置访问日志（-log-format access）的输出，默认为标准输出
+func WithAccessLogOutput(w io.Writer) ServerOption {
+	return func(s *BookServer) {
+		s.accessLogOutput = w
+	}
+}
+
+// log 写入一行访问日志
+func (l *accessLogger) log(entry accessLogEntry) {
+	line := l.format.format(entry)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	fmt.Fprintln(l.w, line)
+}
+
+// newAccessLogInterceptor 创建访问日志拦截器，每次调用结束后写一行访问日志，代替结构化的调用日志。
+// 与日志拦截器一样按配置在响应尾部附加调试信息和记录慢查询
+func newAccessLogInterceptor(logger Logger, access *accessLogger, opts logInterceptorOptions) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		start := time.Now()
+		reqID := requestID(ctx)
+		var scan *scanStats
+		if opts.slowQueryThreshold > 0 {
+			ctx, scan = withScanStats(ctx)
+		}
+
+		resp, err := handler(ctx, req)
+
+		duration := time.Since(start)
+		if opts.trailers {
+			setDebugTrailer(ctx, logger, reqID, duration)
+		}
+		logSlowQuery(logger, opts, info.FullMethod, reqID, req, duration, scan)
+
+		entry := accessLogEntry{
+			start:     start,
+			method:    info.FullMethod,
+			peer:      peerAddr(ctx),
+			status:    status.Convert(err),
+			duration:  duration,
+			requestID: reqID,
+			userAgent: userAgent(ctx),
+		}
+		if msg, ok := resp.(proto.Message); ok && err == nil {
+			entry.bytes = proto.Size(msg)
+		}
+		access.log(entry)
+
+		return resp, err
+	}
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	// 导入生成的protobuf代码
+	pb "grpc-basic-server/pb"
+
+	// 导入gRPC相关包
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+)
+
+// syncBuffer 可并发写入的缓冲区，用于捕获访问日志
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+// Write 追加写入的内容
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+// String 返回已写入的全部内容
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+// TestAccessLog 测试访问日志模式下每次调用按格式写一行日志
+func TestAccessLog(t *testing.T) {
+	var out syncBuffer
+	client, server := startTestServer(t, mustParseConfig(t,
+		"-log-format", "access",
+		"-access-log-format", `%I "%m" %s %b 100%%`,
+	), WithAccessLogOutput(&out))
+	ids := server.loadBooks([]*pb.Book{{Title: "图书", Author: "作者", Price: proto.Float32(10)}})
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), trailerRequestID, "req-1")
+	resp, err := client.GetBook(ctx, &pb.GetBookRequest{Id: ids[0]})
+	if err != nil {
+		t.Fatalf("获取图书失败: %v", err)
+	}
+	ctx = metadata.AppendToOutgoingContext(context.Background(), trailerRequestID, "req-2")
+	client.GetBook(ctx, &pb.GetBookRequest{Id: "不存在"})
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	want := []string{
+		`req-1 "/bookstore.BookService/GetBook" OK ` + strconv.Itoa(proto.Size(resp)) + ` 100%`,
+		`req-2 "/bookstore.BookService/GetBook" NotFound - 100%`,
+	}
+	if len(lines) != len(want) {
+		t.Fatalf("期望%d行访问日志，实际为: %q", len(want), lines)
+	}
+	for i := range want {
+		if lines[i] != want[i] {
+			t.Errorf("第%d行期望 %q，实际为: %q", i+1, want[i], lines[i])
+		}
+	}
+}
+
+// TestParseAccessLogFormat 测试访问日志格式的校验
+func TestParseAccessLogFormat(t *testing.T) {
+	if _, err := parseAccessLogFormat(defaultAccessLogFormat); err != nil {
+		t.Errorf("默认格式应合法: %v", err)
+	}
+	for _, format := range []string{"%x", "结尾%"} {
+		if _, err := parseAccessLogFormat(format); err == nil {
+			t.Errorf("期望格式 %q 校验失败", format)
+		}
+	}
+	if _, err := parseConfig([]string{"-log-format", "text"}); err == nil {
+		t.Error("期望无效的日志输出方式解析失败")
+	}
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"context"
+
+	// 导入生成的protobuf代码
+	pb "grpc-basic-server/pb"
+
+	// 导入gRPC相关包
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// AdjustPrices 按过滤条件批量调整价格（百分比或固定金额）
+func (s *BookServer) AdjustPrices(ctx context.Context, req *pb.AdjustPricesRequest) (*pb.AdjustPricesResponse, error) {
+	// 记录请求日志
+	s.logger.Info("收到批量调整价格请求", "filter", req.GetFilter(), "adjustment", req.GetAdjustment())
+
+	// 验证请求参数
+	if err := validateFilter(req.GetFilter()); err != nil {
+		return nil, err
+	}
+
+	// 根据调整方式构造计算函数，使用 float64 计算减少精度损失
+	var adjust func(price float32) float64
+	switch adjustment := req.GetAdjustment().(type) {
+	case *pb.AdjustPricesRequest_Percent:
+		if !isFinite(adjustment.Percent) {
+			return nil, status.Errorf(codes.InvalidArgument, "调整百分比必须是有效数字")
+		}
+		adjust = func(price float32) float64 {
+			return float64(price) * (1 + float64(adjustment.Percent)/100)
+		}
+	case *pb.AdjustPricesRequest_FixedDelta:
+		if !isFinite(adjustment.FixedDelta) {
+			return nil, status.Errorf(codes.InvalidArgument, "调整金额必须是有效数字")
+		}
+		adjust = func(price float32) float64 {
+			return float64(price) + float64(adjustment.FixedDelta)
+		}
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "必须指定调整百分比或调整金额")
+	}
+
+	// 加写锁，保证所有匹配图书在一次操作中完成调整
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.clock.Now()
+	resp := &pb.AdjustPricesResponse{}
+	catalog := s.catalogFor(ctx, false)
+	for _, book := range catalog.candidates(req.GetFilter()) {
+		if !matchFilter(book, req.GetFilter()) {
+			continue
+		}
+		id := book.GetId()
+
+		// 调整后价格不为正的图书跳过并报告，超过上限的截断为上限
+		price := adjust(book.GetPrice())
+		if price <= 0 || float32(price) <= 0 {
+			resp.SkippedIds = append(resp.SkippedIds, id)
+			continue
+		}
+		if price > maxBookPrice {
+			price = maxBookPrice
+		}
+
+		// 替换为新的副本，不原地修改已存储的图书
+		updated := proto.Clone(book).(*pb.Book)
+		updated.Price = proto.Float32(float32(price))
+		catalog.put(updated, now)
+		resp.UpdatedCount++
+	}
+
+	sortIDs(resp.SkippedIds)
+
+	s.logger.Info("批量调整价格完成", "updated", resp.UpdatedCount, "skipped", len(resp.SkippedIds))
+
+	return resp, nil
+}
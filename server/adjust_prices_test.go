@@ -0,0 +1,81 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	// 导入生成的protobuf代码
+	pb "grpc-basic-server/pb"
+
+	// 导入gRPC相关包
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// TestAdjustPricesPercent 测试对价格区间内的图书打九折
+func TestAdjustPricesPercent(t *testing.T) {
+	// 创建服务器实例
+	server := NewBookServer()
+	ids := server.loadBooks([]*pb.Book{
+		{Title: "便宜图书", Author: "作者1", Price: proto.Float32(10)},
+		{Title: "中等图书1", Author: "作者2", Price: proto.Float32(30)},
+		{Title: "中等图书2", Author: "作者3", Price: proto.Float32(40)},
+		{Title: "昂贵图书", Author: "作者4", Price: proto.Float32(100)},
+	})
+
+	resp, err := server.AdjustPrices(context.Background(), &pb.AdjustPricesRequest{
+		Filter:     &pb.BookFilter{MinPrice: 20, MaxPrice: 50},
+		Adjustment: &pb.AdjustPricesRequest_Percent{Percent: -10},
+	})
+	if err != nil {
+		t.Fatalf("调整价格失败: %v", err)
+	}
+	if resp.UpdatedCount != 2 {
+		t.Errorf("期望调整2本图书，实际为: %d", resp.UpdatedCount)
+	}
+
+	// 验证调整后的价格，区间外的图书保持不变
+	expected := []float32{10, 27, 36, 100}
+	for i, id := range ids {
+		if price := server.books[id].GetPrice(); !floatEquals(price, expected[i]) {
+			t.Errorf("图书 %s 期望价格为%.2f，实际为: %.2f", id, expected[i], price)
+		}
+	}
+}
+
+// TestAdjustPricesSkipNonPositive 测试调整后价格不为正的图书被跳过
+func TestAdjustPricesSkipNonPositive(t *testing.T) {
+	// 创建服务器实例
+	server := NewBookServer()
+	ids := server.loadBooks([]*pb.Book{
+		{Title: "图书1", Author: "作者", Price: proto.Float32(3)},
+		{Title: "图书2", Author: "作者", Price: proto.Float32(20)},
+	})
+
+	resp, err := server.AdjustPrices(context.Background(), &pb.AdjustPricesRequest{
+		Adjustment: &pb.AdjustPricesRequest_FixedDelta{FixedDelta: -5},
+	})
+	if err != nil {
+		t.Fatalf("调整价格失败: %v", err)
+	}
+	if resp.UpdatedCount != 1 || len(resp.SkippedIds) != 1 || resp.SkippedIds[0] != ids[0] {
+		t.Errorf("期望调整1本并跳过 %s，实际为: %v", ids[0], resp)
+	}
+	if price := server.books[ids[0]].GetPrice(); !floatEquals(price, 3) {
+		t.Errorf("被跳过的图书价格不应改变，实际为: %.2f", price)
+	}
+	if price := server.books[ids[1]].GetPrice(); !floatEquals(price, 15) {
+		t.Errorf("期望价格为15，实际为: %.2f", price)
+	}
+}
+
+// TestAdjustPricesRequiresAdjustment 测试未指定调整方式时返回错误
+func TestAdjustPricesRequiresAdjustment(t *testing.T) {
+	server := NewBookServer()
+
+	_, err := server.AdjustPrices(context.Background(), &pb.AdjustPricesRequest{})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("期望错误码为InvalidArgument，实际为: %v", status.Code(err))
+	}
+}
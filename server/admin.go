@@ -0,0 +1,94 @@
+package main
+
+import (
+	"context"
+	"crypto/subtle"
+	"sort"
+
+	// 导入生成的protobuf代码
+	pb "grpc-basic-server/pb"
+	pbv2 "grpc-basic-server/pb/v2"
+
+	// 导入gRPC相关包
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// adminTokenMetadataKey 调用管理接口时携带管理令牌的元数据键
+const adminTokenMetadataKey = "x-admin-token"
+
+// WithAdminToken 开启管理接口，调用时需要在元数据中携带该令牌；为空表示不开启
+func WithAdminToken(token string) ServerOption {
+	return func(s *BookServer) {
+		s.adminToken = token
+	}
+}
+
+// checkAdmin 检查调用方是否为管理员：未开启管理接口或令牌不正确时返回 PermissionDenied
+func (s *BookServer) checkAdmin(ctx context.Context) error {
+	if s.adminToken == "" {
+		return status.Errorf(codes.PermissionDenied, "管理接口未开启")
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	vals := md.Get(adminTokenMetadataKey)
+	if len(vals) == 0 || subtle.ConstantTimeCompare([]byte(vals[0]), []byte(s.adminToken)) != 1 {
+		return status.Errorf(codes.PermissionDenied, "管理令牌无效")
+	}
+	return nil
+}
+
+// DebugDump 逐条发送所有租户的图书及元信息，用于排查问题，仅管理员可调用
+func (v *bookServiceV2) DebugDump(_ *emptypb.Empty, stream grpc.ServerStreamingServer[pbv2.DebugDumpEntry]) error {
+	s := v.s
+	if err := s.checkAdmin(stream.Context()); err != nil {
+		s.logger.Warn("拒绝调试导出请求", "error", err)
+		return err
+	}
+
+	// 在读锁内只收集图书和元信息的引用（两者都不会被原地修改），序列化和发送在锁外进行
+	type dumpEntry struct {
+		tenant string
+		book   *pb.Book
+		meta   *bookMeta
+	}
+	s.mu.RLock()
+	tenants := make([]string, 0, len(s.tenants)+1)
+	tenants = append(tenants, "")
+	for tenant := range s.tenants {
+		tenants = append(tenants, tenant)
+	}
+	sort.Strings(tenants[1:])
+
+	var entries []dumpEntry
+	for _, tenant := range tenants {
+		catalog := &s.bookCatalog
+		if tenant != "" {
+			catalog = s.tenants[tenant]
+		}
+		books := make([]*pb.Book, 0, len(catalog.books))
+		for _, book := range catalog.books {
+			books = append(books, book)
+		}
+		sortBooksByID(books)
+		for _, book := range books {
+			entries = append(entries, dumpEntry{tenant: tenant, book: book, meta: catalog.metaFor(book.GetId())})
+		}
+	}
+	s.mu.RUnlock()
+
+	s.logger.Warn("开始调试导出", "books", len(entries))
+
+	for _, entry := range entries {
+		if err := stream.Context().Err(); err != nil {
+			return status.FromContextError(err).Err()
+		}
+		if err := stream.Send(&pbv2.DebugDumpEntry{Tenant: entry.tenant, Book: toV2Book(entry.book, entry.meta)}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	// 导入生成的protobuf代码
+	pb "grpc-basic-server/pb"
+	pbv2 "grpc-basic-server/pb/v2"
+
+	// 导入gRPC相关包
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// recvDump 读取调试导出的所有记录
+func recvDump(ctx context.Context, client pbv2.BookServiceV2Client) ([]*pbv2.DebugDumpEntry, error) {
+	stream, err := client.DebugDump(ctx, &emptypb.Empty{})
+	if err != nil {
+		return nil, err
+	}
+	var entries []*pbv2.DebugDumpEntry
+	for {
+		entry, err := stream.Recv()
+		if err == io.EOF {
+			return entries, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+}
+
+// TestDebugDumpPermissionDenied 测试未开启管理接口或令牌错误时拒绝调试导出
+func TestDebugDumpPermissionDenied(t *testing.T) {
+	tests := []struct {
+		name  string
+		args  []string
+		token string
+	}{
+		{"未开启管理接口", nil, "secret"},
+		{"缺少令牌", []string{"-enable-admin", "-admin-token", "secret"}, ""},
+		{"令牌错误", []string{"-enable-admin", "-admin-token", "secret"}, "wrong"},
+	}
+	for _, tt := range tests {
+		conn, _ := startTestConn(t, mustParseConfig(t, tt.args...))
+		ctx := context.Background()
+		if tt.token != "" {
+			ctx = metadata.AppendToOutgoingContext(ctx, adminTokenMetadataKey, tt.token)
+		}
+
+		_, err := recvDump(ctx, pbv2.NewBookServiceV2Client(conn))
+		if status.Code(err) != codes.PermissionDenied {
+			t.Errorf("%s: 期望错误码为PermissionDenied，实际为: %v", tt.name, err)
+		}
+	}
+}
+
+// TestDebugDump 测试管理员可以导出所有图书及元信息
+func TestDebugDump(t *testing.T) {
+	conn, _ := startTestConn(t, mustParseConfig(t, "-enable-admin", "-admin-token", "secret"))
+	v1 := pb.NewBookServiceClient(conn)
+	ctx := context.Background()
+
+	for _, title := range []string{"图书1", "图书2", "图书3"} {
+		if _, err := v1.CreateBook(ctx, &pb.CreateBookRequest{Book: &pb.Book{Title: title, Author: "作者", Price: proto.Float32(10)}}); err != nil {
+			t.Fatalf("创建图书失败: %v", err)
+		}
+	}
+	if _, err := v1.UpdateBook(ctx, &pb.UpdateBookRequest{Book: &pb.Book{Id: "book-2", Title: "新书名", Author: "作者", Price: proto.Float32(10)}}); err != nil {
+		t.Fatalf("更新图书失败: %v", err)
+	}
+
+	ctx = metadata.AppendToOutgoingContext(ctx, adminTokenMetadataKey, "secret")
+	entries, err := recvDump(ctx, pbv2.NewBookServiceV2Client(conn))
+	if err != nil {
+		t.Fatalf("调试导出失败: %v", err)
+	}
+	if len(entries) != 3 {
+		t.Fatalf("期望导出3本图书，实际为: %d", len(entries))
+	}
+	if book := entries[1].GetBook(); book.GetTitle() != "新书名" || book.GetVersion() != 2 || book.GetCreatedAt() == nil {
+		t.Errorf("期望导出图书的内容和元信息，实际为: %v", book)
+	}
+}
+
+// TestAdminTokenRequired 测试开启管理接口但未设置令牌时配置无效
+func TestAdminTokenRequired(t *testing.T) {
+	if _, err := parseConfig([]string{"-enable-admin"}); err == nil {
+		t.Errorf("期望未设置管理令牌时返回错误")
+	}
+}
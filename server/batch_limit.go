@@ -0,0 +1,25 @@
+package main
+
+import (
+	// 导入gRPC相关包
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// defaultMaxBatchSize 批量方法单次请求（流式方法为整个流）默认允许的最大条目数
+const defaultMaxBatchSize = 1000
+
+// WithMaxBatchSize 设置批量方法单次请求允许的最大条目数，避免过大的消息和过长的持锁时间
+func WithMaxBatchSize(size int) ServerOption {
+	return func(s *BookServer) {
+		s.maxBatchSize = size
+	}
+}
+
+// checkBatchSize 批量请求的条目数 count 超过上限时返回 InvalidArgument，提示客户端拆分请求
+func (s *BookServer) checkBatchSize(count int) error {
+	if count > s.maxBatchSize {
+		return status.Errorf(codes.InvalidArgument, "批量请求包含%d项，超过上限%d，请拆分为多个请求", count, s.maxBatchSize)
+	}
+	return nil
+}
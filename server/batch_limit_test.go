@@ -0,0 +1,73 @@
+package main
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	// 导入生成的protobuf代码
+	pb "grpc-basic-server/pb"
+	pbv2 "grpc-basic-server/pb/v2"
+
+	// 导入gRPC相关包
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// TestMaxBatchSize 测试批量方法的条目数等于上限时成功，超过上限1个时返回 InvalidArgument
+func TestMaxBatchSize(t *testing.T) {
+	conn, _ := startTestConn(t, mustParseConfig(t, "-max-batch-size", "3"))
+	v2 := pbv2.NewBookServiceV2Client(conn)
+	ctx := context.Background()
+
+	ids := []string{"book-1", "book-2", "book-3"}
+	resp, err := v2.AddTags(ctx, &pbv2.AddTagsRequest{Ids: ids, Tags: []string{"go"}})
+	if err != nil || len(resp.GetResults()) != 3 {
+		t.Fatalf("条目数等于上限时应当成功，实际为: %v, %v", resp, err)
+	}
+
+	_, err = v2.RemoveTags(ctx, &pbv2.RemoveTagsRequest{Ids: append(ids, "book-4"), Tags: []string{"go"}})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("期望错误码为InvalidArgument，实际为: %v", err)
+	}
+}
+
+// TestMaxBatchSizeStream 测试流式批量方法按整个流累计条目数，超过上限后返回 InvalidArgument
+func TestMaxBatchSizeStream(t *testing.T) {
+	client, server := startTestServer(t, mustParseConfig(t, "-max-batch-size", "3"))
+	ids := server.loadBooks([]*pb.Book{
+		{Title: "图书1", Author: "作者", Price: proto.Float32(10)},
+		{Title: "图书2", Author: "作者", Price: proto.Float32(10)},
+		{Title: "图书3", Author: "作者", Price: proto.Float32(10)},
+	})
+
+	stream, err := client.GetBooksBatchStream(context.Background())
+	if err != nil {
+		t.Fatalf("打开流失败: %v", err)
+	}
+	for _, chunk := range [][]string{ids[:2], ids[2:], {"book-4"}} {
+		if err := stream.Send(&pb.GetBooksBatchRequest{Ids: chunk}); err != nil {
+			break
+		}
+	}
+	stream.CloseSend()
+
+	received := 0
+	for {
+		_, err := stream.Recv()
+		if err == io.EOF {
+			t.Fatalf("期望超过上限后返回错误")
+		}
+		if err != nil {
+			if status.Code(err) != codes.InvalidArgument {
+				t.Errorf("期望错误码为InvalidArgument，实际为: %v", err)
+			}
+			break
+		}
+		received++
+	}
+	if received != 3 {
+		t.Errorf("期望超过上限前收到3本图书，实际为%d本", received)
+	}
+}
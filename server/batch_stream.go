@@ -0,0 +1,90 @@
+package main
+
+import (
+	"io"
+	"strconv"
+	"strings"
+
+	// 导入生成的protobuf代码
+	pb "grpc-basic-server/pb"
+
+	// 导入gRPC相关包
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// 报告不存在的图书的响应尾部元数据键
+	trailerMissingCount = "x-missing-count"
+	trailerMissingIDs   = "x-missing-ids"
+
+	// maxReportedMissingIDs 响应尾部最多报告的不存在图书ID数量，避免尾部过大
+	maxReportedMissingIDs = 100
+)
+
+// GetBooksBatchStream 流式批量获取图书：客户端分批发送ID，服务端按批次返回找到的图书。
+// 不存在的图书被跳过，数量和（最多前100个）ID通过响应尾部元数据报告。
+// 整个流中的ID总数超过批量上限时返回 InvalidArgument，已发送的图书不受影响
+func (s *BookServer) GetBooksBatchStream(stream grpc.BidiStreamingServer[pb.GetBooksBatchRequest, pb.Book]) error {
+	ctx := stream.Context()
+
+	// 记录请求日志
+	s.logger.Info("收到流式批量获取图书请求")
+
+	requested := 0
+	found := 0
+	missing := 0
+	var missingIDs []string
+	for {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		requested += len(req.GetIds())
+		if err := s.checkBatchSize(requested); err != nil {
+			return err
+		}
+
+		// 在读锁内查找本批次的图书，发送时不持有锁
+		s.mu.RLock()
+		catalog := s.catalogFor(ctx, false)
+		books := make([]*pb.Book, 0, len(req.GetIds()))
+		for _, id := range req.GetIds() {
+			book, err := catalog.get(id)
+			if err != nil || !s.validForRead(book) {
+				missing++
+				if len(missingIDs) < maxReportedMissingIDs {
+					missingIDs = append(missingIDs, id)
+				}
+				continue
+			}
+			books = append(books, book)
+		}
+		s.mu.RUnlock()
+
+		for _, book := range books {
+			if err := ctx.Err(); err != nil {
+				return status.FromContextError(err).Err()
+			}
+			if err := stream.Send(book); err != nil {
+				return err
+			}
+		}
+		found += len(books)
+	}
+
+	if missing > 0 {
+		stream.SetTrailer(metadata.Pairs(
+			trailerMissingCount, strconv.Itoa(missing),
+			trailerMissingIDs, strings.Join(missingIDs, ","),
+		))
+	}
+
+	s.logger.Info("流式批量获取图书完成", "found", found, "missing", missing)
+
+	return nil
+}
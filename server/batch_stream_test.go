@@ -0,0 +1,68 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"testing"
+
+	// 导入生成的protobuf代码
+	pb "grpc-basic-server/pb"
+
+	// 导入gRPC相关包
+	"google.golang.org/protobuf/proto"
+)
+
+// TestGetBooksBatchStream 测试分批发送1000个ID并收到所有存在的图书
+func TestGetBooksBatchStream(t *testing.T) {
+	// 1000个存在的ID加上混入的不存在ID超过默认的批量上限
+	client, server := startTestServer(t, mustParseConfig(t, "-max-batch-size", "2000"))
+	books := make([]*pb.Book, 1000)
+	for i := range books {
+		books[i] = &pb.Book{Title: fmt.Sprintf("图书%d", i), Author: "作者", Price: proto.Float32(10)}
+	}
+	ids := server.loadBooks(books)
+
+	stream, err := client.GetBooksBatchStream(context.Background())
+	if err != nil {
+		t.Fatalf("打开流失败: %v", err)
+	}
+
+	// 每批100个ID，并混入不存在的ID
+	go func() {
+		for start := 0; start < len(ids); start += 100 {
+			chunk := append([]string{fmt.Sprintf("missing-%d", start)}, ids[start:start+100]...)
+			if err := stream.Send(&pb.GetBooksBatchRequest{Ids: chunk}); err != nil {
+				return
+			}
+		}
+		stream.CloseSend()
+	}()
+
+	received := make(map[string]bool)
+	for {
+		book, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("接收图书失败: %v", err)
+		}
+		received[book.GetId()] = true
+	}
+
+	if len(received) != len(ids) {
+		t.Errorf("期望收到%d本图书，实际为: %d", len(ids), len(received))
+	}
+	for _, id := range ids {
+		if !received[id] {
+			t.Fatalf("缺少图书: %s", id)
+		}
+	}
+
+	// 不存在的图书通过响应尾部报告
+	trailer := stream.Trailer()
+	if got := trailer.Get(trailerMissingCount); len(got) != 1 || got[0] != "10" {
+		t.Errorf("期望报告10本不存在的图书，实际为: %v", got)
+	}
+}
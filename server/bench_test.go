@@ -0,0 +1,225 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+	"testing"
+
+	// 导入生成的protobuf代码
+	pb "grpc-basic-server/pb"
+
+	// 导入gRPC相关包
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+)
+
+// benchStoreSizes 基准测试使用的存储规模
+var benchStoreSizes = []int{100, 1000, 10000}
+
+// silenceLog 关闭基准测试（或大量调用的测试）期间的日志输出，避免日志开销干扰结果
+func silenceLog(tb testing.TB) {
+	tb.Helper()
+
+	log.SetOutput(io.Discard)
+	tb.Cleanup(func() { log.SetOutput(os.Stderr) })
+}
+
+// newPopulatedServer 创建预置 n 本图书的服务器，价格在 1-100 之间均匀分布
+func newPopulatedServer(n int) (*BookServer, []string) {
+	server := NewBookServer()
+
+	books := make([]*pb.Book, n)
+	for i := range books {
+		books[i] = &pb.Book{
+			Title:       fmt.Sprintf("图书%d", i),
+			Author:      fmt.Sprintf("作者%d", i%50),
+			Price:       proto.Float32(float32(i%100 + 1)),
+			Description: proto.String("基准测试图书"),
+			PublishYear: proto.Int32(int32(1990 + i%30)),
+		}
+	}
+	return server, server.loadBooks(books)
+}
+
+// BenchmarkCreateBook 基准测试创建图书
+func BenchmarkCreateBook(b *testing.B) {
+	silenceLog(b)
+	server := NewBookServer()
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		req := &pb.CreateBookRequest{Book: &pb.Book{Title: "图书", Author: "作者", Price: proto.Float32(29.99)}}
+		if _, err := server.CreateBook(context.Background(), req); err != nil {
+			b.Fatalf("创建图书失败: %v", err)
+		}
+	}
+}
+
+// BenchmarkGetBook 基准测试获取图书
+func BenchmarkGetBook(b *testing.B) {
+	silenceLog(b)
+	server, ids := newPopulatedServer(1000)
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		req := &pb.GetBookRequest{Id: ids[i%len(ids)]}
+		if _, err := server.GetBook(context.Background(), req); err != nil {
+			b.Fatalf("获取图书失败: %v", err)
+		}
+	}
+}
+
+// BenchmarkListBooks 基准测试不同存储规模下的分页查询
+func BenchmarkListBooks(b *testing.B) {
+	silenceLog(b)
+	for _, size := range benchStoreSizes {
+		b.Run(fmt.Sprintf("books=%d", size), func(b *testing.B) {
+			server, _ := newPopulatedServer(size)
+			b.ReportAllocs()
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				req := &pb.ListBooksRequest{Page: 2, PageSize: 20}
+				if _, err := server.ListBooks(context.Background(), req); err != nil {
+					b.Fatalf("列出图书失败: %v", err)
+				}
+			}
+		})
+	}
+}
+
+// BenchmarkSearchBooksByPrice 基准测试不同存储规模下的价格区间查询
+func BenchmarkSearchBooksByPrice(b *testing.B) {
+	silenceLog(b)
+	for _, size := range benchStoreSizes {
+		b.Run(fmt.Sprintf("books=%d", size), func(b *testing.B) {
+			server, _ := newPopulatedServer(size)
+			b.ReportAllocs()
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				req := &pb.SearchBooksByPriceRequest{MinPrice: 30, MaxPrice: 50}
+				if _, err := server.SearchBooksByPrice(context.Background(), req); err != nil {
+					b.Fatalf("按价格查询图书失败: %v", err)
+				}
+			}
+		})
+	}
+}
+
+// BenchmarkCreateBookLogging 基准测试经过完整拦截器链创建图书时，逐次调用日志对吞吐量的影响
+func BenchmarkCreateBookLogging(b *testing.B) {
+	silenceLog(b)
+	for _, args := range [][]string{{"-log-level", "info"}, {"-quiet"}} {
+		b.Run(strings.Join(args, "="), func(b *testing.B) {
+			cfg, err := parseConfig(append(args, "-debug-trailers=false"))
+			if err != nil {
+				b.Fatalf("解析参数失败: %v", err)
+			}
+			_, server, err := newGRPCServer(cfg)
+			if err != nil {
+				b.Fatalf("创建服务失败: %v", err)
+			}
+			info := &grpc.UnaryServerInfo{FullMethod: "/bookstore.BookService/CreateBook"}
+			handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+				return server.CreateBook(ctx, req.(*pb.CreateBookRequest))
+			}
+			b.ReportAllocs()
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				req := &pb.CreateBookRequest{Book: &pb.Book{Title: "图书", Author: "作者", Price: proto.Float32(29.99)}}
+				if _, err := server.unaryChain(context.Background(), req, info, handler); err != nil {
+					b.Fatalf("创建图书失败: %v", err)
+				}
+			}
+		})
+	}
+}
+
+// BenchmarkAuthorLookup 基准测试按作者过滤时，使用索引与遍历整个存储的差异
+func BenchmarkAuthorLookup(b *testing.B) {
+	silenceLog(b)
+	filter := &pb.BookFilter{Author: "作者7"}
+	lookups := map[string]func(c *bookCatalog) []*pb.Book{
+		"index": func(c *bookCatalog) []*pb.Book { return c.candidates(filter) },
+		"scan":  func(c *bookCatalog) []*pb.Book { return c.candidates(nil) },
+	}
+	for _, size := range benchStoreSizes {
+		for _, name := range []string{"index", "scan"} {
+			b.Run(fmt.Sprintf("books=%d/%s", size, name), func(b *testing.B) {
+				server, _ := newPopulatedServer(size)
+				b.ReportAllocs()
+				b.ResetTimer()
+
+				for i := 0; i < b.N; i++ {
+					matched := 0
+					for _, book := range lookups[name](&server.bookCatalog) {
+						if matchFilter(book, filter) {
+							matched++
+						}
+					}
+					if matched != size/50 {
+						b.Fatalf("期望匹配%d本图书，实际为%d本", size/50, matched)
+					}
+				}
+			})
+		}
+	}
+}
+
+// BenchmarkSearchBooksByPriceIndex 基准测试10万本图书时，价格索引查询与遍历所有图书的差异
+func BenchmarkSearchBooksByPriceIndex(b *testing.B) {
+	silenceLog(b)
+	server, _ := newPopulatedServer(100000)
+	searches := map[string]func() int{
+		"index": func() int {
+			resp, err := server.SearchBooksByPrice(context.Background(), &pb.SearchBooksByPriceRequest{MinPrice: 30, MaxPrice: 31})
+			if err != nil {
+				b.Fatalf("按价格查询图书失败: %v", err)
+			}
+			return len(resp.GetBooks())
+		},
+		"scan": func() int { return len(scanPriceRange(b, server, 30, 31)) },
+	}
+
+	for _, name := range []string{"index", "scan"} {
+		b.Run(name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if n := searches[name](); n != 2000 {
+					b.Fatalf("期望找到2000本图书，实际为%d本", n)
+				}
+			}
+		})
+	}
+}
+
+// BenchmarkGetBookResponsePool 基准测试经过 gRPC 调用 GetBook 时消息池对内存分配的影响
+func BenchmarkGetBookResponsePool(b *testing.B) {
+	silenceLog(b)
+	for _, enabled := range []bool{false, true} {
+		b.Run(fmt.Sprintf("pool=%v", enabled), func(b *testing.B) {
+			args := []string{"-log-level", "error"}
+			if enabled {
+				args = append(args, "-response-pool")
+			}
+			client, server := startTestServer(b, mustParseConfig(b, args...))
+			ids := server.loadBooks([]*pb.Book{{Title: "图书", Author: "作者", Price: proto.Float32(10)}})
+			ctx := context.Background()
+			b.ReportAllocs()
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				if _, err := client.GetBook(ctx, &pb.GetBookRequest{Id: ids[0]}); err != nil {
+					b.Fatalf("获取图书失败: %v", err)
+				}
+			}
+		})
+	}
+}
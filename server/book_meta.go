@@ -0,0 +1,79 @@
+package main
+
+import (
+	"time"
+
+	// 导入生成的protobuf代码
+	pb "grpc-basic-server/pb"
+
+	// 导入gRPC相关包
+	"google.golang.org/protobuf/proto"
+)
+
+// bookMeta 图书的元信息，v1 的 Book 消息中没有这些字段，单独保存以保持 v1 接口不变
+type bookMeta struct {
+	// 标签，只能通过 v2 接口设置
+	tags []string
+
+	// 创建时间和最后修改时间
+	createdAt time.Time
+	updatedAt time.Time
+
+	// 版本号，每次修改后递增
+	version int64
+}
+
+// put 保存图书并更新元信息和索引：新图书的版本号为1，已有图书的版本号递增，
+// 图书的 last_modified_seq 设置为新的变更序号。
+// 与已存储的图书一样，元信息不会被原地修改，调用方需持有写锁
+func (c *bookCatalog) put(book *pb.Book, now time.Time) *bookMeta {
+	if c.meta == nil {
+		c.meta = make(map[string]*bookMeta)
+	}
+
+	// 传入的是已存储的图书时（如只修改元信息），复制后再设置变更序号，已存储的图书不会被原地修改
+	if c.books[book.GetId()] == book {
+		book = proto.Clone(book).(*pb.Book)
+	}
+	book.LastModifiedSeq = c.nextSeq()
+
+	meta := &bookMeta{createdAt: now, updatedAt: now, version: 1}
+	if old, exists := c.meta[book.GetId()]; exists {
+		meta.tags = old.tags
+		meta.createdAt = old.createdAt
+		meta.version = old.version + 1
+	}
+	if old, exists := c.books[book.GetId()]; exists {
+		c.index.delete(old)
+	}
+	c.index.add(book)
+	c.books[book.GetId()] = book
+	c.meta[book.GetId()] = meta
+	c.changes.notify()
+	return meta
+}
+
+// remove 删除图书及其元信息、索引和未确认的预留，调用方需持有写锁。
+// 预留随图书一起删除，之后即使以相同ID（客户端指定的ID）重新创建图书，旧的预留也不会扣减新图书的库存
+func (c *bookCatalog) remove(id string) {
+	if book, exists := c.books[id]; exists {
+		c.index.delete(book)
+		c.recordDelete(id)
+	}
+	for reservationID, r := range c.reservations {
+		if r.bookID == id {
+			delete(c.reservations, reservationID)
+		}
+	}
+	delete(c.books, id)
+	delete(c.meta, id)
+	c.changes.notify()
+}
+
+// metaFor 返回图书的元信息，不存在时返回零值，调用方需持有 s.mu
+func (c *bookCatalog) metaFor(id string) *bookMeta {
+	if meta, exists := c.meta[id]; exists {
+		return meta
+	}
+	return &bookMeta{}
+}
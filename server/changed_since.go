@@ -0,0 +1,174 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	// 导入生成的protobuf代码
+	pb "grpc-basic-server/pb"
+
+	// 导入gRPC相关包
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// 删除记录（墓碑）默认的保留条数和保留时间
+const (
+	defaultTombstoneLimit     = 10000
+	defaultTombstoneRetention = 24 * time.Hour
+)
+
+// reasonFullResync 同步水位无法增量同步、需要重新全量同步时错误详情 ErrorInfo 的原因
+const reasonFullResync = "FULL_RESYNC_REQUIRED"
+
+// tombstone 一条删除记录
+type tombstone struct {
+	id        string
+	seq       int64
+	deletedAt time.Time
+}
+
+// tombstonePolicy 删除记录的保留策略，所有租户的存储共用
+type tombstonePolicy struct {
+	// 每个存储最多保留的删除记录条数
+	limit int
+
+	// 删除记录的保留时间，0 表示只按条数限制
+	retention time.Duration
+
+	// 当前时间，使用服务器的时钟
+	now func() time.Time
+}
+
+// WithTombstoneRetention 设置每个存储保留的删除记录条数和保留时间（0 表示只按条数限制）。
+// 超出的删除记录被丢弃，早于被丢弃记录的同步水位只能重新全量同步
+func WithTombstoneRetention(limit int, retention time.Duration) ServerOption {
+	return func(s *BookServer) {
+		s.tombstonePolicy.limit = limit
+		s.tombstonePolicy.retention = retention
+	}
+}
+
+// changeLog 存储的变更序号和最近的删除记录。每次 put/remove 序号加1，
+// 修改的图书记录在 last_modified_seq 中，删除的图书记录在 tombstones 中
+type changeLog struct {
+	// 最近一次修改的变更序号，从1开始
+	seq int64
+
+	// 按序号递增的删除记录
+	tombstones []tombstone
+
+	// 已丢弃的删除记录中最大的序号，小于它的同步水位无法确定期间删除了哪些图书
+	trimmedSeq int64
+
+	// 删除记录的保留策略，为 nil 时使用默认策略
+	policy *tombstonePolicy
+}
+
+// nextSeq 分配新的变更序号，调用方需持有写锁
+func (l *changeLog) nextSeq() int64 {
+	l.seq++
+	return l.seq
+}
+
+// recordDelete 为删除分配变更序号并记录，按保留策略丢弃超出条数或过期的记录，调用方需持有写锁
+func (l *changeLog) recordDelete(id string) {
+	policy := l.policy
+	if policy == nil {
+		policy = &tombstonePolicy{limit: defaultTombstoneLimit, retention: defaultTombstoneRetention, now: time.Now}
+	}
+
+	now := policy.now()
+	l.tombstones = append(l.tombstones, tombstone{id: id, seq: l.nextSeq(), deletedAt: now})
+
+	drop := 0
+	if excess := len(l.tombstones) - policy.limit; excess > 0 {
+		drop = excess
+	}
+	if policy.retention > 0 {
+		expired := now.Add(-policy.retention)
+		for drop < len(l.tombstones) && l.tombstones[drop].deletedAt.Before(expired) {
+			drop++
+		}
+	}
+	if drop > 0 {
+		l.trimmedSeq = l.tombstones[drop-1].seq
+		l.tombstones = l.tombstones[drop:]
+	}
+}
+
+// fullResyncError 同步水位 since 无法增量同步时返回的 OutOfRange 错误，
+// 错误详情中的 ErrorInfo 以 FULL_RESYNC_REQUIRED 告知客户端需要通过 ListBooks 重新全量同步
+func fullResyncError(message string, since, current int64) error {
+	st, err := status.New(codes.OutOfRange, message).WithDetails(&errdetails.ErrorInfo{
+		Reason: reasonFullResync,
+		Domain: errorDomain,
+		Metadata: map[string]string{
+			"since_seq":   strconv.FormatInt(since, 10),
+			"current_seq": strconv.FormatInt(current, 10),
+		},
+	})
+	if err != nil {
+		return status.Error(codes.OutOfRange, message)
+	}
+	return st.Err()
+}
+
+// changedSince 返回序号 since 之后被修改的图书（按序号排序）和被删除且目前不存在的图书ID，调用方需持有读锁
+func (c *bookCatalog) changedSince(since int64) ([]*pb.Book, []string) {
+	var books []*pb.Book
+	for _, book := range c.books {
+		if book.GetLastModifiedSeq() > since {
+			books = append(books, book)
+		}
+	}
+	sort.Slice(books, func(i, j int) bool {
+		return books[i].GetLastModifiedSeq() < books[j].GetLastModifiedSeq()
+	})
+
+	// 删除后又以相同ID创建的图书已在 books 中，不再报告删除
+	var deletes []string
+	start := sort.Search(len(c.tombstones), func(i int) bool { return c.tombstones[i].seq > since })
+	for _, d := range c.tombstones[start:] {
+		if _, exists := c.books[d.id]; !exists {
+			deletes = append(deletes, d.id)
+		}
+	}
+	return books, deletes
+}
+
+// ListChangedSince 返回调用方存储中序号 since_seq 之后被修改和删除的图书，用于断开后的增量同步。
+// since_seq 早于保留的删除记录，或大于当前序号（如服务重启后序号重新计数）时无法确定期间的删除，
+// 返回 OutOfRange（ErrorInfo 原因为 FULL_RESYNC_REQUIRED），客户端需要通过 ListBooks 重新全量同步
+func (s *BookServer) ListChangedSince(ctx context.Context, req *pb.ListChangedSinceRequest) (*pb.ListChangedSinceResponse, error) {
+	s.logger.Info("收到增量同步请求", "since_seq", req.GetSinceSeq())
+
+	since := req.GetSinceSeq()
+	if since < 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "变更序号不能为负数: %d", since)
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	catalog := s.catalogFor(ctx, false)
+	if since > catalog.seq {
+		return nil, fullResyncError(fmt.Sprintf("变更序号 %d 大于当前序号 %d，存储可能已被重置，请重新全量同步", since, catalog.seq), since, catalog.seq)
+	}
+	if since < catalog.trimmedSeq {
+		return nil, fullResyncError(fmt.Sprintf("变更序号 %d 过旧，期间的删除记录已被丢弃，请重新全量同步", since), since, catalog.seq)
+	}
+
+	books, deletes := catalog.changedSince(since)
+	s.logger.Info("增量同步完成", "books", len(books), "deletes", len(deletes), "current_seq", catalog.seq)
+
+	return &pb.ListChangedSinceResponse{
+		Books:      s.filterForRead(books),
+		Deletes:    deletes,
+		CurrentSeq: catalog.seq,
+	}, nil
+}
@@ -0,0 +1,173 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+
+	// 导入生成的protobuf代码
+	pb "grpc-basic-server/pb"
+
+	// 导入gRPC相关包
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// TestListChangedSince 测试按同步水位返回期间修改和删除的图书
+func TestListChangedSince(t *testing.T) {
+	client, _ := startTestServer(t, mustParseConfig(t))
+	ctx := context.Background()
+
+	create := func(title string) string {
+		t.Helper()
+		resp, err := client.CreateBook(ctx, &pb.CreateBookRequest{Book: &pb.Book{Title: title, Author: "作者", Price: proto.Float32(10)}})
+		if err != nil {
+			t.Fatalf("创建图书失败: %v", err)
+		}
+		return resp.GetId()
+	}
+	changed := func(since int64) *pb.ListChangedSinceResponse {
+		t.Helper()
+		resp, err := client.ListChangedSince(ctx, &pb.ListChangedSinceRequest{SinceSeq: since})
+		if err != nil {
+			t.Fatalf("增量同步失败: %v", err)
+		}
+		return resp
+	}
+	ids := func(books []*pb.Book) []string {
+		var result []string
+		for _, book := range books {
+			result = append(result, book.GetId())
+		}
+		return result
+	}
+
+	a := create("图书A")
+	b := create("图书B")
+	c := create("图书C")
+	watermark := changed(0).GetCurrentSeq()
+	if watermark != 3 {
+		t.Fatalf("期望当前序号为3，实际为: %d", watermark)
+	}
+
+	// 水位之后：修改 A，删除 B，创建 D
+	if _, err := client.UpdateBook(ctx, &pb.UpdateBookRequest{Book: &pb.Book{Id: a, Title: "图书A（第二版）", Author: "作者"}}); err != nil {
+		t.Fatalf("更新图书失败: %v", err)
+	}
+	if _, err := client.DeleteBook(ctx, &pb.DeleteBookRequest{Id: b}); err != nil {
+		t.Fatalf("删除图书失败: %v", err)
+	}
+	d := create("图书D")
+
+	resp := changed(watermark)
+	if got := ids(resp.GetBooks()); !slices.Equal(got, []string{a, d}) {
+		t.Errorf("期望修改的图书为 %v，实际为: %v", []string{a, d}, got)
+	}
+	if resp.GetBooks()[0].GetTitle() != "图书A（第二版）" || resp.GetBooks()[0].GetLastModifiedSeq() != 4 {
+		t.Errorf("修改后的图书不正确: %v", resp.GetBooks()[0])
+	}
+	if !slices.Equal(resp.GetDeletes(), []string{b}) {
+		t.Errorf("期望删除的图书为 %v，实际为: %v", []string{b}, resp.GetDeletes())
+	}
+	if resp.GetCurrentSeq() != 6 {
+		t.Errorf("期望当前序号为6，实际为: %d", resp.GetCurrentSeq())
+	}
+
+	// 从头同步时未修改的 C 同样返回；已是最新水位时没有变化
+	if got := ids(changed(0).GetBooks()); !slices.Equal(got, []string{c, a, d}) {
+		t.Errorf("从头同步期望 %v，实际为: %v", []string{c, a, d}, got)
+	}
+	if resp := changed(resp.GetCurrentSeq()); len(resp.GetBooks()) != 0 || len(resp.GetDeletes()) != 0 {
+		t.Errorf("期望没有变化，实际为: %v", resp)
+	}
+
+	// 大于当前序号的水位（如服务重启后）要求重新全量同步
+	_, err := client.ListChangedSince(ctx, &pb.ListChangedSinceRequest{SinceSeq: 100})
+	if reason := fullResyncReason(t, err); reason != reasonFullResync {
+		t.Errorf("期望要求重新全量同步，实际为: %v", err)
+	}
+}
+
+// fullResyncReason 检查错误码为 OutOfRange 并返回错误详情中 ErrorInfo 的原因
+func fullResyncReason(t *testing.T, err error) string {
+	t.Helper()
+	st := status.Convert(err)
+	if st.Code() != codes.OutOfRange {
+		t.Fatalf("期望错误码为OutOfRange，实际为: %v", err)
+	}
+	for _, detail := range st.Details() {
+		if info, ok := detail.(*errdetails.ErrorInfo); ok {
+			return info.GetReason()
+		}
+	}
+	return ""
+}
+
+// TestChangeLogTrim 测试删除记录超过保留条数后丢弃最早的记录，过旧的水位需要重新全量同步
+func TestChangeLogTrim(t *testing.T) {
+	const limit = 5
+	server := NewBookServer(WithTombstoneRetention(limit, 0))
+	now := time.Now()
+
+	server.mu.Lock()
+	for i := 0; i < 2*limit; i++ {
+		id := fmt.Sprintf("book-%d", i)
+		server.put(&pb.Book{Id: id, Title: "图书", Author: "作者", Price: proto.Float32(10)}, now)
+		server.remove(id)
+	}
+	tombstones := len(server.tombstones)
+	server.mu.Unlock()
+
+	if tombstones != limit {
+		t.Errorf("期望保留%d条删除记录，实际为: %d", limit, tombstones)
+	}
+	_, err := server.ListChangedSince(context.Background(), &pb.ListChangedSinceRequest{SinceSeq: 1})
+	if reason := fullResyncReason(t, err); reason != reasonFullResync {
+		t.Errorf("期望过旧的水位要求重新全量同步，实际为: %v", err)
+	}
+
+	// 仍在保留范围内的水位正常返回期间的删除
+	resp, err := server.ListChangedSince(context.Background(), &pb.ListChangedSinceRequest{SinceSeq: server.seq - 4})
+	if err != nil || !slices.Equal(resp.GetDeletes(), []string{"book-8", "book-9"}) {
+		t.Errorf("期望返回最后两次删除，实际为: %v, %v", resp, err)
+	}
+}
+
+// TestTombstoneRetention 测试删除记录超过保留时间后被丢弃，之前的水位需要重新全量同步
+func TestTombstoneRetention(t *testing.T) {
+	clock := newFakeClock()
+	server := NewBookServer(WithClock(clock), WithTombstoneRetention(defaultTombstoneLimit, time.Hour))
+	ctx := context.Background()
+
+	ids := server.loadBooks([]*pb.Book{
+		{Title: "图书A", Author: "作者", Price: proto.Float32(10)},
+		{Title: "图书B", Author: "作者", Price: proto.Float32(10)},
+	})
+	if _, err := server.DeleteBook(ctx, &pb.DeleteBookRequest{Id: ids[0]}); err != nil {
+		t.Fatalf("删除图书失败: %v", err)
+	}
+
+	// 保留时间内正常返回删除
+	resp, err := server.ListChangedSince(ctx, &pb.ListChangedSinceRequest{SinceSeq: 2})
+	if err != nil || !slices.Equal(resp.GetDeletes(), []string{ids[0]}) {
+		t.Fatalf("期望返回删除的图书，实际为: %v, %v", resp, err)
+	}
+
+	// 超过保留时间后再删除一本，第一条删除记录被丢弃
+	clock.Advance(2 * time.Hour)
+	if _, err := server.DeleteBook(ctx, &pb.DeleteBookRequest{Id: ids[1]}); err != nil {
+		t.Fatalf("删除图书失败: %v", err)
+	}
+	_, err = server.ListChangedSince(ctx, &pb.ListChangedSinceRequest{SinceSeq: 2})
+	if reason := fullResyncReason(t, err); reason != reasonFullResync {
+		t.Errorf("期望过期的水位要求重新全量同步，实际为: %v", err)
+	}
+	resp, err = server.ListChangedSince(ctx, &pb.ListChangedSinceRequest{SinceSeq: 3})
+	if err != nil || !slices.Equal(resp.GetDeletes(), []string{ids[1]}) {
+		t.Errorf("期望返回最后一次删除，实际为: %v, %v", resp, err)
+	}
+}
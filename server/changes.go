@@ -0,0 +1,33 @@
+package main
+
+import "sync"
+
+// changeNotifier 图书修改通知：等待方通过 wait 取得通道，下一次修改时通道被关闭。
+// 通知不携带修改内容，等待方收到后需要自行重新读取
+type changeNotifier struct {
+	mu sync.Mutex
+	ch chan struct{}
+}
+
+// newChangeNotifier 创建修改通知
+func newChangeNotifier() *changeNotifier {
+	return &changeNotifier{ch: make(chan struct{})}
+}
+
+// wait 返回在下一次修改时被关闭的通道
+func (n *changeNotifier) wait() <-chan struct{} {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.ch
+}
+
+// notify 唤醒所有等待方，n 为 nil 时不做任何事
+func (n *changeNotifier) notify() {
+	if n == nil {
+		return
+	}
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	close(n.ch)
+	n.ch = make(chan struct{})
+}
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+
+	// 导入gRPC相关包
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// clientIDPattern 客户端指定的图书ID格式：以字母或数字开头，只包含字母、数字、点、下划线和连字符，最长64个字符
+var clientIDPattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]{0,63}$`)
+
+// WithClientIDs 允许创建图书时使用客户端指定的ID（如导入时保留外部系统的ID），ID 为空时仍由服务端生成
+func WithClientIDs(allow bool) ServerOption {
+	return func(s *BookServer) {
+		s.allowClientIDs = allow
+	}
+}
+
+// assignID 返回新图书的ID：允许客户端指定且请求中的ID非空时使用该ID，否则在租户内生成。
+// ID 格式不合法返回 InvalidArgument，与已有图书冲突返回 AlreadyExists，调用方需持有写锁
+func (s *BookServer) assignID(catalog *bookCatalog, requested string) (string, error) {
+	if !s.allowClientIDs || requested == "" {
+		return catalog.generateID(), nil
+	}
+	if !clientIDPattern.MatchString(requested) {
+		return "", status.Errorf(codes.InvalidArgument, "图书ID格式不正确: %q，只能包含字母、数字、点、下划线和连字符，最长64个字符", requested)
+	}
+	if _, exists := catalog.books[requested]; exists {
+		return "", s.storeErrToStatus(fmt.Errorf("%w，ID: %s", ErrAlreadyExists, requested))
+	}
+	return requested, nil
+}
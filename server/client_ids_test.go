@@ -0,0 +1,62 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	// 导入生成的protobuf代码
+	pb "grpc-basic-server/pb"
+
+	// 导入gRPC相关包
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// TestClientProvidedIDs 测试开启后使用客户端指定的ID，冲突时返回 AlreadyExists，ID 为空时由服务端生成
+func TestClientProvidedIDs(t *testing.T) {
+	server := NewBookServer(WithClientIDs(true))
+	ctx := context.Background()
+	create := func(id string) (*pb.CreateBookResponse, error) {
+		return server.CreateBook(ctx, &pb.CreateBookRequest{Book: &pb.Book{Id: id, Title: "图书", Author: "作者", Price: proto.Float32(10)}})
+	}
+
+	resp, err := create("isbn-9787111")
+	if err != nil {
+		t.Fatalf("使用指定ID创建图书失败: %v", err)
+	}
+	if resp.GetId() != "isbn-9787111" {
+		t.Errorf("期望使用客户端指定的ID，实际为: %s", resp.GetId())
+	}
+
+	if _, err := create("isbn-9787111"); status.Code(err) != codes.AlreadyExists {
+		t.Errorf("期望ID冲突时返回 AlreadyExists，实际为: %v", err)
+	}
+	if _, err := create("含有 空格"); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("期望ID格式不正确时返回 InvalidArgument，实际为: %v", err)
+	}
+
+	// 客户端占用了服务端将要生成的ID，生成时应跳过
+	if _, err := create("book-1"); err != nil {
+		t.Fatalf("使用指定ID创建图书失败: %v", err)
+	}
+	resp, err = create("")
+	if err != nil {
+		t.Fatalf("创建图书失败: %v", err)
+	}
+	if resp.GetId() != "book-2" {
+		t.Errorf("期望ID为空时由服务端生成 book-2，实际为: %s", resp.GetId())
+	}
+}
+
+// TestClientProvidedIDsDisabled 测试默认忽略客户端指定的ID
+func TestClientProvidedIDsDisabled(t *testing.T) {
+	server := NewBookServer()
+	resp, err := server.CreateBook(context.Background(), &pb.CreateBookRequest{Book: &pb.Book{Id: "my-id", Title: "图书", Author: "作者", Price: proto.Float32(10)}})
+	if err != nil {
+		t.Fatalf("创建图书失败: %v", err)
+	}
+	if resp.GetId() != "book-1" {
+		t.Errorf("期望默认由服务端生成ID，实际为: %s", resp.GetId())
+	}
+}
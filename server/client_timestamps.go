@@ -0,0 +1,46 @@
+package main
+
+import (
+	"time"
+
+	// 导入gRPC相关包
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// clientTimestampSkew 信任客户端时间戳时，允许晚于服务端当前时间的时钟偏差
+	clientTimestampSkew = time.Minute
+)
+
+// minClientTimestamp 信任客户端时间戳时允许的最早时间，更早的时间戳（如未初始化的零值）视为无效
+var minClientTimestamp = time.Date(1970, 1, 2, 0, 0, 0, 0, time.UTC)
+
+// WithTrustClientTimestamps 信任 v2 请求中客户端提供的 created_at/updated_at（如从其他系统迁移数据时保留原有时间），
+// 默认忽略客户端提供的时间戳，总是由服务端设置
+func WithTrustClientTimestamps(trust bool) ServerOption {
+	return func(s *BookServer) {
+		s.trustClientTimestamps = trust
+	}
+}
+
+// clientTimestamp 返回图书时间戳字段 field 应使用的时间：未信任客户端时间戳或客户端未设置时返回 fallback。
+// 信任时客户端的时间戳必须合法，不能晚于 now（允许 clientTimestampSkew 的偏差），也不能早于 minClientTimestamp，
+// 否则返回 InvalidArgument
+func (s *BookServer) clientTimestamp(field string, ts *timestamppb.Timestamp, now, fallback time.Time) (time.Time, error) {
+	if !s.trustClientTimestamps || ts == nil {
+		return fallback, nil
+	}
+	if err := ts.CheckValid(); err != nil {
+		return time.Time{}, status.Errorf(codes.InvalidArgument, "%s 无效: %v", field, err)
+	}
+	t := ts.AsTime()
+	if t.After(now.Add(clientTimestampSkew)) {
+		return time.Time{}, status.Errorf(codes.InvalidArgument, "%s 不能晚于当前时间: %s", field, t.Format(time.RFC3339))
+	}
+	if t.Before(minClientTimestamp) {
+		return time.Time{}, status.Errorf(codes.InvalidArgument, "%s 过早: %s", field, t.Format(time.RFC3339))
+	}
+	return t, nil
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	// 导入生成的protobuf代码
+	pbv2 "grpc-basic-server/pb/v2"
+
+	// 导入gRPC相关包
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// TestClientTimestampsIgnored 测试默认忽略客户端提供的时间戳，由服务端设置
+func TestClientTimestampsIgnored(t *testing.T) {
+	clock := newFakeClock()
+	conn, _ := startTestConn(t, mustParseConfig(t), WithClock(clock))
+	v2 := pbv2.NewBookServiceV2Client(conn)
+
+	spoofed := timestamppb.New(clock.Now().Add(24 * time.Hour))
+	created, err := v2.CreateBook(context.Background(), &pbv2.CreateBookRequest{Book: &pbv2.Book{
+		Title: "图书", Author: "作者", Price: 10, CreatedAt: spoofed, UpdatedAt: spoofed,
+	}})
+	if err != nil {
+		t.Fatalf("创建图书失败: %v", err)
+	}
+	if !created.GetCreatedAt().AsTime().Equal(clock.Now()) || !created.GetUpdatedAt().AsTime().Equal(clock.Now()) {
+		t.Errorf("期望使用服务端时间，实际为: %v, %v", created.GetCreatedAt().AsTime(), created.GetUpdatedAt().AsTime())
+	}
+}
+
+// TestTrustClientTimestamps 测试信任客户端时间戳时保留合法的时间戳，拒绝晚于当前时间或过早的时间戳
+func TestTrustClientTimestamps(t *testing.T) {
+	clock := newFakeClock()
+	conn, _ := startTestConn(t, mustParseConfig(t, "-trust-client-timestamps"), WithClock(clock))
+	v2 := pbv2.NewBookServiceV2Client(conn)
+	ctx := context.Background()
+
+	past := clock.Now().Add(-365 * 24 * time.Hour)
+	created, err := v2.CreateBook(ctx, &pbv2.CreateBookRequest{Book: &pbv2.Book{
+		Title: "图书", Author: "作者", Price: 10, CreatedAt: timestamppb.New(past),
+	}})
+	if err != nil {
+		t.Fatalf("创建图书失败: %v", err)
+	}
+	if !created.GetCreatedAt().AsTime().Equal(past) || !created.GetUpdatedAt().AsTime().Equal(past) {
+		t.Errorf("期望保留客户端的时间戳，实际为: %v, %v", created.GetCreatedAt().AsTime(), created.GetUpdatedAt().AsTime())
+	}
+
+	for name, ts := range map[string]time.Time{
+		"晚于当前时间": clock.Now().Add(time.Hour),
+		"过早":     time.Unix(0, 0),
+	} {
+		_, err := v2.CreateBook(ctx, &pbv2.CreateBookRequest{Book: &pbv2.Book{
+			Title: "图书", Author: "作者", Price: 10, CreatedAt: timestamppb.New(ts),
+		}})
+		if status.Code(err) != codes.InvalidArgument {
+			t.Errorf("%s: 期望错误码为InvalidArgument，实际为: %v", name, err)
+		}
+	}
+
+	// 修改时间不能早于创建时间
+	_, err = v2.UpdateBook(ctx, &pbv2.UpdateBookRequest{Book: &pbv2.Book{
+		Id: created.GetId(), Title: "图书", Author: "作者", Price: 12, UpdatedAt: timestamppb.New(past.Add(-time.Hour)),
+	}})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("期望错误码为InvalidArgument，实际为: %v", err)
+	}
+}
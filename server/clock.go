@@ -0,0 +1,52 @@
+package main
+
+import "time"
+
+// Clock 服务端使用的时间来源，快照、预留的过期和图书时间戳都通过它获取当前时间，
+// 测试中可以替换为手动推进的时钟
+type Clock interface {
+	// Now 返回当前时间
+	Now() time.Time
+
+	// NewTicker 创建按 d 间隔触发的定时器，用于后台回收任务
+	NewTicker(d time.Duration) Ticker
+}
+
+// Ticker 周期定时器
+type Ticker interface {
+	// C 返回接收触发时间的通道
+	C() <-chan time.Time
+
+	// Stop 停止定时器
+	Stop()
+}
+
+// realClock 基于系统时间的默认时钟
+type realClock struct{}
+
+// Now 返回系统当前时间
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+// NewTicker 创建基于 time.Ticker 的定时器
+func (realClock) NewTicker(d time.Duration) Ticker {
+	return realTicker{time.NewTicker(d)}
+}
+
+// realTicker 包装 time.Ticker 以实现 Ticker 接口
+type realTicker struct {
+	*time.Ticker
+}
+
+// C 返回接收触发时间的通道
+func (t realTicker) C() <-chan time.Time {
+	return t.Ticker.C
+}
+
+// WithClock 设置服务端使用的时钟，默认使用系统时间
+func WithClock(clock Clock) ServerOption {
+	return func(s *BookServer) {
+		s.clock = clock
+	}
+}
@@ -0,0 +1,151 @@
+package main
+
+import (
+	"context"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+
+	// 导入生成的protobuf代码
+	pb "grpc-basic-server/pb"
+
+	// 导入gRPC相关包
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// fakeClock 测试用的时钟，只有调用 Advance 时时间才会前进并触发定时器
+type fakeClock struct {
+	mu      sync.Mutex
+	now     time.Time
+	tickers []*fakeTicker
+}
+
+// fakeTicker fakeClock 创建的定时器
+type fakeTicker struct {
+	clock   *fakeClock
+	period  time.Duration
+	next    time.Time
+	c       chan time.Time
+	stopped bool
+}
+
+// newFakeClock 创建从固定时间开始的测试时钟
+func newFakeClock() *fakeClock {
+	return &fakeClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+}
+
+// Now 返回测试时钟的当前时间
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// NewTicker 创建在 Advance 时触发的定时器
+func (c *fakeClock) NewTicker(d time.Duration) Ticker {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	t := &fakeTicker{clock: c, period: d, next: c.now.Add(d), c: make(chan time.Time, 1)}
+	c.tickers = append(c.tickers, t)
+	return t
+}
+
+// Advance 推进时间，到期的定时器各触发一次并收到推进后的时间；
+// 接收方尚未处理上一次触发时，用最新的时间替换它，保证接收方最终看到推进后的时间
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.now = c.now.Add(d)
+	for _, t := range c.tickers {
+		if t.stopped || t.next.After(c.now) {
+			continue
+		}
+		select {
+		case <-t.c:
+		default:
+		}
+		t.c <- c.now
+		for !t.next.After(c.now) {
+			t.next = t.next.Add(t.period)
+		}
+	}
+}
+
+// activeTickers 返回未停止的定时器数量
+func (c *fakeClock) activeTickers() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	n := 0
+	for _, t := range c.tickers {
+		if !t.stopped {
+			n++
+		}
+	}
+	return n
+}
+
+// C 返回接收触发时间的通道
+func (t *fakeTicker) C() <-chan time.Time {
+	return t.c
+}
+
+// Stop 停止定时器
+func (t *fakeTicker) Stop() {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+	t.stopped = true
+}
+
+// waitUntil 等待后台协程使 cond 成立，只让出调度而不按时间等待
+func waitUntil(t *testing.T, cond func() bool) {
+	t.Helper()
+
+	for i := 0; i < 1000000; i++ {
+		if cond() {
+			return
+		}
+		runtime.Gosched()
+	}
+	t.Fatalf("等待条件成立超时")
+}
+
+// TestFakeClockSnapshotJanitor 测试推进时钟超过快照有效期后，后台回收立即生效
+func TestFakeClockSnapshotJanitor(t *testing.T) {
+	clock := newFakeClock()
+	server := NewBookServer(WithClock(clock), WithSnapshotTTL(time.Hour))
+
+	snap, err := server.OpenSnapshot(context.Background(), &emptypb.Empty{})
+	if err != nil {
+		t.Fatalf("打开快照失败: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go server.runSnapshotJanitor(ctx, time.Minute)
+	waitUntil(t, func() bool { return clock.activeTickers() == 1 })
+
+	// 未过期时快照仍然可用
+	clock.Advance(59 * time.Minute)
+	if _, err := server.ListBooks(context.Background(), &pb.ListBooksRequest{SnapshotToken: snap.Token}); err != nil {
+		t.Fatalf("期望快照仍然有效，实际为: %v", err)
+	}
+
+	// 超过有效期后，下一次触发即回收
+	clock.Advance(2 * time.Minute)
+	waitUntil(t, func() bool {
+		server.snapMu.Lock()
+		defer server.snapMu.Unlock()
+		return len(server.snapshots) == 0
+	})
+
+	_, err = server.ListBooks(context.Background(), &pb.ListBooksRequest{SnapshotToken: snap.Token})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("期望错误码为NotFound，实际为: %v", err)
+	}
+}
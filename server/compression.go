@@ -0,0 +1,35 @@
+package main
+
+import (
+	"context"
+	"slices"
+
+	// 导入gRPC相关包
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/encoding/gzip"
+	"google.golang.org/protobuf/proto"
+)
+
+// newCompressionInterceptor 创建响应压缩拦截器：响应大小不小于 threshold 字节且客户端支持 gzip 时压缩响应，
+// 较小的响应不压缩以节省CPU。threshold 小于等于0表示不压缩
+func newCompressionInterceptor(threshold int) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		resp, err := handler(ctx, req)
+		if err != nil || threshold <= 0 {
+			return resp, err
+		}
+
+		// 一元调用的响应头在拦截器返回后才发送，此时仍可以设置压缩方式
+		msg, ok := resp.(proto.Message)
+		if !ok || proto.Size(msg) < threshold {
+			return resp, nil
+		}
+		supported, _ := grpc.ClientSupportedCompressors(ctx)
+		if slices.Contains(supported, gzip.Name) {
+			if err := grpc.SetSendCompressor(ctx, gzip.Name); err != nil {
+				return nil, err
+			}
+		}
+		return resp, nil
+	}
+}
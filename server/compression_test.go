@@ -0,0 +1,79 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+
+	// 导入生成的protobuf代码
+	pb "grpc-basic-server/pb"
+
+	// 导入gRPC相关包
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/stats"
+	"google.golang.org/protobuf/proto"
+)
+
+// encodingRecorder 客户端的 stats.Handler，记录每个方法响应使用的压缩方式
+type encodingRecorder struct {
+	mu        sync.Mutex
+	encodings map[string]string
+}
+
+// methodKey 在 context 中保存方法名的键
+type methodKey struct{}
+
+// TagRPC 在 context 中保存方法名
+func (r *encodingRecorder) TagRPC(ctx context.Context, info *stats.RPCTagInfo) context.Context {
+	return context.WithValue(ctx, methodKey{}, info.FullMethodName)
+}
+
+// HandleRPC 从响应头中记录压缩方式
+func (r *encodingRecorder) HandleRPC(ctx context.Context, s stats.RPCStats) {
+	if in, ok := s.(*stats.InHeader); ok {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.encodings[ctx.Value(methodKey{}).(string)] = in.Compression
+	}
+}
+
+// TagConn 不需要连接级别的信息
+func (r *encodingRecorder) TagConn(ctx context.Context, _ *stats.ConnTagInfo) context.Context {
+	return ctx
+}
+
+// HandleConn 不需要处理连接事件
+func (r *encodingRecorder) HandleConn(context.Context, stats.ConnStats) {}
+
+// TestCompressionThreshold 测试小响应不压缩，超过阈值的大响应使用 gzip 压缩
+func TestCompressionThreshold(t *testing.T) {
+	recorder := &encodingRecorder{encodings: map[string]string{}}
+	conn, server := startTestConnWith(t, mustParseConfig(t, "-compression-threshold", "1024"), testHook{
+		dialOptions: []grpc.DialOption{grpc.WithStatsHandler(recorder)},
+	})
+	client := pb.NewBookServiceClient(conn)
+
+	books := make([]*pb.Book, 20)
+	for i := range books {
+		books[i] = &pb.Book{Title: "图书", Author: "作者", Price: proto.Float32(10), Description: proto.String(strings.Repeat("描述", 50))}
+	}
+	ids := server.loadBooks(books)
+
+	ctx := context.Background()
+	if _, err := client.GetBook(ctx, &pb.GetBookRequest{Id: ids[0]}); err != nil {
+		t.Fatalf("获取图书失败: %v", err)
+	}
+	if _, err := client.ListBooks(ctx, &pb.ListBooksRequest{PageSize: 20}); err != nil {
+		t.Fatalf("列出图书失败: %v", err)
+	}
+
+	recorder.mu.Lock()
+	defer recorder.mu.Unlock()
+	if got := recorder.encodings["/bookstore.BookService/GetBook"]; got != "" {
+		t.Errorf("期望小响应不压缩，实际压缩方式为: %q", got)
+	}
+	if got := recorder.encodings["/bookstore.BookService/ListBooks"]; got != "gzip" {
+		t.Errorf("期望大响应使用gzip压缩，实际为: %q", got)
+	}
+}
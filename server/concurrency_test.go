@@ -0,0 +1,91 @@
+package main
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	// 导入生成的protobuf代码
+	pb "grpc-basic-server/pb"
+	pbv2 "grpc-basic-server/pb/v2"
+
+	// 导入gRPC相关包
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// TestUpdateRacingDelete 测试同一本图书的各种修改与删除并发执行时，修改要么成功要么返回 NotFound，
+// 删除只成功一次，删除后图书不会因为修改而重新出现
+func TestUpdateRacingDelete(t *testing.T) {
+	silenceLog(t)
+	ctx := context.Background()
+
+	for round := 0; round < 50; round++ {
+		server := NewBookServer()
+		v2 := &bookServiceV2{s: server}
+		id := server.loadBooks([]*pb.Book{{Title: "图书", Author: "作者", Price: proto.Float32(10), Stock: 100}})[0]
+		reservation, err := server.ReserveBook(ctx, &pb.ReserveBookRequest{Id: id, Quantity: 1})
+		if err != nil {
+			t.Fatalf("预留库存失败: %v", err)
+		}
+
+		updates := []func() error{
+			func() error {
+				_, err := server.UpdateBook(ctx, &pb.UpdateBookRequest{Book: &pb.Book{Id: id, Title: "新标题", Author: "作者", Price: proto.Float32(20)}})
+				return err
+			},
+			func() error {
+				_, err := v2.UpdateBook(ctx, &pbv2.UpdateBookRequest{Book: &pbv2.Book{Id: id, Title: "v2标题", Author: "作者", Price: 30}})
+				return err
+			},
+			func() error {
+				_, err := server.PurchaseBook(ctx, &pb.PurchaseBookRequest{Id: id, Quantity: 1})
+				return err
+			},
+			func() error {
+				_, err := server.ConfirmReservation(ctx, &pb.ReservationRequest{ReservationId: reservation.GetReservationId()})
+				return err
+			},
+		}
+
+		var wg sync.WaitGroup
+		var mu sync.Mutex
+		deleted := 0
+		start := make(chan struct{})
+		for i := 0; i < 4; i++ {
+			wg.Add(2)
+			go func() {
+				defer wg.Done()
+				<-start
+				_, err := server.DeleteBook(ctx, &pb.DeleteBookRequest{Id: id})
+				if err == nil {
+					mu.Lock()
+					deleted++
+					mu.Unlock()
+				} else if status.Code(err) != codes.NotFound {
+					t.Errorf("删除图书返回了意外的错误: %v", err)
+				}
+			}()
+			go func(update func() error) {
+				defer wg.Done()
+				<-start
+				if err := update(); err != nil && status.Code(err) != codes.NotFound {
+					t.Errorf("修改图书返回了意外的错误: %v", err)
+				}
+			}(updates[i])
+		}
+		close(start)
+		wg.Wait()
+
+		if deleted != 1 {
+			t.Fatalf("期望删除只成功一次，实际为%d次", deleted)
+		}
+		if _, err := server.GetBook(ctx, &pb.GetBookRequest{Id: id}); status.Code(err) != codes.NotFound {
+			t.Fatalf("删除后图书不应重新出现，实际为: %v", err)
+		}
+		if len(server.reservations) != 0 || len(server.meta) != 0 || len(server.index.byAuthor) != 0 {
+			t.Fatalf("删除后不应残留预留、元信息或索引")
+		}
+	}
+}
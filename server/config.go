@@ -0,0 +1,502 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"math"
+	"net/netip"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	// 导入生成的protobuf代码
+	pb "grpc-basic-server/pb"
+	pbv2 "grpc-basic-server/pb/v2"
+
+	// 导入gRPC相关包
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/keepalive"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// 连接与流资源限制的默认值：
+// 限制并发流可避免单个连接占用过多资源；空闲连接及时关闭以释放资源；
+// 连接存活时间默认不限制：限制后客户端会被定期强制重连，长时间运行的流式调用在宽限期后会被中断
+const (
+	defaultMaxConcurrentStreams  = 100
+	defaultMaxConnectionIdle     = 15 * time.Minute
+	defaultMaxConnectionAge      = 0
+	defaultMaxConnectionAgeGrace = 0
+
+	// 监听器层面的限制：同时保持的连接数，建立连接后完成 HTTP/2 握手的时限，以及单次写入的时限。
+	// 单次读取默认不限制，否则长时间只接收服务端消息的流式调用会被中断
+	defaultMaxConnections   = 1000
+	defaultHandshakeTimeout = 10 * time.Second
+	defaultWriteTimeout     = 30 * time.Second
+
+	// infinity 表示不限制的时长，与 gRPC 内部约定一致
+	infinity = time.Duration(math.MaxInt64)
+)
+
+// config 服务端配置，由命令行参数解析得到
+type config struct {
+	// 监听地址
+	addr string
+
+	// 快照有效期
+	snapshotTTL time.Duration
+
+	// 流式请求允许部分结果时的截止时间余量
+	streamGrace time.Duration
+
+	// 查询类调用记录慢查询日志的耗时阈值，0 表示不记录
+	slowQueryThreshold time.Duration
+
+	// 日志输出方式和访问日志格式
+	logFormat       string
+	accessLogFormat accessLogFormat
+
+	// 日志级别、内容记录与脱敏
+	logLevel      logLevel
+	logPayloads   bool
+	logSampleRate float64
+	redactFields  []string
+
+	// 是否在响应尾部附加调试信息
+	debugTrailers bool
+
+	// 是否在错误中附加 google.rpc 错误详情和按 accept-language 选择语言的描述
+	richErrors bool
+
+	// 方法访问控制
+	readOnly     bool
+	allowMethods []string
+	denyMethods  []string
+
+	// 允许访问的调用方网段，为空表示不限制
+	allowCIDRs []netip.Prefix
+
+	// 管理接口开关和管理令牌
+	enableAdmin bool
+	adminToken  string
+
+	// 每个请求必须携带的元数据键
+	requiredMetadata []string
+
+	// 用于多租户隔离的元数据键，为空表示不开启
+	tenantMetadata string
+
+	// 按调用方角色裁剪响应中的图书字段，为 nil 表示不开启
+	fieldAccess *fieldAccess
+
+	// 每个租户每秒允许的写请求数、突发上限和单独设置的租户速率
+	writeRate        float64
+	writeBurst       int
+	tenantWriteRates map[string]float64
+
+	// 创建图书时可选字段的默认值
+	defaultDescription string
+	defaultPublishYear bool
+	defaultCurrency    string
+
+	// 启动时加载的演示数据
+	seed     bool
+	seedFile string
+
+	// 存储可用性检查间隔
+	healthInterval time.Duration
+
+	// 是否在标记为 SERVING 前预热存储，以及预热的最大尝试次数
+	warmup         bool
+	warmupAttempts int
+
+	// 优雅关闭的最长等待时间
+	shutdownTimeout time.Duration
+
+	// 调试 HTTP 接口的监听地址，为空表示不开启
+	debugHTTP string
+
+	// 是否允许创建图书时使用客户端指定的ID
+	allowClientIDs bool
+
+	// 是否信任 v2 请求中客户端提供的时间戳
+	trustClientTimestamps bool
+
+	// 是否复用常用的响应消息
+	responsePool bool
+
+	// 是否去除描述中的 HTML 标签
+	sanitizeDescriptions bool
+
+	// 随机源的固定种子，为 nil 表示随机初始化
+	randSeed *uint64
+
+	// 每个存储保留的删除记录条数和保留时间
+	tombstoneLimit     int
+	tombstoneRetention time.Duration
+
+	// 批量方法单次请求允许的最大条目数
+	maxBatchSize int
+
+	// 客户端流式调用允许接收的最大消息数
+	maxStreamMessages int
+
+	// 一元查询允许返回的最大图书数量
+	maxSearchResults int
+
+	// 最大响应消息大小和最大请求消息大小
+	maxMessageSize     int
+	maxRecvMessageSize int
+
+	// 响应大小不小于该值时使用 gzip 压缩，0 表示不压缩
+	compressionThreshold int
+
+	// 读取图书时的校验策略
+	readValidation readValidationPolicy
+
+	// 更新图书时不可修改的字段
+	immutableFields []protoreflect.FieldDescriptor
+
+	// 连接与流的资源限制
+	maxConcurrentStreams  uint
+	maxConnectionIdle     time.Duration
+	maxConnectionAge      time.Duration
+	maxConnectionAgeGrace time.Duration
+
+	// 监听器层面的连接限制和建立连接后完成握手的时限
+	listenerLimits   listenerLimits
+	handshakeTimeout time.Duration
+}
+
+// parseConfig 解析命令行参数
+func parseConfig(args []string) (*config, error) {
+	cfg := &config{}
+	var quiet bool
+	var defaultCurrencyValue, accessLogFormatValue string
+	var roleMetadata, roleFields, defaultRole string
+	var logLevelValue, immutableFields, redactFields, allowMethods, denyMethods, allowCIDRs, requiredMetadata, readValidation, tenantWriteRates string
+
+	fs := flag.NewFlagSet("server", flag.ContinueOnError)
+	fs.StringVar(&cfg.addr, "addr", ":50051", "监听地址，也可以是 unix:///path/to/socket 形式的 Unix 域套接字")
+	fs.DurationVar(&cfg.snapshotTTL, "snapshot-ttl", defaultSnapshotTTL, "快照有效期，过期后自动回收")
+	fs.DurationVar(&cfg.streamGrace, "stream-grace", defaultStreamGrace, "流式请求允许部分结果时，距离截止时间小于该值即提前结束")
+	fs.StringVar(&logLevelValue, "log-level", string(logLevelInfo), "日志级别：info 记录每次调用的开始和结束，debug 额外以 JSON 记录请求和响应内容（按 -redact-fields 脱敏），warn 只记录警告和错误，error 只记录错误")
+	fs.BoolVar(&quiet, "quiet", false, "关闭逐次调用的日志，只保留启动信息和错误日志，等同于 -log-level error")
+	fs.StringVar(&cfg.logFormat, "log-format", logFormatStructured, "逐次调用的日志输出方式：structured 结构化日志，access 每次调用向标准输出写一行访问日志（格式由 -access-log-format 指定）")
+	fs.StringVar(&accessLogFormatValue, "access-log-format", defaultAccessLogFormat, "访问日志格式：%h 调用方地址，%t 开始时间，%m 方法名，%s 状态码，%D 耗时（微秒），%I 请求ID，%b 响应字节数，%u user-agent，%% 百分号")
+	fs.DurationVar(&cfg.slowQueryThreshold, "slow-query-threshold", 0, "查询类调用（ListBooks、SearchBooksByPrice 等）的耗时达到该值时记录查询参数、遍历和匹配的图书数，0 表示不记录")
+	fs.Float64Var(&cfg.logSampleRate, "log-sample-rate", 0, "记录调用详情（请求、响应、耗时）的采样比例，0~1，如 0.01 表示1%；失败的调用总是记录详情")
+	fs.BoolVar(&cfg.logPayloads, "log-payloads", false, "是否在日志中记录请求和响应内容")
+	fs.BoolVar(&cfg.debugTrailers, "debug-trailers", true, "是否在一元调用的响应尾部附加服务端版本、请求ID和处理耗时")
+	fs.BoolVar(&cfg.richErrors, "rich-errors", false, "错误响应附加 google.rpc 错误详情（ErrorInfo、BadRequest）和按 accept-language 元数据选择语言（中文或英文）的 LocalizedMessage")
+	fs.StringVar(&redactFields, "redact-fields", "", "记录内容时需要脱敏的字段路径，逗号分隔，如 book.description,books.description")
+	fs.BoolVar(&cfg.readOnly, "readonly", false, "只读模式，拒绝所有修改类方法（Create/Update/Delete/Purchase 等）")
+	fs.StringVar(&allowMethods, "allow-methods", "", "允许调用的完整方法名列表，逗号分隔，为空表示不限制")
+	fs.StringVar(&denyMethods, "deny-methods", "", "禁止调用的完整方法名列表，逗号分隔")
+	fs.StringVar(&allowCIDRs, "allow-cidrs", "", "允许访问的调用方网段，逗号分隔，如 10.0.0.0/8,127.0.0.1/32，为空表示不限制")
+	fs.BoolVar(&cfg.enableAdmin, "enable-admin", false, "开启管理接口（如 DebugDump），调用时需在 x-admin-token 元数据中携带 -admin-token")
+	fs.StringVar(&cfg.adminToken, "admin-token", "", "管理令牌，开启管理接口时必须设置")
+	fs.StringVar(&requiredMetadata, "required-metadata", "", "每个请求必须携带的元数据键，逗号分隔，如 x-tenant-id（健康检查除外）")
+	fs.StringVar(&cfg.tenantMetadata, "tenant-metadata", "", "开启多租户隔离，按该元数据键的值划分图书，如 x-tenant-id（该键同时成为必需元数据）")
+	fs.StringVar(&roleMetadata, "role-metadata", "", "开启按角色裁剪响应中的图书字段，从该元数据键（如 x-role）读取调用方角色，为空表示不开启")
+	fs.StringVar(&roleFields, "role-fields", "", "每个角色可见的图书字段，格式为 角色=字段,字段;角色=*，如 public=id,title,author,price;admin=*（图书ID总是可见）")
+	fs.StringVar(&defaultRole, "default-role", "public", "未携带角色或角色未在 -role-fields 中配置时使用的角色")
+	fs.Float64Var(&cfg.writeRate, "write-rate", 0, "每个租户每秒允许的写请求数（创建、修改、删除等），超过时返回 ResourceExhausted，0 表示不限制")
+	fs.IntVar(&cfg.writeBurst, "write-burst", 0, "每个租户允许的突发写请求数，0 表示与写速率相同（至少为1）")
+	fs.StringVar(&tenantWriteRates, "tenant-write-rates", "", "单独设置部分租户的写速率，格式为 租户=每秒请求数，逗号分隔，如 tenant-a=5,tenant-b=50（0 表示不限制）")
+	fs.BoolVar(&cfg.allowClientIDs, "allow-client-ids", false, "允许创建图书时使用请求中非空的图书ID（如导入时保留外部ID），ID 已存在时返回 AlreadyExists")
+	fs.BoolVar(&cfg.trustClientTimestamps, "trust-client-timestamps", false, "信任 v2 请求中客户端提供的 created_at/updated_at（用于迁移数据），时间戳晚于当前时间或过早时返回 InvalidArgument；默认忽略，总是由服务端设置")
+	fs.BoolVar(&cfg.responsePool, "response-pool", false, "复用 CreateBook、GetBook、UpdateBook、DeleteBook 的响应消息以减少内存分配，不能与 gRPC 二进制日志（"+binaryLogEnv+"）同时使用")
+	fs.Func("rand-seed", "随机源（GetRandomBook、日志采样等）的固定种子，设置后随机结果可重复，用于测试和演示；不设置时随机初始化", func(value string) error {
+		seed, err := strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			return fmt.Errorf("无效的随机种子: %q", value)
+		}
+		cfg.randSeed = &seed
+		return nil
+	})
+	fs.IntVar(&cfg.tombstoneLimit, "tombstone-limit", defaultTombstoneLimit, "每个存储为增量同步（ListChangedSince）保留的删除记录条数，超出后丢弃最早的记录")
+	fs.DurationVar(&cfg.tombstoneRetention, "tombstone-retention", defaultTombstoneRetention, "删除记录的保留时间，过期的记录被丢弃，0 表示只按条数限制")
+	fs.BoolVar(&cfg.sanitizeDescriptions, "sanitize-descriptions", false, "创建和更新图书时去除描述中的 HTML 标签（script、style 等元素连同内容一起去除），保存清理后的描述")
+	fs.StringVar(&defaultCurrencyValue, "default-currency", defaultCurrency, "创建图书时未指定币种所使用的默认币种（ISO 4217 代码）")
+	fs.StringVar(&cfg.defaultDescription, "default-description", "", "创建图书时未提供描述所使用的默认描述，为空表示不填充")
+	fs.BoolVar(&cfg.defaultPublishYear, "default-publish-year", false, "创建图书时未提供出版年份则使用当前年份")
+	fs.BoolVar(&cfg.seed, "seed", false, "启动时加载内置的演示图书")
+	fs.StringVar(&cfg.seedFile, "seed-file", "", "启动时从 JSON/CSV 文件加载演示图书，优先于 -seed")
+	fs.StringVar(&cfg.debugHTTP, "debug-http", "", "开启调试 HTTP 接口的监听地址，如 localhost:8080，可以用 JSON 调用 /debug/CreateBook 等方法，为空表示不开启")
+	fs.DurationVar(&cfg.shutdownTimeout, "shutdown-timeout", defaultShutdownTimeout, "收到退出信号后等待进行中请求完成的最长时间，超时后强制停止服务")
+	fs.BoolVar(&cfg.warmup, "warmup", false, "启动时先预热存储（执行一次统计查询），成功后健康检查状态才变为 SERVING")
+	fs.IntVar(&cfg.warmupAttempts, "warmup-attempts", defaultWarmupAttempts, "预热失败时的最大尝试次数（按指数退避重试），全部失败后服务退出")
+	fs.DurationVar(&cfg.healthInterval, "health-interval", defaultHealthCheckInterval, "后台检查存储可用性并更新健康检查状态的间隔")
+	fs.IntVar(&cfg.maxBatchSize, "max-batch-size", defaultMaxBatchSize, "批量方法（AddTags、RemoveTags 等）单次请求允许的最大图书数量，流式批量方法按整个流累计，超过时返回 InvalidArgument")
+	fs.IntVar(&cfg.maxStreamMessages, "max-stream-messages", defaultMaxStreamMessages, "客户端流式方法（ReplaceCatalog、ValidateBooks、GetBooksBatchStream）单个流允许接收的最大消息数，超过时返回 ResourceExhausted 并关闭流，0 表示不限制")
+	fs.IntVar(&cfg.maxSearchResults, "max-search-results", defaultMaxSearchResults, "SearchBooksByPrice 允许返回的最大图书数量，超过时返回 FailedPrecondition 要求改用 StreamBooks，0 表示不限制")
+	fs.IntVar(&cfg.maxMessageSize, "max-message-size", defaultMaxMessageSize, "最大响应消息大小（字节），ListBooks 响应超过时截断当前页")
+	fs.IntVar(&cfg.maxRecvMessageSize, "max-recv-message-size", defaultMaxRecvMessageSize, "最大请求消息大小（字节），超过时请求被拒绝并记录警告日志")
+	fs.IntVar(&cfg.compressionThreshold, "compression-threshold", 0, "响应大小（字节）不小于该值且客户端支持时使用 gzip 压缩，0 表示不压缩")
+	fs.StringVar(&immutableFields, "immutable-fields", strings.Join(defaultImmutableFields, ","), "设置后不可修改的图书字段，逗号分隔，更新时修改这些字段返回 InvalidArgument")
+	fs.StringVar(&readValidation, "read-validation", string(readValidationOff), "读取图书时的校验策略：off 不校验，log 记录无效图书，skip 跳过无效图书")
+	fs.UintVar(&cfg.maxConcurrentStreams, "max-concurrent-streams", defaultMaxConcurrentStreams, "每个连接允许的最大并发流数量")
+	fs.DurationVar(&cfg.maxConnectionIdle, "max-connection-idle", defaultMaxConnectionIdle, "连接空闲超过该时间后关闭，0 表示不限制")
+	fs.DurationVar(&cfg.maxConnectionAge, "max-connection-age", defaultMaxConnectionAge, "连接存活超过该时间后关闭，客户端需要重新连接，0 表示不限制。在负载均衡后扩容实例时设置（如 30m），使已有连接定期重新分布到新实例")
+	fs.DurationVar(&cfg.maxConnectionAgeGrace, "max-connection-age-grace", defaultMaxConnectionAgeGrace, "连接达到最大存活时间后，等待进行中请求完成的时间，0 表示不限制；有长时间运行的流式调用（如 StreamPriceHistogram）时按其可接受的中断设置")
+	fs.IntVar(&cfg.listenerLimits.maxConnections, "max-connections", defaultMaxConnections, "同时保持的最大连接数，超过时新连接排队等待已有连接关闭，0 表示不限制")
+	fs.DurationVar(&cfg.handshakeTimeout, "handshake-timeout", defaultHandshakeTimeout, "建立连接后完成 HTTP/2 握手的时限，超时关闭连接")
+	fs.DurationVar(&cfg.listenerLimits.readTimeout, "read-timeout", 0, "单次读取连接的时限，客户端超过该时间没有发送任何数据时关闭连接，0 表示不限制（设置时应大于客户端的保活间隔）")
+	fs.DurationVar(&cfg.listenerLimits.writeTimeout, "write-timeout", defaultWriteTimeout, "单次写入连接的时限，客户端长时间不读取响应时关闭连接，0 表示不限制")
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+
+	policy, err := parseReadValidation(readValidation)
+	if err != nil {
+		return nil, err
+	}
+	cfg.readValidation = policy
+	if cfg.logLevel, err = parseLogLevel(logLevelValue); err != nil {
+		return nil, err
+	}
+	if cfg.logFormat != logFormatStructured && cfg.logFormat != logFormatAccess {
+		return nil, fmt.Errorf("无效的日志输出方式: %s（可选 %s、%s）", cfg.logFormat, logFormatStructured, logFormatAccess)
+	}
+	if cfg.accessLogFormat, err = parseAccessLogFormat(accessLogFormatValue); err != nil {
+		return nil, err
+	}
+	if cfg.defaultCurrency, err = parseCurrency(defaultCurrencyValue); err != nil {
+		return nil, err
+	}
+	if quiet {
+		cfg.logLevel = logLevelError
+	}
+	if cfg.shutdownTimeout < 0 {
+		return nil, fmt.Errorf("优雅关闭等待时间不能为负数: %v", cfg.shutdownTimeout)
+	}
+	if cfg.maxBatchSize < 1 {
+		return nil, fmt.Errorf("批量上限必须大于0: %d", cfg.maxBatchSize)
+	}
+	if cfg.maxStreamMessages < 0 {
+		return nil, fmt.Errorf("流式消息上限不能为负数: %d", cfg.maxStreamMessages)
+	}
+	if cfg.listenerLimits.maxConnections < 0 {
+		return nil, fmt.Errorf("最大连接数不能为负数: %d", cfg.listenerLimits.maxConnections)
+	}
+	if cfg.handshakeTimeout <= 0 {
+		return nil, fmt.Errorf("握手时限必须大于0: %v", cfg.handshakeTimeout)
+	}
+	if cfg.listenerLimits.readTimeout < 0 || cfg.listenerLimits.writeTimeout < 0 {
+		return nil, fmt.Errorf("读写时限不能为负数")
+	}
+	if cfg.tombstoneLimit < 1 {
+		return nil, fmt.Errorf("删除记录保留条数必须大于0: %d", cfg.tombstoneLimit)
+	}
+	if cfg.tombstoneRetention < 0 {
+		return nil, fmt.Errorf("删除记录保留时间不能为负数: %v", cfg.tombstoneRetention)
+	}
+	if cfg.writeRate < 0 || math.IsInf(cfg.writeRate, 0) || math.IsNaN(cfg.writeRate) {
+		return nil, fmt.Errorf("写速率必须为非负数: %v", cfg.writeRate)
+	}
+	if cfg.writeBurst < 0 {
+		return nil, fmt.Errorf("突发写请求数不能为负数: %d", cfg.writeBurst)
+	}
+	if cfg.fieldAccess, err = parseFieldAccess(roleMetadata, roleFields, defaultRole); err != nil {
+		return nil, err
+	}
+	if cfg.tenantWriteRates, err = parseTenantWriteRates(splitList(tenantWriteRates)); err != nil {
+		return nil, err
+	}
+	if cfg.maxSearchResults < 0 {
+		return nil, fmt.Errorf("查询结果上限不能为负数: %d", cfg.maxSearchResults)
+	}
+	if cfg.warmupAttempts < 1 {
+		return nil, fmt.Errorf("预热尝试次数必须大于0: %d", cfg.warmupAttempts)
+	}
+	if cfg.slowQueryThreshold < 0 {
+		return nil, fmt.Errorf("慢查询阈值不能为负数: %v", cfg.slowQueryThreshold)
+	}
+	if cfg.logSampleRate < 0 || cfg.logSampleRate > 1 {
+		return nil, fmt.Errorf("采样比例必须在0到1之间: %v", cfg.logSampleRate)
+	}
+	if cfg.immutableFields, err = parseImmutableFields(splitList(immutableFields)); err != nil {
+		return nil, err
+	}
+	if cfg.responsePool && os.Getenv(binaryLogEnv) != "" {
+		return nil, fmt.Errorf("-response-pool 不能与 gRPC 二进制日志（%s）同时使用", binaryLogEnv)
+	}
+	if cfg.enableAdmin && cfg.adminToken == "" {
+		return nil, fmt.Errorf("开启管理接口时必须设置 -admin-token")
+	}
+	if !cfg.enableAdmin {
+		cfg.adminToken = ""
+	}
+	if cfg.allowCIDRs, err = parseCIDRs(splitList(allowCIDRs)); err != nil {
+		return nil, err
+	}
+	cfg.redactFields = splitList(redactFields)
+	cfg.allowMethods = splitList(allowMethods)
+	cfg.denyMethods = splitList(denyMethods)
+	cfg.requiredMetadata = splitList(requiredMetadata)
+	if cfg.tenantMetadata != "" {
+		cfg.requiredMetadata = append(cfg.requiredMetadata, cfg.tenantMetadata)
+	}
+	if cfg.readOnly {
+		cfg.denyMethods = append(cfg.denyMethods, mutatingMethods()...)
+	}
+	return cfg, nil
+}
+
+// newGRPCServer 根据配置创建gRPC服务器并注册图书服务，opts 在配置之后应用（如注入日志实现）
+func newGRPCServer(cfg *config, opts ...ServerOption) (*grpc.Server, *BookServer, error) {
+	// 固定种子在 opts 之前应用，opts 仍可以覆盖
+	if cfg.randSeed != nil {
+		opts = append([]ServerOption{WithRandSeed(*cfg.randSeed)}, opts...)
+	}
+	bookServer := NewBookServer(append([]ServerOption{
+		WithSnapshotTTL(cfg.snapshotTTL),
+		WithTenantKey(cfg.tenantMetadata),
+		WithStreamGrace(cfg.streamGrace),
+		WithBookDefaults(cfg.defaultDescription, cfg.defaultPublishYear),
+		WithDefaultCurrency(cfg.defaultCurrency),
+		WithMaxMessageSize(cfg.maxMessageSize),
+		WithMaxBatchSize(cfg.maxBatchSize),
+		WithMaxSearchResults(cfg.maxSearchResults),
+		WithReadValidation(cfg.readValidation),
+		WithAdminToken(cfg.adminToken),
+		WithImmutableFields(cfg.immutableFields),
+		WithClientIDs(cfg.allowClientIDs),
+		WithTrustClientTimestamps(cfg.trustClientTimestamps),
+		WithResponsePool(cfg.responsePool),
+		WithTombstoneRetention(cfg.tombstoneLimit, cfg.tombstoneRetention),
+		WithWriteQuota(cfg.writeRate, cfg.writeBurst, cfg.tenantWriteRates),
+		WithDescriptionSanitization(cfg.sanitizeDescriptions),
+	}, opts...)...)
+
+	// 处理器和拦截器的日志都按日志级别过滤，在注入的日志实现之外包装，与选项的顺序无关
+	bookServer.logger = newLeveledLogger(bookServer.logger, cfg.logLevel)
+
+	// 创建日志拦截器，记录内容时按配置脱敏；访问日志模式下每次调用只写一行访问日志
+	logOptions := logInterceptorOptions{
+		level:       cfg.logLevel,
+		logPayloads: cfg.logPayloads,
+		redactor:    newFieldRedactor(cfg.redactFields),
+		trailers:    cfg.debugTrailers,
+		sampleRate:  cfg.logSampleRate,
+		random:      bookServer.random,
+
+		slowQueryThreshold: cfg.slowQueryThreshold,
+	}
+	logInterceptor := newLogInterceptor(bookServer.logger, logOptions)
+	if cfg.logFormat == logFormatAccess {
+		access := &accessLogger{w: bookServer.accessLogOutput, format: cfg.accessLogFormat}
+		logInterceptor = newAccessLogInterceptor(bookServer.logger, access, logOptions)
+	}
+
+	// 加载演示数据
+	seeded, err := seedBooks(bookServer, cfg.seed, cfg.seedFile)
+	if err != nil {
+		return nil, nil, fmt.Errorf("加载演示数据失败: %v", err)
+	}
+	if seeded > 0 {
+		bookServer.logger.Info("已加载演示图书", "count", seeded)
+	}
+
+	// 一元拦截器：进行中请求计数、请求大小统计和调用指标在最外层，被拒绝的调用同样会计入；
+	// 其次是详细错误和日志，详细错误在日志之外，日志记录的是原始错误，访问控制等拦截器返回的错误同样附带错误详情
+	unary := []grpc.UnaryServerInterceptor{
+		bookServer.inFlightInterceptor,
+		bookServer.requestSizeInterceptor,
+		bookServer.metricsInterceptor,
+		newRichErrorInterceptor(cfg.richErrors),
+		logInterceptor,
+		newPeerFilterInterceptor(cfg.allowCIDRs),
+		newMethodFilterInterceptor(cfg.allowMethods, cfg.denyMethods),
+		newRequiredMetadataInterceptor(cfg.requiredMetadata),
+		newReadinessInterceptor(bookServer.storePinger),
+		bookServer.writeQuotaInterceptor,
+		newCompressionInterceptor(cfg.compressionThreshold),
+		// 在压缩之内，压缩按裁剪后的响应大小判断
+		newFieldAccessInterceptor(cfg.fieldAccess),
+		// 放在最内层，外层的拦截器（如日志）能看到 panic 转换后的错误
+		bookServer.recoveryInterceptor,
+	}
+
+	// 调试 HTTP 接口经过同样的拦截器，只读模式、访问控制等规则同样生效
+	bookServer.unaryChain = chainUnaryInterceptors(unary)
+
+	// 创建gRPC服务器
+	s := grpc.NewServer(
+		grpc.MaxConcurrentStreams(uint32(cfg.maxConcurrentStreams)),
+		grpc.MaxSendMsgSize(cfg.maxMessageSize),
+		grpc.MaxRecvMsgSize(cfg.maxRecvMessageSize),
+		grpc.StatsHandler(&oversizeLogger{logger: bookServer.logger, maxSize: cfg.maxRecvMessageSize}),
+		// 放在最后，其他 stats.Handler 处理完响应之后才放回消息池
+		grpc.StatsHandler(&responseReleaser{pools: &bookServer.responses}),
+		grpc.KeepaliveParams(keepaliveParams(cfg)),
+		grpc.ConnectionTimeout(cfg.handshakeTimeout),
+		grpc.ChainUnaryInterceptor(unary...),
+		// 流式方法同样需要进行中请求计数、详细错误、调用方网段、方法访问控制、必需元数据检查、存储可用性检查、写配额、字段裁剪和 panic 恢复，
+		// 客户端流式方法还限制接收的消息总数。
+		// panic 恢复在最外层和最内层各有一个：最内层使处理器 panic 转换后的错误能被指标和详细错误看到，
+		// 最外层兜底其他拦截器中的 panic，流式处理器出错时不会使整个进程退出
+		grpc.ChainStreamInterceptor(
+			bookServer.recoveryStreamInterceptor,
+			bookServer.inFlightStreamInterceptor,
+			bookServer.metricsStreamInterceptor,
+			newRichErrorStreamInterceptor(cfg.richErrors),
+			newPeerFilterStreamInterceptor(cfg.allowCIDRs),
+			newMethodFilterStreamInterceptor(cfg.allowMethods, cfg.denyMethods),
+			newRequiredMetadataStreamInterceptor(cfg.requiredMetadata),
+			newReadinessStreamInterceptor(bookServer.storePinger),
+			bookServer.writeQuotaStreamInterceptor,
+			newStreamMessageLimitInterceptor(cfg.maxStreamMessages),
+			newFieldAccessStreamInterceptor(cfg.fieldAccess),
+			bookServer.recoveryStreamInterceptor,
+		),
+	)
+
+	// 注册图书服务和健康检查服务
+	pb.RegisterBookServiceServer(s, bookServer)
+	pbv2.RegisterBookServiceV2Server(s, &bookServiceV2{s: bookServer})
+	bookServer.healthServer = health.NewServer()
+	healthpb.RegisterHealthServer(s, bookServer.healthServer)
+
+	// 需要预热时先标记为 NOT_SERVING，避免负载均衡在预热完成前把流量转发到本实例
+	if cfg.warmup {
+		setServingStatus(bookServer.healthServer, healthpb.HealthCheckResponse_NOT_SERVING)
+	}
+
+	return s, bookServer, nil
+}
+
+// keepaliveParams 根据配置生成连接保活参数，0 表示不限制
+func keepaliveParams(cfg *config) keepalive.ServerParameters {
+	params := keepalive.ServerParameters{
+		MaxConnectionIdle:     cfg.maxConnectionIdle,
+		MaxConnectionAge:      cfg.maxConnectionAge,
+		MaxConnectionAgeGrace: cfg.maxConnectionAgeGrace,
+	}
+	// gRPC 中 0 表示使用默认值，这里统一为不限制
+	if params.MaxConnectionIdle <= 0 {
+		params.MaxConnectionIdle = infinity
+	}
+	if params.MaxConnectionAge <= 0 {
+		params.MaxConnectionAge = infinity
+	}
+	if params.MaxConnectionAgeGrace <= 0 {
+		params.MaxConnectionAgeGrace = infinity
+	}
+	return params
+}
+
+// splitList 拆分逗号分隔的列表，忽略空白项
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// defaultCurrency 未指定币种时使用的默认币种，与客户端原有的 ¥ 价格格式一致
+const defaultCurrency = "CNY"
+
+// knownCurrencies 支持的币种（ISO 4217 代码）
+var knownCurrencies = map[string]bool{
+	"CNY": true, "USD": true, "EUR": true, "JPY": true, "GBP": true, "HKD": true,
+	"TWD": true, "KRW": true, "SGD": true, "AUD": true, "CAD": true, "CHF": true,
+	"INR": true, "RUB": true, "BRL": true, "NZD": true, "SEK": true, "MOP": true,
+}
+
+// normalizeCurrency 去除币种代码首尾空白并转为大写
+func normalizeCurrency(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// isKnownCurrency 返回币种代码（不区分大小写）是否受支持，空字符串表示未设置，视为有效
+func isKnownCurrency(code string) bool {
+	code = normalizeCurrency(code)
+	return code == "" || knownCurrencies[code]
+}
+
+// resolveCurrency 返回规范化后的币种，未设置时使用 fallback（创建时为默认币种，更新时为原有币种）
+func resolveCurrency(code, fallback string) string {
+	if code = normalizeCurrency(code); code != "" {
+		return code
+	}
+	return fallback
+}
+
+// parseCurrency 解析命令行中的默认币种
+func parseCurrency(code string) (string, error) {
+	if code = normalizeCurrency(code); code == "" || !knownCurrencies[code] {
+		return "", fmt.Errorf("无效的币种: %q（需要 ISO 4217 代码，如 CNY、USD）", code)
+	}
+	return code, nil
+}
+
+// WithDefaultCurrency 设置创建图书时未指定币种所使用的默认币种
+func WithDefaultCurrency(code string) ServerOption {
+	return func(s *BookServer) {
+		s.defaults.currency = code
+	}
+}
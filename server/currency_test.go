@@ -0,0 +1,88 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	// 导入生成的protobuf代码
+	pb "grpc-basic-server/pb"
+
+	// 导入gRPC相关包
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// TestCurrency 测试有效币种、无效币种和默认币种
+func TestCurrency(t *testing.T) {
+	client, _ := startTestServer(t, mustParseConfig(t))
+	ctx := context.Background()
+	create := func(currency string) (string, error) {
+		resp, err := client.CreateBook(ctx, &pb.CreateBookRequest{Book: &pb.Book{Title: "图书", Author: "作者", Price: proto.Float32(10), Currency: currency}})
+		return resp.GetId(), err
+	}
+	currencyOf := func(id string) string {
+		resp, err := client.GetBook(ctx, &pb.GetBookRequest{Id: id})
+		if err != nil {
+			t.Fatalf("获取图书失败: %v", err)
+		}
+		return resp.GetBook().GetCurrency()
+	}
+
+	usdID, err := create(" usd ")
+	if err != nil {
+		t.Fatalf("创建图书失败: %v", err)
+	}
+	if got := currencyOf(usdID); got != "USD" {
+		t.Errorf("期望币种规范化为 USD，实际为: %s", got)
+	}
+
+	if _, err := create("XYZ"); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("期望无效币种返回 InvalidArgument，实际为: %v", err)
+	}
+
+	cnyID, err := create("")
+	if err != nil {
+		t.Fatalf("创建图书失败: %v", err)
+	}
+	if got := currencyOf(cnyID); got != "CNY" {
+		t.Errorf("期望默认币种为 CNY，实际为: %s", got)
+	}
+
+	// 更新时未指定币种保留原有币种
+	if _, err := client.UpdateBook(ctx, &pb.UpdateBookRequest{Book: &pb.Book{Id: usdID, Title: "新书名", Author: "作者", Price: proto.Float32(12)}}); err != nil {
+		t.Fatalf("更新图书失败: %v", err)
+	}
+	if got := currencyOf(usdID); got != "USD" {
+		t.Errorf("期望更新后保留币种 USD，实际为: %s", got)
+	}
+
+	// 按价格查询时按币种过滤
+	resp, err := client.SearchBooksByPrice(ctx, &pb.SearchBooksByPriceRequest{MinPrice: 0, MaxPrice: 100, Currency: "cny"})
+	if err != nil {
+		t.Fatalf("按价格查询失败: %v", err)
+	}
+	if ids := bookIDs(resp.GetBooks()); !equalIDs(ids, []string{cnyID}) {
+		t.Errorf("期望只返回 CNY 图书 %s，实际为: %v", cnyID, ids)
+	}
+}
+
+// TestDefaultCurrencyFlag 测试通过 -default-currency 配置默认币种
+func TestDefaultCurrencyFlag(t *testing.T) {
+	client, _ := startTestServer(t, mustParseConfig(t, "-default-currency", "eur"))
+	resp, err := client.CreateBook(context.Background(), &pb.CreateBookRequest{Book: &pb.Book{Title: "图书", Author: "作者", Price: proto.Float32(10)}})
+	if err != nil {
+		t.Fatalf("创建图书失败: %v", err)
+	}
+	got, err := client.GetBook(context.Background(), &pb.GetBookRequest{Id: resp.GetId()})
+	if err != nil {
+		t.Fatalf("获取图书失败: %v", err)
+	}
+	if got.GetBook().GetCurrency() != "EUR" {
+		t.Errorf("期望默认币种为 EUR，实际为: %s", got.GetBook().GetCurrency())
+	}
+
+	if _, err := parseConfig([]string{"-default-currency", "XYZ"}); err == nil {
+		t.Error("期望无效的默认币种返回错误")
+	}
+}
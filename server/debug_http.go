@@ -0,0 +1,229 @@
+package main
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+
+	// 导入生成的protobuf代码
+	pb "grpc-basic-server/pb"
+
+	// 导入gRPC相关包
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// debugHTTPPrefix 调试 HTTP 接口的路径前缀，完整路径为 /debug/<方法名>，如 /debug/CreateBook
+	debugHTTPPrefix = "/debug/"
+
+	// protobufContentType 使用二进制 protobuf 编码的请求和响应的 Content-Type，其余请求按 JSON 处理
+	protobufContentType = "application/x-protobuf"
+
+	// jsonContentType JSON 编码的响应的 Content-Type
+	jsonContentType = "application/json"
+)
+
+// debugMethod 调试 HTTP 接口可以调用的一元方法
+type debugMethod struct {
+	newRequest func() proto.Message
+	call       func(ctx context.Context, req proto.Message) (proto.Message, error)
+}
+
+// newDebugMethod 将类型化的处理器包装为 debugMethod
+func newDebugMethod[Req, Resp proto.Message](newRequest func() Req, call func(context.Context, Req) (Resp, error)) debugMethod {
+	return debugMethod{
+		newRequest: func() proto.Message { return newRequest() },
+		call: func(ctx context.Context, req proto.Message) (proto.Message, error) {
+			return call(ctx, req.(Req))
+		},
+	}
+}
+
+// newDebugHTTPHandler 创建调试 HTTP 接口：将 POST 请求体（JSON 或二进制 protobuf）转换为请求消息，
+// 在进程内经过与 gRPC 相同的拦截器调用处理器，并以相同的编码返回响应；/debug/vars 以 expvar 格式输出运行指标，
+// /readyz 返回就绪状态。
+// 仅用于本地调试
+func newDebugHTTPHandler(s *BookServer) http.Handler {
+	methods := map[string]debugMethod{
+		"CreateBook":         newDebugMethod(func() *pb.CreateBookRequest { return &pb.CreateBookRequest{} }, s.CreateBook),
+		"GetBook":            newDebugMethod(func() *pb.GetBookRequest { return &pb.GetBookRequest{} }, s.GetBook),
+		"UpdateBook":         newDebugMethod(func() *pb.UpdateBookRequest { return &pb.UpdateBookRequest{} }, s.UpdateBook),
+		"DeleteBook":         newDebugMethod(func() *pb.DeleteBookRequest { return &pb.DeleteBookRequest{} }, s.DeleteBook),
+		"ListBooks":          newDebugMethod(func() *pb.ListBooksRequest { return &pb.ListBooksRequest{} }, s.ListBooks),
+		"SearchBooksByPrice": newDebugMethod(func() *pb.SearchBooksByPriceRequest { return &pb.SearchBooksByPriceRequest{} }, s.SearchBooksByPrice),
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle(debugHTTPPrefix+"vars", newVarsHandler(s))
+	mux.Handle(readyzPath, newReadyzHandler(s))
+	mux.HandleFunc(debugHTTPPrefix, func(w http.ResponseWriter, r *http.Request) {
+		binary := r.Header.Get("Content-Type") == protobufContentType
+
+		if r.Method != http.MethodPost {
+			writeDebugError(w, binary, status.Errorf(codes.Unimplemented, "只支持 POST 请求"))
+			return
+		}
+		name := strings.TrimPrefix(r.URL.Path, debugHTTPPrefix)
+		method, exists := methods[name]
+		if !exists {
+			writeDebugError(w, binary, status.Errorf(codes.Unimplemented, "不支持的方法: %s", name))
+			return
+		}
+
+		// 解析请求体，大小限制与 gRPC 的默认最大请求消息大小一致
+		body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, defaultMaxRecvMessageSize))
+		if err != nil {
+			writeDebugError(w, binary, status.Errorf(codes.ResourceExhausted, "读取请求体失败: %v", err))
+			return
+		}
+		req := method.newRequest()
+		if binary {
+			err = proto.Unmarshal(body, req)
+		} else if len(body) > 0 {
+			err = protojson.Unmarshal(body, req)
+		}
+		if err != nil {
+			writeDebugError(w, binary, status.Errorf(codes.InvalidArgument, "解析请求失败: %v", err))
+			return
+		}
+
+		// 模拟 gRPC 调用的上下文：HTTP 头作为元数据，HTTP 客户端地址作为调用方地址
+		stream := &debugTransportStream{method: "/" + pb.BookService_ServiceDesc.ServiceName + "/" + name}
+		ctx := metadata.NewIncomingContext(r.Context(), debugMetadata(r.Header))
+		ctx = grpc.NewContextWithServerTransportStream(ctx, stream)
+		if addr, err := net.ResolveTCPAddr("tcp", r.RemoteAddr); err == nil {
+			ctx = peer.NewContext(ctx, &peer.Peer{Addr: addr})
+		}
+
+		info := &grpc.UnaryServerInfo{Server: s, FullMethod: stream.method}
+		resp, err := s.unaryChain(ctx, req, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+			return method.call(ctx, req.(proto.Message))
+		})
+
+		// 处理器设置的响应头和尾部元数据都作为 HTTP 响应头返回
+		for _, md := range []metadata.MD{stream.header, stream.trailer} {
+			for key, values := range md {
+				for _, value := range values {
+					w.Header().Add(key, value)
+				}
+			}
+		}
+		if err != nil {
+			writeDebugError(w, binary, err)
+			return
+		}
+		writeDebugMessage(w, binary, http.StatusOK, resp.(proto.Message))
+	})
+	return mux
+}
+
+// debugMetadata 将 HTTP 头转换为 gRPC 元数据，键统一为小写
+func debugMetadata(header http.Header) metadata.MD {
+	md := metadata.MD{}
+	for key, values := range header {
+		md.Append(strings.ToLower(key), values...)
+	}
+	return md
+}
+
+// writeDebugError 将 gRPC 错误转换为对应的 HTTP 状态码，响应体为 google.rpc.Status
+func writeDebugError(w http.ResponseWriter, binary bool, err error) {
+	st := status.Convert(err)
+	writeDebugMessage(w, binary, httpStatusFromCode(st.Code()), st.Proto())
+}
+
+// writeDebugMessage 按请求的编码写出响应消息
+func writeDebugMessage(w http.ResponseWriter, binary bool, code int, msg proto.Message) {
+	contentType, marshal := jsonContentType, protojson.Marshal
+	if binary {
+		contentType, marshal = protobufContentType, proto.Marshal
+	}
+	data, err := marshal(msg)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(code)
+	w.Write(data)
+}
+
+// httpStatusFromCode 返回 gRPC 状态码对应的 HTTP 状态码，映射规则与 gRPC-Gateway 相同
+func httpStatusFromCode(code codes.Code) int {
+	switch code {
+	case codes.OK:
+		return http.StatusOK
+	case codes.Canceled:
+		return 499
+	case codes.InvalidArgument, codes.FailedPrecondition, codes.OutOfRange:
+		return http.StatusBadRequest
+	case codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.AlreadyExists, codes.Aborted:
+		return http.StatusConflict
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	case codes.Unimplemented:
+		return http.StatusNotImplemented
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// debugTransportStream 在 gRPC 服务器之外调用处理器时使用的传输流，收集处理器设置的元数据
+type debugTransportStream struct {
+	method  string
+	header  metadata.MD
+	trailer metadata.MD
+}
+
+// Method 返回被调用的完整方法名
+func (s *debugTransportStream) Method() string {
+	return s.method
+}
+
+// SetHeader 合并响应头元数据
+func (s *debugTransportStream) SetHeader(md metadata.MD) error {
+	s.header = metadata.Join(s.header, md)
+	return nil
+}
+
+// SendHeader 合并响应头元数据，HTTP 响应头在处理器返回后统一写出
+func (s *debugTransportStream) SendHeader(md metadata.MD) error {
+	return s.SetHeader(md)
+}
+
+// SetTrailer 合并尾部元数据
+func (s *debugTransportStream) SetTrailer(md metadata.MD) error {
+	s.trailer = metadata.Join(s.trailer, md)
+	return nil
+}
+
+// chainUnaryInterceptors 将多个一元拦截器组合为一个，执行顺序与 grpc.ChainUnaryInterceptor 相同
+func chainUnaryInterceptors(interceptors []grpc.UnaryServerInterceptor) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		for i := len(interceptors) - 1; i >= 0; i-- {
+			interceptor, next := interceptors[i], handler
+			handler = func(ctx context.Context, req interface{}) (interface{}, error) {
+				return interceptor(ctx, req, info, next)
+			}
+		}
+		return handler(ctx, req)
+	}
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	// 导入生成的protobuf代码
+	pb "grpc-basic-server/pb"
+
+	// 导入gRPC相关包
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// TestDebugHTTPCreateBook 测试通过调试 HTTP 接口用 JSON 创建的图书可以通过 gRPC 读取
+func TestDebugHTTPCreateBook(t *testing.T) {
+	conn, bookServer := startTestConn(t, mustParseConfig(t))
+	client := pb.NewBookServiceClient(conn)
+	httpServer := httptest.NewServer(newDebugHTTPHandler(bookServer))
+	defer httpServer.Close()
+
+	body := `{"book": {"title": "调试图书", "author": "作者", "price": 12.5}}`
+	resp, err := http.Post(httpServer.URL+"/debug/CreateBook", "application/json", strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("调用调试接口失败: %v", err)
+	}
+	defer resp.Body.Close()
+	data, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("期望状态码200，实际为: %d %s", resp.StatusCode, data)
+	}
+	if resp.Header.Get(trailerServerVersion) != serverVersion {
+		t.Errorf("期望响应头包含服务端版本，实际为: %v", resp.Header)
+	}
+
+	created := &pb.CreateBookResponse{}
+	if err := protojson.Unmarshal(data, created); err != nil {
+		t.Fatalf("解析响应失败: %v", err)
+	}
+	got, err := client.GetBook(context.Background(), &pb.GetBookRequest{Id: created.GetId()})
+	if err != nil {
+		t.Fatalf("通过gRPC获取图书失败: %v", err)
+	}
+	if got.GetBook().GetTitle() != "调试图书" || got.GetBook().GetPrice() != 12.5 {
+		t.Errorf("期望读取到调试接口创建的图书，实际为: %v", got.GetBook())
+	}
+
+	// 二进制 protobuf 编码
+	req, _ := proto.Marshal(&pb.GetBookRequest{Id: created.GetId()})
+	resp, err = http.Post(httpServer.URL+"/debug/GetBook", protobufContentType, strings.NewReader(string(req)))
+	if err != nil {
+		t.Fatalf("调用调试接口失败: %v", err)
+	}
+	defer resp.Body.Close()
+	data, _ = io.ReadAll(resp.Body)
+	fetched := &pb.GetBookResponse{}
+	if err := proto.Unmarshal(data, fetched); err != nil || fetched.GetBook().GetTitle() != "调试图书" {
+		t.Errorf("期望二进制编码返回图书，实际为: %v, %v", fetched, err)
+	}
+}
+
+// TestDebugHTTPErrors 测试 gRPC 错误转换为 HTTP 状态码，只读模式同样生效
+func TestDebugHTTPErrors(t *testing.T) {
+	_, bookServer := startTestConn(t, mustParseConfig(t, "-readonly"))
+	httpServer := httptest.NewServer(newDebugHTTPHandler(bookServer))
+	defer httpServer.Close()
+
+	tests := []struct {
+		path string
+		body string
+		want int
+	}{
+		{"/debug/GetBook", `{"id": "不存在"}`, http.StatusNotFound},
+		{"/debug/GetBook", `{"id": `, http.StatusBadRequest},
+		{"/debug/CreateBook", `{"book": {"title": "图书", "author": "作者", "price": 10}}`, http.StatusForbidden},
+		{"/debug/StreamBooks", `{}`, http.StatusNotImplemented},
+	}
+	for _, tt := range tests {
+		resp, err := http.Post(httpServer.URL+tt.path, "application/json", strings.NewReader(tt.body))
+		if err != nil {
+			t.Fatalf("调用调试接口失败: %v", err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != tt.want {
+			t.Errorf("%s %s 期望状态码%d，实际为: %d", tt.path, tt.body, tt.want, resp.StatusCode)
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"time"
+
+	// 导入生成的protobuf代码
+	pb "grpc-basic-server/pb"
+
+	// 导入gRPC相关包
+	"google.golang.org/protobuf/proto"
+)
+
+// bookDefaults 创建图书时为未设置的可选字段填充的默认值，零值表示不填充
+type bookDefaults struct {
+	// 未设置描述时使用的默认描述
+	description string
+
+	// 未设置出版年份时是否使用当前年份
+	currentPublishYear bool
+
+	// 币种为空时使用的默认币种，由 WithDefaultCurrency 设置
+	currency string
+}
+
+// WithBookDefaults 设置创建图书时可选字段的默认值
+func WithBookDefaults(description string, currentPublishYear bool) ServerOption {
+	return func(s *BookServer) {
+		s.defaults.description = description
+		s.defaults.currentPublishYear = currentPublishYear
+	}
+}
+
+// apply 为未设置的可选字段填充默认值。描述和出版年份带有字段存在性，
+// 显式设置为空字符串或0时保留客户端的值；币种没有字段存在性，以空字符串作为未设置
+func (d bookDefaults) apply(book *pb.Book, now time.Time) {
+	if book.Description == nil && d.description != "" {
+		book.Description = proto.String(d.description)
+	}
+	if book.PublishYear == nil && d.currentPublishYear {
+		book.PublishYear = proto.Int32(int32(now.Year()))
+	}
+	book.Currency = resolveCurrency(book.GetCurrency(), d.currency)
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	// 导入生成的protobuf代码
+	pb "grpc-basic-server/pb"
+
+	// 导入gRPC相关包
+	"google.golang.org/protobuf/proto"
+)
+
+// TestCreateBookDefaults 测试开启默认值后，未设置的可选字段被填充
+func TestCreateBookDefaults(t *testing.T) {
+	// 创建开启默认值的服务器实例
+	server := NewBookServer(WithBookDefaults("暂无描述", true))
+
+	resp, err := server.CreateBook(context.Background(), &pb.CreateBookRequest{
+		Book: &pb.Book{Title: "图书", Author: "作者", Price: proto.Float32(10)},
+	})
+	if err != nil {
+		t.Fatalf("创建图书失败: %v", err)
+	}
+
+	book := server.books[resp.Id]
+	if year := int32(time.Now().Year()); book.GetPublishYear() != year {
+		t.Errorf("期望出版年份为%d，实际为: %d", year, book.GetPublishYear())
+	}
+	if book.GetDescription() != "暂无描述" {
+		t.Errorf("期望描述为默认值，实际为: %s", book.GetDescription())
+	}
+}
+
+// TestCreateBookDefaultsKeepProvided 测试已设置的字段不会被默认值覆盖
+func TestCreateBookDefaultsKeepProvided(t *testing.T) {
+	server := NewBookServer(WithBookDefaults("暂无描述", true))
+
+	resp, err := server.CreateBook(context.Background(), &pb.CreateBookRequest{
+		Book: &pb.Book{Title: "图书", Author: "作者", Price: proto.Float32(10), Description: proto.String("描述"), PublishYear: proto.Int32(2001)},
+	})
+	if err != nil {
+		t.Fatalf("创建图书失败: %v", err)
+	}
+
+	book := server.books[resp.Id]
+	if book.GetPublishYear() != 2001 || book.GetDescription() != "描述" {
+		t.Errorf("已设置的字段不应被覆盖，实际为: %v", book)
+	}
+
+	// 显式设置为空字符串和0同样视为已设置
+	resp, err = server.CreateBook(context.Background(), &pb.CreateBookRequest{
+		Book: &pb.Book{Title: "图书", Author: "作者", Price: proto.Float32(10), Description: proto.String(""), PublishYear: proto.Int32(0)},
+	})
+	if err != nil {
+		t.Fatalf("创建图书失败: %v", err)
+	}
+	if book := server.books[resp.Id]; book.Description == nil || book.GetDescription() != "" || book.GetPublishYear() != 0 {
+		t.Errorf("显式设置的零值不应被覆盖，实际为: %v", book)
+	}
+}
+
+// TestCreateBookNoDefaults 测试默认不填充任何字段
+func TestCreateBookNoDefaults(t *testing.T) {
+	server := NewBookServer()
+
+	resp, err := server.CreateBook(context.Background(), &pb.CreateBookRequest{
+		Book: &pb.Book{Title: "图书", Author: "作者", Price: proto.Float32(10)},
+	})
+	if err != nil {
+		t.Fatalf("创建图书失败: %v", err)
+	}
+
+	book := server.books[resp.Id]
+	if book.GetPublishYear() != 0 || book.GetDescription() != "" {
+		t.Errorf("未开启默认值时不应填充字段，实际为: %v", book)
+	}
+}
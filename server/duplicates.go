@@ -0,0 +1,80 @@
+package main
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"unicode"
+
+	// 导入生成的protobuf代码
+	pb "grpc-basic-server/pb"
+
+	// 导入gRPC相关包
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// FindDuplicates 按规范化的标题和作者（或 ISBN）对图书分组，返回成员多于一本的分组
+func (s *BookServer) FindDuplicates(ctx context.Context, req *pb.FindDuplicatesRequest) (*pb.FindDuplicatesResponse, error) {
+	// 记录请求日志
+	s.logger.Info("收到查找重复图书请求", "strategy", req.GetStrategy())
+
+	// 验证判定方式
+	if _, ok := pb.DuplicateStrategy_name[int32(req.GetStrategy())]; !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "不支持的重复判定方式: %v", req.GetStrategy())
+	}
+
+	// 在读锁内只按分组键收集图书，排序放到锁外进行
+	s.mu.RLock()
+	catalog := s.catalogFor(ctx, false)
+	groups := make(map[string][]*pb.Book)
+	for _, book := range catalog.books {
+		if !s.validForRead(book) {
+			continue
+		}
+		if key := duplicateKey(book, req.GetStrategy()); key != "" {
+			groups[key] = append(groups[key], book)
+		}
+	}
+	s.mu.RUnlock()
+
+	resp := &pb.FindDuplicatesResponse{}
+	for key, books := range groups {
+		if len(books) > 1 {
+			sortBooksByID(books)
+			resp.Groups = append(resp.Groups, &pb.DuplicateGroup{Key: key, Books: books})
+		}
+	}
+	sort.Slice(resp.Groups, func(i, j int) bool { return resp.Groups[i].GetKey() < resp.Groups[j].GetKey() })
+
+	s.logger.Info("查找重复图书完成", "groups", len(resp.Groups))
+
+	return resp, nil
+}
+
+// duplicateKey 返回图书按指定方式判定重复时的分组键，返回空字符串表示不参与分组
+func duplicateKey(book *pb.Book, strategy pb.DuplicateStrategy) string {
+	if strategy == pb.DuplicateStrategy_DUPLICATE_STRATEGY_ISBN {
+		return normalizeISBN(book.GetIsbn())
+	}
+	return normalizeText(book.GetTitle()) + "|" + normalizeText(book.GetAuthor())
+}
+
+// normalizeText 转为小写，标点和连续空白都视为一个空格，
+// 使 "Clean Code" 与 "clean  code." 得到相同的结果
+func normalizeText(text string) string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	return strings.Join(words, " ")
+}
+
+// normalizeISBN 去掉 ISBN 中的连字符和空白，校验位 x 统一为大写
+func normalizeISBN(isbn string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '-' || unicode.IsSpace(r) {
+			return -1
+		}
+		return unicode.ToUpper(r)
+	}, isbn)
+}
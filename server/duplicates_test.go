@@ -0,0 +1,52 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	// 导入生成的protobuf代码
+	pb "grpc-basic-server/pb"
+
+	// 导入gRPC相关包
+	"google.golang.org/protobuf/proto"
+)
+
+// TestFindDuplicates 测试标题和作者仅有大小写、标点差异的图书被分到同一组
+func TestFindDuplicates(t *testing.T) {
+	// 创建服务器实例
+	server := NewBookServer()
+	ids := server.loadBooks([]*pb.Book{
+		{Title: "Clean Code", Author: "Robert C. Martin", Price: proto.Float32(10), Isbn: "978-0132350884"},
+		{Title: "clean  code.", Author: "robert c martin", Price: proto.Float32(20), Isbn: "9780132350884"},
+		{Title: "Clean Architecture", Author: "Robert C. Martin", Price: proto.Float32(30)},
+	})
+
+	resp, err := server.FindDuplicates(context.Background(), &pb.FindDuplicatesRequest{})
+	if err != nil {
+		t.Fatalf("查找重复图书失败: %v", err)
+	}
+	if len(resp.GetGroups()) != 1 {
+		t.Fatalf("期望1个分组，实际为: %v", resp.GetGroups())
+	}
+	if group := resp.GetGroups()[0]; !equalIDs(bookIDs(group.GetBooks()), ids[:2]) {
+		t.Errorf("期望分组包含 %v，实际为: %v", ids[:2], bookIDs(group.GetBooks()))
+	}
+
+	// 按 ISBN 分组，未设置 ISBN 的图书不参与
+	resp, err = server.FindDuplicates(context.Background(), &pb.FindDuplicatesRequest{Strategy: pb.DuplicateStrategy_DUPLICATE_STRATEGY_ISBN})
+	if err != nil {
+		t.Fatalf("查找重复图书失败: %v", err)
+	}
+	if len(resp.GetGroups()) != 1 || resp.GetGroups()[0].GetKey() != "9780132350884" {
+		t.Errorf("期望按ISBN得到1个分组，实际为: %v", resp.GetGroups())
+	}
+}
+
+// bookIDs 返回图书列表的ID
+func bookIDs(books []*pb.Book) []string {
+	ids := make([]string, 0, len(books))
+	for _, book := range books {
+		ids = append(ids, book.GetId())
+	}
+	return ids
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"context"
+	"strconv"
+
+	// 导入gRPC相关包
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// 条件请求使用的元数据键：响应头中的 etag 为图书的当前版本，
+// 请求中的 if-none-match 为客户端缓存的版本，if-match 为更新前读取到的版本
+const (
+	etagHeader        = "etag"
+	ifNoneMatchHeader = "if-none-match"
+	ifMatchHeader     = "if-match"
+)
+
+// formatETag 将图书版本号格式化为 etag
+func formatETag(version int64) string {
+	return strconv.FormatInt(version, 10)
+}
+
+// notModified 判断请求携带的 if-none-match 是否与当前 etag 一致
+func notModified(ctx context.Context, etag string) bool {
+	md, _ := metadata.FromIncomingContext(ctx)
+	for _, v := range md.Get(ifNoneMatchHeader) {
+		if v == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// preconditionFailed 判断请求携带的 if-match 是否与当前 etag 不一致，没有携带时不检查
+func preconditionFailed(ctx context.Context, etag string) bool {
+	md, _ := metadata.FromIncomingContext(ctx)
+	vals := md.Get(ifMatchHeader)
+	return len(vals) > 0 && vals[0] != etag
+}
+
+// setETagHeader 在响应头中返回图书的当前 etag，直接调用处理器（没有 gRPC 流）时忽略
+func setETagHeader(ctx context.Context, logger Logger, etag string) {
+	if grpc.ServerTransportStreamFromContext(ctx) == nil {
+		return
+	}
+	if err := grpc.SetHeader(ctx, metadata.Pairs(etagHeader, etag)); err != nil {
+		logger.Warn("设置etag响应头失败", "error", err)
+	}
+}
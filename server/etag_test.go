@@ -0,0 +1,85 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	// 导入生成的protobuf代码
+	pb "grpc-basic-server/pb"
+
+	// 导入gRPC相关包
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// TestGetBookETag 测试 if-none-match 与当前版本一致时返回未修改，图书修改后返回完整图书
+func TestGetBookETag(t *testing.T) {
+	client, server := startTestServer(t, mustParseConfig(t))
+	ids := server.loadBooks([]*pb.Book{{Title: "图书", Author: "作者", Price: proto.Float32(10)}})
+	ctx := context.Background()
+
+	var header metadata.MD
+	if _, err := client.GetBook(ctx, &pb.GetBookRequest{Id: ids[0]}, grpc.Header(&header)); err != nil {
+		t.Fatalf("获取图书失败: %v", err)
+	}
+	etags := header.Get(etagHeader)
+	if len(etags) != 1 {
+		t.Fatalf("期望响应头中包含etag，实际为: %v", header)
+	}
+
+	// 未修改时不返回图书内容
+	conditional := metadata.AppendToOutgoingContext(ctx, ifNoneMatchHeader, etags[0])
+	resp, err := client.GetBook(conditional, &pb.GetBookRequest{Id: ids[0]})
+	if err != nil || !resp.GetNotModified() || resp.GetBook() != nil {
+		t.Errorf("期望返回未修改标记，实际为: %v, %v", resp, err)
+	}
+
+	// 修改后返回完整图书
+	if _, err := client.UpdateBook(ctx, &pb.UpdateBookRequest{Book: &pb.Book{Id: ids[0], Title: "新书名", Author: "作者", Price: proto.Float32(10)}}); err != nil {
+		t.Fatalf("更新图书失败: %v", err)
+	}
+	resp, err = client.GetBook(conditional, &pb.GetBookRequest{Id: ids[0]})
+	if err != nil || resp.GetNotModified() || resp.GetBook().GetTitle() != "新书名" {
+		t.Errorf("期望修改后返回完整图书，实际为: %v, %v", resp, err)
+	}
+}
+
+// TestUpdateBookIfMatch 测试 if-match 与当前版本一致时更新成功，图书在读取后被修改过时返回 Aborted
+func TestUpdateBookIfMatch(t *testing.T) {
+	client, server := startTestServer(t, mustParseConfig(t))
+	ids := server.loadBooks([]*pb.Book{{Title: "图书", Author: "作者", Price: proto.Float32(10)}})
+	ctx := context.Background()
+
+	var header metadata.MD
+	if _, err := client.GetBook(ctx, &pb.GetBookRequest{Id: ids[0]}, grpc.Header(&header)); err != nil {
+		t.Fatalf("获取图书失败: %v", err)
+	}
+	etag := header.Get(etagHeader)[0]
+
+	// 其他请求在读取之后修改了图书
+	if _, err := client.UpdateBook(ctx, &pb.UpdateBookRequest{Book: &pb.Book{Id: ids[0], Title: "并发修改", Author: "作者"}}); err != nil {
+		t.Fatalf("更新图书失败: %v", err)
+	}
+
+	stale := metadata.AppendToOutgoingContext(ctx, ifMatchHeader, etag)
+	_, err := client.UpdateBook(stale, &pb.UpdateBookRequest{Book: &pb.Book{Id: ids[0], Title: "过期的修改", Author: "作者"}})
+	if status.Code(err) != codes.Aborted {
+		t.Errorf("期望错误码为Aborted，实际为: %v", err)
+	}
+	if title := server.books[ids[0]].GetTitle(); title != "并发修改" {
+		t.Errorf("并发的修改不应被覆盖，实际标题为: %s", title)
+	}
+
+	// 使用最新的版本可以更新
+	header = nil
+	if _, err := client.GetBook(ctx, &pb.GetBookRequest{Id: ids[0]}, grpc.Header(&header)); err != nil {
+		t.Fatalf("获取图书失败: %v", err)
+	}
+	current := metadata.AppendToOutgoingContext(ctx, ifMatchHeader, header.Get(etagHeader)[0])
+	if _, err := client.UpdateBook(current, &pb.UpdateBookRequest{Book: &pb.Book{Id: ids[0], Title: "新书名", Author: "作者"}}); err != nil {
+		t.Errorf("版本一致时应更新成功: %v", err)
+	}
+}
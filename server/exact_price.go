@@ -0,0 +1,21 @@
+package main
+
+import (
+	"context"
+
+	// 导入生成的protobuf代码
+	pb "grpc-basic-server/pb"
+)
+
+// GetBooksByExactPrice 按精确价格查询图书，委托给最低和最高价格相等的 SearchBooksByPrice，
+// 参数校验、币种过滤和结果数量上限都与后者相同
+func (s *BookServer) GetBooksByExactPrice(ctx context.Context, req *pb.GetBooksByExactPriceRequest) (*pb.SearchBooksByPriceResponse, error) {
+	// 记录请求日志
+	s.logger.Info("收到按精确价格查询图书请求", "price", req.GetPrice())
+
+	return s.SearchBooksByPrice(ctx, &pb.SearchBooksByPriceRequest{
+		MinPrice: req.GetPrice(),
+		MaxPrice: req.GetPrice(),
+		Currency: req.GetCurrency(),
+	})
+}
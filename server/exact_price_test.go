@@ -0,0 +1,69 @@
+package main
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	// 导入生成的protobuf代码
+	pb "grpc-basic-server/pb"
+
+	// 导入gRPC相关包
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// TestSearchBooksByEqualPriceBounds 测试最低和最高价格相等时只返回价格恰好相等的图书，
+// GetBooksByExactPrice 返回相同的结果
+func TestSearchBooksByEqualPriceBounds(t *testing.T) {
+	client, server := startTestServer(t, mustParseConfig(t))
+	ids := server.loadBooks([]*pb.Book{
+		{Title: "图书1", Author: "作者", Price: proto.Float32(19.99)},
+		{Title: "图书2", Author: "作者", Price: proto.Float32(20)},
+		{Title: "图书3", Author: "作者", Price: proto.Float32(20)},
+		{Title: "图书4", Author: "作者", Price: proto.Float32(20.01)},
+		{Title: "图书5", Author: "作者", Price: proto.Float32(20), Currency: "USD"},
+	})
+	ctx := context.Background()
+	bookIDs := func(books []*pb.Book) []string {
+		var result []string
+		for _, book := range books {
+			result = append(result, book.GetId())
+		}
+		return result
+	}
+
+	// 闭区间：两端相等时返回价格恰好为20的图书，不包含相邻价格
+	resp, err := client.SearchBooksByPrice(ctx, &pb.SearchBooksByPriceRequest{MinPrice: 20, MaxPrice: 20})
+	if err != nil {
+		t.Fatalf("按价格查询失败: %v", err)
+	}
+	want := []string{ids[1], ids[2], ids[4]}
+	if got := bookIDs(resp.GetBooks()); !slices.Equal(got, want) {
+		t.Errorf("期望返回 %v，实际为: %v", want, got)
+	}
+
+	exact, err := client.GetBooksByExactPrice(ctx, &pb.GetBooksByExactPriceRequest{Price: 20})
+	if err != nil {
+		t.Fatalf("按精确价格查询失败: %v", err)
+	}
+	if got := bookIDs(exact.GetBooks()); !slices.Equal(got, want) {
+		t.Errorf("精确价格查询期望返回 %v，实际为: %v", want, got)
+	}
+
+	// 币种过滤同样生效；没有匹配时返回空列表
+	exact, err = client.GetBooksByExactPrice(ctx, &pb.GetBooksByExactPriceRequest{Price: 20, Currency: "USD"})
+	if err != nil || !slices.Equal(bookIDs(exact.GetBooks()), []string{ids[4]}) {
+		t.Errorf("期望只返回美元图书，实际为: %v, %v", exact, err)
+	}
+	exact, err = client.GetBooksByExactPrice(ctx, &pb.GetBooksByExactPriceRequest{Price: 21})
+	if err != nil || len(exact.GetBooks()) != 0 {
+		t.Errorf("期望没有匹配的图书，实际为: %v, %v", exact, err)
+	}
+
+	// 负数价格与区间查询一样被拒绝
+	if _, err := client.GetBooksByExactPrice(ctx, &pb.GetBooksByExactPriceRequest{Price: -1}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("期望错误码为InvalidArgument，实际为: %v", err)
+	}
+}
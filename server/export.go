@@ -0,0 +1,130 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"strconv"
+
+	// 导入生成的protobuf代码
+	pb "grpc-basic-server/pb"
+
+	// 导入gRPC相关包
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// exportChunkSize 导出时每个数据块的目标大小
+const exportChunkSize = 32 * 1024
+
+// exportCSVHeader 导出 CSV 的表头，与 -seed-file 支持的列兼容
+var exportCSVHeader = []string{"id", "title", "author", "price", "description", "publish_year", "stock", "currency"}
+
+// StreamExport 按过滤条件流式导出图书，数据按块发送，
+// 发送受 gRPC 流控约束，客户端处理慢时服务端会随之阻塞
+func (s *BookServer) StreamExport(req *pb.StreamExportRequest, stream grpc.ServerStreamingServer[pb.ExportChunk]) error {
+	ctx := stream.Context()
+
+	// 记录请求日志
+	s.logger.Info("收到流式导出请求", "format", req.GetFormat(), "filter", req.GetFilter())
+
+	// 验证请求参数
+	if err := validateFilter(req.GetFilter()); err != nil {
+		return err
+	}
+	encode, err := newExportEncoder(req.GetFormat())
+	if err != nil {
+		return err
+	}
+
+	books, err := s.booksForRead(ctx, "")
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	flush := func() error {
+		if buf.Len() == 0 {
+			return nil
+		}
+		// 复制数据，避免发送后复用缓冲区影响已发送的消息
+		data := append([]byte(nil), buf.Bytes()...)
+		buf.Reset()
+		return stream.Send(&pb.ExportChunk{Data: data})
+	}
+
+	if req.GetFormat() == pb.ExportFormat_EXPORT_FORMAT_CSV {
+		if err := writeCSVRecord(&buf, exportCSVHeader); err != nil {
+			return status.Errorf(codes.Internal, "导出失败: %v", err)
+		}
+	}
+
+	exported := 0
+	for _, book := range books {
+		if !matchFilter(book, req.GetFilter()) {
+			continue
+		}
+		if err := ctx.Err(); err != nil {
+			return status.FromContextError(err).Err()
+		}
+		if err := encode(&buf, book); err != nil {
+			return status.Errorf(codes.Internal, "导出图书失败，ID: %s, 错误: %v", book.GetId(), err)
+		}
+		exported++
+
+		if buf.Len() >= exportChunkSize {
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+	}
+	if err := flush(); err != nil {
+		return err
+	}
+
+	s.logger.Info("流式导出完成", "count", exported)
+
+	return nil
+}
+
+// newExportEncoder 返回指定格式的单本图书编码函数
+func newExportEncoder(format pb.ExportFormat) (func(buf *bytes.Buffer, book *pb.Book) error, error) {
+	switch format {
+	case pb.ExportFormat_EXPORT_FORMAT_JSONL:
+		return func(buf *bytes.Buffer, book *pb.Book) error {
+			data, err := protojson.Marshal(book)
+			if err != nil {
+				return err
+			}
+			buf.Write(data)
+			buf.WriteByte('\n')
+			return nil
+		}, nil
+	case pb.ExportFormat_EXPORT_FORMAT_CSV:
+		return func(buf *bytes.Buffer, book *pb.Book) error {
+			return writeCSVRecord(buf, []string{
+				book.GetId(),
+				book.GetTitle(),
+				book.GetAuthor(),
+				strconv.FormatFloat(float64(book.GetPrice()), 'f', -1, 32),
+				book.GetDescription(),
+				strconv.Itoa(int(book.GetPublishYear())),
+				strconv.Itoa(int(book.GetStock())),
+				book.GetCurrency(),
+			})
+		}, nil
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "不支持的导出格式: %v", format)
+	}
+}
+
+// writeCSVRecord 将一行 CSV 写入缓冲区
+func writeCSVRecord(buf *bytes.Buffer, record []string) error {
+	w := csv.NewWriter(buf)
+	if err := w.Write(record); err != nil {
+		return err
+	}
+	w.Flush()
+	return w.Error()
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	// 导入生成的protobuf代码
+	pb "grpc-basic-server/pb"
+
+	// 导入gRPC相关包
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// exportBooks 调用 StreamExport 并拼接所有数据块
+func exportBooks(t *testing.T, client pb.BookServiceClient, req *pb.StreamExportRequest) []byte {
+	t.Helper()
+
+	stream, err := client.StreamExport(context.Background(), req)
+	if err != nil {
+		t.Fatalf("流式导出失败: %v", err)
+	}
+	var buf bytes.Buffer
+	for {
+		chunk, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("接收导出数据失败: %v", err)
+		}
+		buf.Write(chunk.GetData())
+	}
+	return buf.Bytes()
+}
+
+// TestStreamExportCSV 测试按价格区间导出 CSV
+func TestStreamExportCSV(t *testing.T) {
+	client, server := startTestServer(t, mustParseConfig(t))
+
+	// 足够多的图书，保证导出被拆分为多个数据块
+	books := make([]*pb.Book, 2000)
+	for i := range books {
+		books[i] = &pb.Book{Title: fmt.Sprintf("图书%d", i), Author: "作者", Price: proto.Float32(float32(i%100 + 1)), Description: proto.String("描述, 带逗号")}
+	}
+	server.loadBooks(books)
+
+	data := exportBooks(t, client, &pb.StreamExportRequest{
+		Filter: &pb.BookFilter{MinPrice: 1, MaxPrice: 10},
+		Format: pb.ExportFormat_EXPORT_FORMAT_CSV,
+	})
+
+	records, err := csv.NewReader(bytes.NewReader(data)).ReadAll()
+	if err != nil {
+		t.Fatalf("解析导出的CSV失败: %v", err)
+	}
+	if strings.Join(records[0], ",") != strings.Join(exportCSVHeader, ",") {
+		t.Errorf("表头错误: %v", records[0])
+	}
+	if rows := len(records) - 1; rows != 200 {
+		t.Errorf("期望导出200行，实际为: %d", rows)
+	}
+
+	// 导出的 CSV 可以直接作为演示数据加载
+	seeded, err := parseSeedCSV(bytes.NewReader(data))
+	if err != nil || len(seeded) != 200 {
+		t.Errorf("导出的CSV无法作为演示数据加载: %d, %v", len(seeded), err)
+	}
+}
+
+// TestStreamExportJSONL 测试导出 JSON Lines
+func TestStreamExportJSONL(t *testing.T) {
+	client, server := startTestServer(t, mustParseConfig(t))
+	server.loadBooks([]*pb.Book{
+		{Title: "图书1", Author: "作者", Price: proto.Float32(10)},
+		{Title: "图书2", Author: "作者", Price: proto.Float32(20)},
+	})
+
+	data := exportBooks(t, client, &pb.StreamExportRequest{Format: pb.ExportFormat_EXPORT_FORMAT_JSONL})
+
+	lines := strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("期望导出2行，实际为: %d", len(lines))
+	}
+	for _, line := range lines {
+		book := &pb.Book{}
+		if err := protojson.Unmarshal([]byte(line), book); err != nil {
+			t.Errorf("解析导出的JSON失败: %v", err)
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"context"
+	"sort"
+
+	// 导入生成的protobuf代码
+	pb "grpc-basic-server/pb"
+
+	// 导入gRPC相关包
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// SetFeatured 将图书设置为推荐图书，已推荐的图书会更新排序
+func (s *BookServer) SetFeatured(ctx context.Context, req *pb.SetFeaturedRequest) (*pb.FeaturedResponse, error) {
+	// 记录请求日志
+	s.logger.Info("收到设置推荐图书请求", "id", req.GetId(), "rank", req.GetRank())
+
+	// 验证请求参数
+	if req.GetId() == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "图书ID不能为空")
+	}
+	if req.GetRank() < 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "推荐排序不能为负数")
+	}
+
+	// 加写锁保护并发访问
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.setFeatured(ctx, req.GetId(), true, req.GetRank()); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("成功设置推荐图书", "id", req.GetId())
+
+	return &pb.FeaturedResponse{
+		Message: "推荐图书设置成功",
+	}, nil
+}
+
+// UnsetFeatured 取消图书的推荐状态
+func (s *BookServer) UnsetFeatured(ctx context.Context, req *pb.UnsetFeaturedRequest) (*pb.FeaturedResponse, error) {
+	// 记录请求日志
+	s.logger.Info("收到取消推荐图书请求", "id", req.GetId())
+
+	// 验证请求参数
+	if req.GetId() == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "图书ID不能为空")
+	}
+
+	// 加写锁保护并发访问
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.setFeatured(ctx, req.GetId(), false, 0); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("成功取消推荐图书", "id", req.GetId())
+
+	return &pb.FeaturedResponse{
+		Message: "推荐图书已取消",
+	}, nil
+}
+
+// ListFeaturedBooks 按推荐排序列出推荐图书，排序相同时按ID排序
+func (s *BookServer) ListFeaturedBooks(ctx context.Context, _ *emptypb.Empty) (*pb.ListFeaturedBooksResponse, error) {
+	// 记录请求日志
+	s.logger.Info("收到推荐图书列表请求")
+
+	// 加读锁保护并发访问
+	s.mu.RLock()
+	var books []*pb.Book
+	for _, book := range s.catalogFor(ctx, false).books {
+		if book.GetFeatured() {
+			books = append(books, book)
+		}
+	}
+	s.mu.RUnlock()
+	books = s.filterForRead(books)
+
+	sort.Slice(books, func(i, j int) bool {
+		if books[i].GetFeaturedRank() != books[j].GetFeaturedRank() {
+			return books[i].GetFeaturedRank() < books[j].GetFeaturedRank()
+		}
+		return idLess(books[i].GetId(), books[j].GetId())
+	})
+
+	s.logger.Info("返回推荐图书", "count", len(books))
+
+	return &pb.ListFeaturedBooksResponse{
+		Books: books,
+	}, nil
+}
+
+// setFeatured 修改图书的推荐状态，调用方必须持有写锁
+func (s *BookServer) setFeatured(ctx context.Context, id string, featured bool, rank int32) error {
+	catalog := s.catalogFor(ctx, false)
+	book, err := catalog.get(id)
+	if err != nil {
+		s.logger.Warn("图书不存在，无法修改推荐状态", "id", id)
+		return s.storeErrToStatus(err)
+	}
+
+	// 替换为新的副本，不原地修改已存储的图书
+	updated := proto.Clone(book).(*pb.Book)
+	updated.Featured = featured
+	updated.FeaturedRank = rank
+	catalog.put(updated, s.clock.Now())
+	return nil
+}
@@ -0,0 +1,131 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	// 导入生成的protobuf代码
+	pb "grpc-basic-server/pb"
+
+	// 导入gRPC相关包
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// featuredIDs 返回推荐图书列表中的图书ID
+func featuredIDs(t *testing.T, server *BookServer) []string {
+	t.Helper()
+
+	resp, err := server.ListFeaturedBooks(context.Background(), &emptypb.Empty{})
+	if err != nil {
+		t.Fatalf("获取推荐图书失败: %v", err)
+	}
+	ids := make([]string, 0, len(resp.Books))
+	for _, book := range resp.Books {
+		ids = append(ids, book.GetId())
+	}
+	return ids
+}
+
+// equalIDs 比较两个ID列表是否相同
+func equalIDs(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// TestFeaturedBooks 测试设置、重新排序和取消推荐图书
+func TestFeaturedBooks(t *testing.T) {
+	// 创建服务器实例
+	server := NewBookServer()
+	ids := server.loadBooks([]*pb.Book{
+		{Title: "图书1", Author: "作者1", Price: proto.Float32(10)},
+		{Title: "图书2", Author: "作者2", Price: proto.Float32(20)},
+		{Title: "图书3", Author: "作者3", Price: proto.Float32(30)},
+	})
+	ctx := context.Background()
+
+	// 设置推荐图书
+	for id, rank := range map[string]int32{ids[0]: 2, ids[1]: 1} {
+		if _, err := server.SetFeatured(ctx, &pb.SetFeaturedRequest{Id: id, Rank: rank}); err != nil {
+			t.Fatalf("设置推荐图书失败: %v", err)
+		}
+	}
+	if got := featuredIDs(t, server); !equalIDs(got, []string{ids[1], ids[0]}) {
+		t.Errorf("推荐图书顺序错误: %v", got)
+	}
+
+	// 重新排序，排序相同时按ID排序
+	if _, err := server.SetFeatured(ctx, &pb.SetFeaturedRequest{Id: ids[0], Rank: 1}); err != nil {
+		t.Fatalf("更新推荐排序失败: %v", err)
+	}
+	if got := featuredIDs(t, server); !equalIDs(got, []string{ids[0], ids[1]}) {
+		t.Errorf("重新排序后推荐图书顺序错误: %v", got)
+	}
+
+	// 取消推荐后不再出现在列表中
+	if _, err := server.UnsetFeatured(ctx, &pb.UnsetFeaturedRequest{Id: ids[0]}); err != nil {
+		t.Fatalf("取消推荐图书失败: %v", err)
+	}
+	if got := featuredIDs(t, server); !equalIDs(got, []string{ids[1]}) {
+		t.Errorf("取消推荐后推荐图书列表错误: %v", got)
+	}
+	if server.books[ids[0]].GetFeatured() {
+		t.Errorf("取消推荐后图书不应标记为推荐")
+	}
+}
+
+// TestUpdateBookKeepsFeatured 测试更新图书不会改变推荐状态
+func TestUpdateBookKeepsFeatured(t *testing.T) {
+	server := NewBookServer()
+	ids := server.loadBooks([]*pb.Book{{Title: "图书", Author: "作者", Price: proto.Float32(10)}})
+	ctx := context.Background()
+
+	if _, err := server.SetFeatured(ctx, &pb.SetFeaturedRequest{Id: ids[0], Rank: 3}); err != nil {
+		t.Fatalf("设置推荐图书失败: %v", err)
+	}
+	_, err := server.UpdateBook(ctx, &pb.UpdateBookRequest{
+		Book: &pb.Book{Id: ids[0], Title: "新标题", Author: "作者", Price: proto.Float32(12)},
+	})
+	if err != nil {
+		t.Fatalf("更新图书失败: %v", err)
+	}
+
+	book := server.books[ids[0]]
+	if !book.GetFeatured() || book.GetFeaturedRank() != 3 {
+		t.Errorf("更新图书后推荐状态丢失: %v", book)
+	}
+}
+
+// TestSetFeaturedErrors 测试设置推荐图书的错误情况
+func TestSetFeaturedErrors(t *testing.T) {
+	server := NewBookServer()
+	ids := server.loadBooks([]*pb.Book{{Title: "图书", Author: "作者", Price: proto.Float32(10)}})
+	ctx := context.Background()
+
+	tests := []struct {
+		name string
+		req  *pb.SetFeaturedRequest
+		code codes.Code
+	}{
+		{"空ID", &pb.SetFeaturedRequest{Rank: 1}, codes.InvalidArgument},
+		{"负数排序", &pb.SetFeaturedRequest{Id: ids[0], Rank: -1}, codes.InvalidArgument},
+		{"图书不存在", &pb.SetFeaturedRequest{Id: "book-999", Rank: 1}, codes.NotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := server.SetFeatured(ctx, tt.req)
+			if status.Code(err) != tt.code {
+				t.Errorf("期望错误码为%v，实际为: %v", tt.code, status.Code(err))
+			}
+		})
+	}
+}
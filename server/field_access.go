@@ -0,0 +1,183 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	// 导入生成的protobuf代码
+	pb "grpc-basic-server/pb"
+	pbv2 "grpc-basic-server/pb/v2"
+
+	// 导入gRPC相关包
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// allFields 角色字段列表中表示可以看到所有字段
+const allFields = "*"
+
+// fieldAccess 按调用方角色裁剪响应中图书字段的规则。角色从元数据 key 中读取，
+// 没有携带角色或角色未配置时使用 defaultRole；图书ID总是可见
+type fieldAccess struct {
+	key         string
+	defaultRole string
+
+	// 每个角色可见的字段，值为 nil 表示可以看到所有字段
+	roles map[string]map[protoreflect.Name]bool
+}
+
+// bookMessages 需要按角色裁剪字段的图书消息（v1 和 v2）
+var bookMessages = map[protoreflect.FullName]bool{
+	(&pb.Book{}).ProtoReflect().Descriptor().FullName():   true,
+	(&pbv2.Book{}).ProtoReflect().Descriptor().FullName(): true,
+}
+
+// parseFieldAccess 解析角色可见字段配置，格式为 角色=字段,字段;角色=*，如 public=id,title,author;admin=*。
+// 字段名为 v1 或 v2 图书的 proto 字段名；key 为空时不开启字段裁剪
+func parseFieldAccess(key, spec, defaultRole string) (*fieldAccess, error) {
+	if key == "" {
+		return nil, nil
+	}
+
+	access := &fieldAccess{key: strings.ToLower(key), defaultRole: defaultRole, roles: make(map[string]map[protoreflect.Name]bool)}
+	for _, entry := range strings.Split(spec, ";") {
+		if strings.TrimSpace(entry) == "" {
+			continue
+		}
+		role, fields, ok := strings.Cut(entry, "=")
+		role = strings.TrimSpace(role)
+		if !ok || role == "" {
+			return nil, fmt.Errorf("无效的角色字段配置 %q，格式应为 角色=字段,字段", entry)
+		}
+		if _, exists := access.roles[role]; exists {
+			return nil, fmt.Errorf("角色 %s 重复配置", role)
+		}
+
+		names := splitList(fields)
+		if len(names) == 1 && names[0] == allFields {
+			access.roles[role] = nil
+			continue
+		}
+		visible := make(map[protoreflect.Name]bool, len(names))
+		for _, name := range names {
+			if !isBookField(protoreflect.Name(name)) {
+				return nil, fmt.Errorf("图书没有字段: %s", name)
+			}
+			visible[protoreflect.Name(name)] = true
+		}
+		access.roles[role] = visible
+	}
+
+	if _, ok := access.roles[defaultRole]; !ok {
+		return nil, fmt.Errorf("默认角色 %q 没有配置可见字段", defaultRole)
+	}
+	return access, nil
+}
+
+// isBookField 判断 v1 或 v2 的图书是否有该字段
+func isBookField(name protoreflect.Name) bool {
+	return (&pb.Book{}).ProtoReflect().Descriptor().Fields().ByName(name) != nil ||
+		(&pbv2.Book{}).ProtoReflect().Descriptor().Fields().ByName(name) != nil
+}
+
+// visibleFields 返回调用方角色可见的字段，nil 表示可以看到所有字段
+func (a *fieldAccess) visibleFields(ctx context.Context) map[protoreflect.Name]bool {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if vals := md.Get(a.key); len(vals) > 0 {
+		if visible, ok := a.roles[vals[0]]; ok {
+			return visible
+		}
+	}
+	return a.roles[a.defaultRole]
+}
+
+// project 返回裁剪了不可见图书字段的响应副本，原消息（可能引用存储中的图书）不会被修改
+func (a *fieldAccess) project(ctx context.Context, msg interface{}) interface{} {
+	m, ok := msg.(proto.Message)
+	if !ok || m == nil {
+		return msg
+	}
+	visible := a.visibleFields(ctx)
+	if visible == nil {
+		return msg
+	}
+
+	clone := proto.Clone(m)
+	projectMessage(clone.ProtoReflect(), visible)
+	return clone
+}
+
+// projectMessage 递归查找图书消息，清除不可见的字段
+func projectMessage(m protoreflect.Message, visible map[protoreflect.Name]bool) {
+	if bookMessages[m.Descriptor().FullName()] {
+		var hidden []protoreflect.FieldDescriptor
+		m.Range(func(fd protoreflect.FieldDescriptor, _ protoreflect.Value) bool {
+			if fd.Name() != "id" && !visible[fd.Name()] {
+				hidden = append(hidden, fd)
+			}
+			return true
+		})
+		for _, fd := range hidden {
+			m.Clear(fd)
+		}
+		return
+	}
+
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		if fd.Kind() != protoreflect.MessageKind {
+			return true
+		}
+		switch {
+		case fd.IsList():
+			list := v.List()
+			for i := 0; i < list.Len(); i++ {
+				projectMessage(list.Get(i).Message(), visible)
+			}
+		case fd.IsMap():
+			if fd.MapValue().Kind() == protoreflect.MessageKind {
+				v.Map().Range(func(_ protoreflect.MapKey, mv protoreflect.Value) bool {
+					projectMessage(mv.Message(), visible)
+					return true
+				})
+			}
+		default:
+			projectMessage(v.Message(), visible)
+		}
+		return true
+	})
+}
+
+// newFieldAccessInterceptor 创建按调用方角色裁剪响应中图书字段的拦截器，access 为 nil 时不裁剪
+func newFieldAccessInterceptor(access *fieldAccess) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		resp, err := handler(ctx, req)
+		if access == nil || err != nil {
+			return resp, err
+		}
+		return access.project(ctx, resp), nil
+	}
+}
+
+// newFieldAccessStreamInterceptor 创建裁剪流式响应中图书字段的拦截器，规则与一元方法相同
+func newFieldAccessStreamInterceptor(access *fieldAccess) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if access == nil {
+			return handler(srv, ss)
+		}
+		return handler(srv, &projectingServerStream{ServerStream: ss, access: access})
+	}
+}
+
+// projectingServerStream 发送前裁剪消息中图书字段的服务端流
+type projectingServerStream struct {
+	grpc.ServerStream
+	access *fieldAccess
+}
+
+// SendMsg 裁剪不可见的字段后发送
+func (s *projectingServerStream) SendMsg(m interface{}) error {
+	return s.ServerStream.SendMsg(s.access.project(s.Context(), m))
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	// 导入生成的protobuf代码
+	pb "grpc-basic-server/pb"
+
+	// 导入gRPC相关包
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+)
+
+// roleContext 返回携带角色元数据的上下文
+func roleContext(role string) context.Context {
+	return metadata.AppendToOutgoingContext(context.Background(), "x-role", role)
+}
+
+// TestFieldAccessByRole 测试 public 角色看不到库存，admin 角色可以看到所有字段
+func TestFieldAccessByRole(t *testing.T) {
+	client, server := startTestServer(t, mustParseConfig(t,
+		"-role-metadata", "x-role",
+		"-role-fields", "public=id,title,author,price,currency;admin=*",
+	))
+	ids := server.loadBooks([]*pb.Book{{Title: "图书", Author: "作者", Price: proto.Float32(10), Stock: 7}})
+
+	// public 角色的一元和流式响应都不包含库存
+	resp, err := client.GetBook(roleContext("public"), &pb.GetBookRequest{Id: ids[0]})
+	if err != nil {
+		t.Fatalf("获取图书失败: %v", err)
+	}
+	if book := resp.GetBook(); book.GetStock() != 0 || book.GetId() != ids[0] || book.GetTitle() != "图书" || book.GetPrice() != 10 {
+		t.Errorf("public 角色期望只看到可见字段，实际为: %v", book)
+	}
+	stream, err := client.StreamBooks(roleContext("public"), &pb.StreamBooksRequest{})
+	if err != nil {
+		t.Fatalf("流式获取图书失败: %v", err)
+	}
+	msg, err := stream.Recv()
+	if err != nil {
+		t.Fatalf("接收图书失败: %v", err)
+	}
+	if msg.GetBook().GetStock() != 0 || msg.GetBook().GetTitle() != "图书" {
+		t.Errorf("public 角色的流式响应期望不包含库存，实际为: %v", msg.GetBook())
+	}
+
+	// 未携带角色时使用默认角色 public
+	resp, err = client.GetBook(context.Background(), &pb.GetBookRequest{Id: ids[0]})
+	if err != nil || resp.GetBook().GetStock() != 0 {
+		t.Errorf("默认角色期望看不到库存，实际为: %v, %v", resp, err)
+	}
+
+	// admin 角色可以看到库存
+	resp, err = client.GetBook(roleContext("admin"), &pb.GetBookRequest{Id: ids[0]})
+	if err != nil || resp.GetBook().GetStock() != 7 {
+		t.Errorf("admin 角色期望看到库存7，实际为: %v, %v", resp, err)
+	}
+
+	// 裁剪的是响应副本，存储中的图书不受影响
+	server.mu.RLock()
+	stock := server.books[ids[0]].GetStock()
+	server.mu.RUnlock()
+	if stock != 7 {
+		t.Errorf("存储中的库存不应被修改，实际为: %d", stock)
+	}
+}
+
+// TestParseFieldAccess 测试角色字段配置的校验
+func TestParseFieldAccess(t *testing.T) {
+	for _, spec := range []string{
+		"public=id,cost",     // 图书没有该字段
+		"admin=*",            // 默认角色 public 未配置
+		"public=id;public=*", // 角色重复
+		"=id",                // 缺少角色名
+	} {
+		if _, err := parseFieldAccess("x-role", spec, "public"); err == nil {
+			t.Errorf("期望配置 %q 校验失败", spec)
+		}
+	}
+	if access, err := parseFieldAccess("", "", "public"); access != nil || err != nil {
+		t.Errorf("未设置角色元数据键时期望不开启，实际为: %v, %v", access, err)
+	}
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"strings"
+
+	// 导入生成的protobuf代码
+	pb "grpc-basic-server/pb"
+
+	// 导入gRPC相关包
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// validateFilter 验证过滤条件，filter 为空时视为不过滤
+func validateFilter(filter *pb.BookFilter) error {
+	if !isFinite(filter.GetMinPrice()) || !isFinite(filter.GetMaxPrice()) {
+		return status.Errorf(codes.InvalidArgument, "价格必须是有效数字")
+	}
+	if filter.GetMinPrice() < 0 {
+		return status.Errorf(codes.InvalidArgument, "最低价格不能为负数")
+	}
+	if filter.GetMaxPrice() < 0 {
+		return status.Errorf(codes.InvalidArgument, "最高价格不能为负数")
+	}
+	if filter.GetMaxPrice() > 0 && filter.GetMaxPrice() < filter.GetMinPrice() {
+		return status.Errorf(codes.InvalidArgument, "最高价格不能小于最低价格")
+	}
+	return nil
+}
+
+// matchFilter 判断图书是否符合过滤条件，filter 为空时匹配所有图书
+func matchFilter(book *pb.Book, filter *pb.BookFilter) bool {
+	if filter == nil {
+		return true
+	}
+
+	price := book.GetPrice()
+	if price < filter.GetMinPrice() {
+		return false
+	}
+	if filter.GetMaxPrice() > 0 && price > filter.GetMaxPrice() {
+		return false
+	}
+	if filter.GetAuthor() != "" && !strings.EqualFold(book.GetAuthor(), filter.GetAuthor()) {
+		return false
+	}
+	if filter.GetPublishYear() != 0 && book.GetPublishYear() != filter.GetPublishYear() {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,142 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	// 导入生成的protobuf代码
+	pb "grpc-basic-server/pb"
+
+	// 导入gRPC相关包
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// defaultHealthCheckInterval 后台检查存储可用性的默认间隔
+const defaultHealthCheckInterval = 5 * time.Second
+
+// 启动预热的默认尝试次数和重试退避时间，退避时间每次失败后翻倍，不超过 maxWarmupBackoff
+const (
+	defaultWarmupAttempts = 5
+	warmupInitialBackoff  = 200 * time.Millisecond
+	maxWarmupBackoff      = 5 * time.Second
+)
+
+// pinger 可检查连通性的存储
+type pinger interface {
+	Ping(ctx context.Context) error
+}
+
+// Ping 检查图书存储是否可用，内存存储总是可用
+func (s *BookServer) Ping(ctx context.Context) error {
+	return nil
+}
+
+// WithPinger 设置检查存储可用性的实现（如数据库连接），用于每次调用前的就绪检查和后台健康检查。
+// 不设置时使用内存存储，它总是可用，因此不做每次调用前的检查
+func WithPinger(store pinger) ServerOption {
+	return func(s *BookServer) {
+		s.storePinger = store
+	}
+}
+
+// healthPinger 返回后台健康检查使用的存储，未设置 WithPinger 时为内存存储本身
+func (s *BookServer) healthPinger() pinger {
+	if s.storePinger != nil {
+		return s.storePinger
+	}
+	return s
+}
+
+// warmer 可在启动时预热的存储
+type warmer interface {
+	Warmup(ctx context.Context) error
+}
+
+// Warmup 预热图书存储：执行一次统计图书数量的查询。内存存储没有需要预热的连接和缓存，总是成功
+func (s *BookServer) Warmup(ctx context.Context) error {
+	s.logger.Info("存储预热查询完成", "books", s.bookCount())
+	return nil
+}
+
+// setServingStatus 同时设置整体服务和图书服务的健康检查状态
+func setServingStatus(healthServer *health.Server, status healthpb.HealthCheckResponse_ServingStatus) {
+	healthServer.SetServingStatus("", status)
+	healthServer.SetServingStatus(pb.BookService_ServiceDesc.ServiceName, status)
+}
+
+// runWarmup 预热存储，失败时按指数退避重试（按 clock 计时），最多尝试 attempts 次；ctx 被取消时提前返回。
+// 预热期间健康状态保持 NOT_SERVING，预热成功后再启动 runHealthCheck 标记为 SERVING
+func runWarmup(ctx context.Context, logger Logger, clock Clock, store warmer, attempts int, backoff time.Duration) error {
+	var err error
+	for attempt := 1; attempt <= attempts; attempt++ {
+		if err = store.Warmup(ctx); err == nil {
+			logger.Info("存储预热成功", "attempt", attempt)
+			return nil
+		}
+		logger.Warn("存储预热失败", "attempt", attempt, "error", err)
+		if attempt == attempts {
+			break
+		}
+
+		// 时钟只提供周期定时器，等到第一次触发即停止，测试中可以用手动推进的时钟代替真实的等待
+		timer := clock.NewTicker(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C():
+		}
+		timer.Stop()
+		backoff = min(backoff*2, maxWarmupBackoff)
+	}
+	return err
+}
+
+// runHealthCheck 按 clock 的定时器定期检查存储可用性并更新健康检查状态，直到 ctx 被取消。
+// 检查失败时整体服务和图书服务均标记为 NOT_SERVING，恢复后重新标记为 SERVING
+func runHealthCheck(ctx context.Context, logger Logger, clock Clock, healthServer *health.Server, store pinger, interval time.Duration) {
+	ticker := clock.NewTicker(interval)
+	defer ticker.Stop()
+
+	var current healthpb.HealthCheckResponse_ServingStatus
+	for {
+		next := healthpb.HealthCheckResponse_SERVING
+		if err := store.Ping(ctx); err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			next = healthpb.HealthCheckResponse_NOT_SERVING
+			logger.Error("存储不可用", "error", err)
+		}
+		if next != current {
+			logger.Info("健康状态变更", "status", next)
+			setServingStatus(healthServer, next)
+			current = next
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C():
+		}
+	}
+}
+
+// readyzPath 就绪检查的 HTTP 路径，注册在调试 HTTP 接口上
+const readyzPath = "/readyz"
+
+// newReadyzHandler 创建就绪检查接口：整体服务的健康检查状态为 SERVING 时返回 200，
+// 否则（存储不可用、预热未完成或正在关闭）返回 503，与 gRPC 健康检查的结果一致
+func newReadyzHandler(s *BookServer) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp, err := s.healthServer.Check(r.Context(), &healthpb.HealthCheckRequest{})
+		if err != nil || resp.GetStatus() != healthpb.HealthCheckResponse_SERVING {
+			http.Error(w, resp.GetStatus().String(), http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprintln(w, resp.GetStatus())
+	})
+}
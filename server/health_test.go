@@ -0,0 +1,160 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	// 导入gRPC相关包
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// failingStore 测试用的存储，failing 为 true 时 Ping 返回错误
+type failingStore struct {
+	failing atomic.Bool
+}
+
+// Ping 模拟存储连通性检查
+func (s *failingStore) Ping(ctx context.Context) error {
+	if s.failing.Load() {
+		return errors.New("连接被拒绝")
+	}
+	return nil
+}
+
+// waitForHealth 等待健康检查状态变为期望值
+func waitForHealth(t *testing.T, healthServer *health.Server, want healthpb.HealthCheckResponse_ServingStatus) {
+	t.Helper()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		resp, err := healthServer.Check(context.Background(), &healthpb.HealthCheckRequest{})
+		if err == nil && resp.GetStatus() == want {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("期望健康状态为%v，实际为: %v, %v", want, resp.GetStatus(), err)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// TestHealthCheckFailingStore 测试存储不可用时健康状态为 NOT_SERVING，下一次定时检查发现恢复后重新为 SERVING
+func TestHealthCheckFailingStore(t *testing.T) {
+	_, bookServer := startTestServer(t, mustParseConfig(t))
+	clock := newFakeClock()
+	store := &failingStore{}
+	store.failing.Store(true)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go runHealthCheck(ctx, bookServer.logger, clock, bookServer.healthServer, store, time.Minute)
+
+	waitForHealth(t, bookServer.healthServer, healthpb.HealthCheckResponse_NOT_SERVING)
+
+	// 存储恢复后，到下一次检查前状态不变
+	store.failing.Store(false)
+	waitUntil(t, func() bool { return clock.activeTickers() == 1 })
+	clock.Advance(time.Minute)
+	waitForHealth(t, bookServer.healthServer, healthpb.HealthCheckResponse_SERVING)
+}
+
+// TestHealthCheckInMemoryStore 测试内存存储总是可用
+func TestHealthCheckInMemoryStore(t *testing.T) {
+	_, bookServer := startTestServer(t, mustParseConfig(t))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go runHealthCheck(ctx, bookServer.logger, newFakeClock(), bookServer.healthServer, bookServer, time.Minute)
+
+	waitForHealth(t, bookServer.healthServer, healthpb.HealthCheckResponse_SERVING)
+}
+
+// TestReadyz 测试 /readyz 按健康检查状态返回 200 或 503
+func TestReadyz(t *testing.T) {
+	_, bookServer := startTestConn(t, mustParseConfig(t))
+	httpServer := httptest.NewServer(newDebugHTTPHandler(bookServer))
+	defer httpServer.Close()
+
+	get := func() int {
+		t.Helper()
+		resp, err := http.Get(httpServer.URL + readyzPath)
+		if err != nil {
+			t.Fatalf("调用就绪检查接口失败: %v", err)
+		}
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	if code := get(); code != http.StatusOK {
+		t.Errorf("期望状态码200，实际为: %d", code)
+	}
+	setServingStatus(bookServer.healthServer, healthpb.HealthCheckResponse_NOT_SERVING)
+	if code := get(); code != http.StatusServiceUnavailable {
+		t.Errorf("存储不可用时期望状态码503，实际为: %d", code)
+	}
+}
+
+// slowWarmer 测试用的存储，前 failures 次预热失败，之后等待 release 关闭后才成功
+type slowWarmer struct {
+	failures int
+	attempts atomic.Int32
+	release  chan struct{}
+}
+
+// Warmup 模拟冷启动的存储
+func (w *slowWarmer) Warmup(ctx context.Context) error {
+	if int(w.attempts.Add(1)) <= w.failures {
+		return errors.New("连接超时")
+	}
+	select {
+	case <-w.release:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// TestWarmupBeforeServing 测试开启预热时健康状态保持 NOT_SERVING，预热（含失败重试）完成后才变为 SERVING
+func TestWarmupBeforeServing(t *testing.T) {
+	_, bookServer := startTestServer(t, mustParseConfig(t, "-warmup"))
+	clock := newFakeClock()
+	warmer := &slowWarmer{failures: 2, release: make(chan struct{})}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		if err := runWarmup(ctx, bookServer.logger, clock, warmer, 3, time.Second); err != nil {
+			t.Errorf("预热失败: %v", err)
+			return
+		}
+		runHealthCheck(ctx, bookServer.logger, clock, bookServer.healthServer, &failingStore{}, time.Minute)
+	}()
+
+	// 每次失败后等待退避时间（1秒、2秒）再重试，时钟推进前不会重试
+	for attempt, backoff := range []time.Duration{time.Second, 2 * time.Second} {
+		waitUntil(t, func() bool { return int(warmer.attempts.Load()) == attempt+1 && clock.activeTickers() == 1 })
+		clock.Advance(backoff)
+	}
+
+	// 第3次尝试阻塞在预热中，健康状态仍为 NOT_SERVING
+	waitUntil(t, func() bool { return warmer.attempts.Load() == 3 })
+	waitForHealth(t, bookServer.healthServer, healthpb.HealthCheckResponse_NOT_SERVING)
+
+	close(warmer.release)
+	waitForHealth(t, bookServer.healthServer, healthpb.HealthCheckResponse_SERVING)
+}
+
+// TestWarmupGivesUp 测试预热全部失败时返回最后一次的错误
+func TestWarmupGivesUp(t *testing.T) {
+	warmer := &slowWarmer{failures: 10}
+	err := runWarmup(context.Background(), NewBookServer().logger, realClock{}, warmer, 3, time.Millisecond)
+	if err == nil || warmer.attempts.Load() != 3 {
+		t.Errorf("期望尝试3次后返回错误，实际尝试%d次, %v", warmer.attempts.Load(), err)
+	}
+}
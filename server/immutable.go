@@ -0,0 +1,57 @@
+package main
+
+import (
+	"fmt"
+
+	// 导入生成的protobuf代码
+	pb "grpc-basic-server/pb"
+
+	// 导入gRPC相关包
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// defaultImmutableFields 默认设置后不可修改的字段，ISBN 是图书的自然键
+var defaultImmutableFields = []string{"isbn"}
+
+// parseImmutableFields 将字段名解析为 Book 的字段描述，字段不存在时返回错误
+func parseImmutableFields(names []string) ([]protoreflect.FieldDescriptor, error) {
+	fields := (&pb.Book{}).ProtoReflect().Descriptor().Fields()
+	immutable := make([]protoreflect.FieldDescriptor, 0, len(names))
+	for _, name := range names {
+		fd := fields.ByName(protoreflect.Name(name))
+		if fd == nil {
+			return nil, fmt.Errorf("图书没有字段: %s", name)
+		}
+		immutable = append(immutable, fd)
+	}
+	return immutable, nil
+}
+
+// WithImmutableFields 设置更新图书时不可修改的字段：已存储的值非空时，更新必须保持相同的值
+func WithImmutableFields(fields []protoreflect.FieldDescriptor) ServerOption {
+	return func(s *BookServer) {
+		s.immutableFields = fields
+	}
+}
+
+// checkImmutableFields 比较已存储的图书和更新后的图书，修改了不可修改的字段时返回 InvalidArgument。
+// 更新中未设置的不可修改字段沿用已存储的值，不了解该字段的旧客户端仍然可以正常更新
+func (s *BookServer) checkImmutableFields(stored, updated *pb.Book) error {
+	storedMsg, updatedMsg := stored.ProtoReflect(), updated.ProtoReflect()
+	for _, fd := range s.immutableFields {
+		// 尚未设置的字段可以在更新时设置
+		if !storedMsg.Has(fd) {
+			continue
+		}
+		if !updatedMsg.Has(fd) {
+			updatedMsg.Set(fd, storedMsg.Get(fd))
+			continue
+		}
+		if !storedMsg.Get(fd).Equal(updatedMsg.Get(fd)) {
+			return status.Errorf(codes.InvalidArgument, "字段 %s 设置后不可修改", fd.Name())
+		}
+	}
+	return nil
+}
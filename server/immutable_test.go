@@ -0,0 +1,62 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	// 导入生成的protobuf代码
+	pb "grpc-basic-server/pb"
+
+	// 导入gRPC相关包
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// TestImmutableISBN 测试更新图书时不能修改已设置的 ISBN
+func TestImmutableISBN(t *testing.T) {
+	server := NewBookServer()
+	ids := server.loadBooks([]*pb.Book{{Title: "图书", Author: "作者", Price: proto.Float32(10), Isbn: "978-7-111-11111-1"}})
+	ctx := context.Background()
+
+	// 修改 ISBN 被拒绝，错误信息中包含字段名
+	_, err := server.UpdateBook(ctx, &pb.UpdateBookRequest{Book: &pb.Book{
+		Id: ids[0], Title: "图书", Author: "作者", Price: proto.Float32(10), Isbn: "978-7-222-22222-2",
+	}})
+	if st := status.Convert(err); st.Code() != codes.InvalidArgument || st.Message() != "字段 isbn 设置后不可修改" {
+		t.Errorf("期望修改ISBN返回InvalidArgument，实际为: %v", err)
+	}
+
+	// 未携带 ISBN 的更新沿用已存储的值
+	if _, err := server.UpdateBook(ctx, &pb.UpdateBookRequest{Book: &pb.Book{
+		Id: ids[0], Title: "新书名", Author: "作者", Price: proto.Float32(10),
+	}}); err != nil {
+		t.Fatalf("期望未携带ISBN的更新成功，实际为: %v", err)
+	}
+	if book := server.books[ids[0]]; book.GetTitle() != "新书名" || book.GetIsbn() != "978-7-111-11111-1" {
+		t.Errorf("期望更新书名并保留ISBN，实际为: %v", book)
+	}
+}
+
+// TestImmutableFieldsUnset 测试不可修改的字段尚未设置时可以在更新中设置
+func TestImmutableFieldsUnset(t *testing.T) {
+	server := NewBookServer()
+	ids := server.loadBooks([]*pb.Book{{Title: "图书", Author: "作者", Price: proto.Float32(10)}})
+
+	if _, err := server.UpdateBook(context.Background(), &pb.UpdateBookRequest{Book: &pb.Book{
+		Id: ids[0], Title: "图书", Author: "作者", Price: proto.Float32(10), Isbn: "978-7-111-11111-1",
+	}}); err != nil {
+		t.Errorf("期望可以设置尚未设置的ISBN，实际为: %v", err)
+	}
+}
+
+// TestParseImmutableFields 测试配置不存在的字段时返回错误
+func TestParseImmutableFields(t *testing.T) {
+	if _, err := parseConfig([]string{"-immutable-fields", "isbn,publisher"}); err == nil {
+		t.Errorf("期望不存在的字段返回错误")
+	}
+	cfg, err := parseConfig([]string{"-immutable-fields", "isbn,author"})
+	if err != nil || len(cfg.immutableFields) != 2 {
+		t.Errorf("期望解析出2个字段，实际为: %v, %v", cfg, err)
+	}
+}
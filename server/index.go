@@ -0,0 +1,101 @@
+package main
+
+import (
+	"strings"
+
+	// 导入生成的protobuf代码
+	pb "grpc-basic-server/pb"
+)
+
+// idSet 图书ID集合
+type idSet map[string]struct{}
+
+// bookIndex 图书的二级索引（作者、出版年份 → 图书ID，以及按价格排序的索引），
+// 与 bookCatalog.books 在同一把写锁内由 put/remove 维护，按作者、年份或价格区间查询时不必遍历整个存储
+type bookIndex struct {
+	byAuthor map[string]idSet
+	byYear   map[int32]idSet
+	byPrice  priceIndex
+}
+
+// authorKey 作者索引的键：作者匹配与 strings.EqualFold 一样不区分大小写，
+// 先转大写再转小写，使 EqualFold 认为相等的写法（如 ſ 与 s）得到相同的键
+func authorKey(author string) string {
+	return strings.ToLower(strings.ToUpper(author))
+}
+
+// add 将图书加入索引
+func (idx *bookIndex) add(book *pb.Book) {
+	if idx.byAuthor == nil {
+		idx.byAuthor = make(map[string]idSet)
+		idx.byYear = make(map[int32]idSet)
+	}
+	addToSet(idx.byAuthor, authorKey(book.GetAuthor()), book.GetId())
+	addToSet(idx.byYear, book.GetPublishYear(), book.GetId())
+	idx.byPrice.insert(book.GetPrice(), book.GetId())
+}
+
+// delete 将图书从索引中删除，集合为空时删除对应的键
+func (idx *bookIndex) delete(book *pb.Book) {
+	deleteFromSet(idx.byAuthor, authorKey(book.GetAuthor()), book.GetId())
+	deleteFromSet(idx.byYear, book.GetPublishYear(), book.GetId())
+	idx.byPrice.delete(book.GetPrice(), book.GetId())
+}
+
+// addToSet 将 id 加入 key 对应的集合
+func addToSet[K comparable](sets map[K]idSet, key K, id string) {
+	set, exists := sets[key]
+	if !exists {
+		set = make(idSet)
+		sets[key] = set
+	}
+	set[id] = struct{}{}
+}
+
+// deleteFromSet 将 id 从 key 对应的集合中删除
+func deleteFromSet[K comparable](sets map[K]idSet, key K, id string) {
+	if set, exists := sets[key]; exists {
+		delete(set, id)
+		if len(set) == 0 {
+			delete(sets, key)
+		}
+	}
+}
+
+// candidates 返回可能符合过滤条件的图书（无序）：指定作者或出版年份时从索引中取较小的集合，否则返回所有图书。
+// 结果可能包含不符合其他条件的图书，调用方仍需使用 matchFilter 检查。调用方需持有 s.mu
+func (c *bookCatalog) candidates(filter *pb.BookFilter) []*pb.Book {
+	var ids idSet
+	switch author, year := filter.GetAuthor(), filter.GetPublishYear(); {
+	case author == "" && year == 0:
+		books := make([]*pb.Book, 0, len(c.books))
+		for _, book := range c.books {
+			books = append(books, book)
+		}
+		return books
+	case year == 0:
+		ids = c.index.byAuthor[authorKey(author)]
+	case author == "":
+		ids = c.index.byYear[year]
+	default:
+		ids = c.index.byAuthor[authorKey(author)]
+		if byYear := c.index.byYear[year]; len(byYear) < len(ids) {
+			ids = byYear
+		}
+	}
+
+	books := make([]*pb.Book, 0, len(ids))
+	for id := range ids {
+		books = append(books, c.books[id])
+	}
+	return books
+}
+
+// booksInPriceRange 通过价格索引返回价格在 [minPrice, maxPrice] 内的图书，按价格排序。调用方需持有 s.mu
+func (c *bookCatalog) booksInPriceRange(minPrice, maxPrice float32) []*pb.Book {
+	var books []*pb.Book
+	c.index.byPrice.ascend(minPrice, maxPrice, func(id string) {
+		books = append(books, c.books[id])
+	})
+	return books
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	// 导入生成的protobuf代码
+	pb "grpc-basic-server/pb"
+
+	// 导入gRPC相关包
+	"google.golang.org/protobuf/proto"
+)
+
+// indexedIDs 返回按过滤条件从索引中取出的图书ID（已排序）
+func indexedIDs(server *BookServer, filter *pb.BookFilter) []string {
+	server.mu.RLock()
+	defer server.mu.RUnlock()
+
+	var ids []string
+	for _, book := range server.candidates(filter) {
+		ids = append(ids, book.GetId())
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// TestIndexAfterUpdate 测试更新、重命名作者和删除图书后，作者和年份索引与图书存储保持一致
+func TestIndexAfterUpdate(t *testing.T) {
+	server := NewBookServer()
+	ctx := context.Background()
+	ids := server.loadBooks([]*pb.Book{
+		{Title: "图书1", Author: "张三", Price: proto.Float32(10), PublishYear: proto.Int32(2020)},
+		{Title: "图书2", Author: "张三", Price: proto.Float32(10), PublishYear: proto.Int32(2021)},
+		{Title: "图书3", Author: "李四", Price: proto.Float32(10), PublishYear: proto.Int32(2020)},
+	})
+
+	// 更新图书修改作者和年份后，只能从新的作者和年份查到
+	if _, err := server.UpdateBook(ctx, &pb.UpdateBookRequest{Book: &pb.Book{
+		Id: ids[0], Title: "图书1", Author: "王五", Price: proto.Float32(10), PublishYear: proto.Int32(2021),
+	}}); err != nil {
+		t.Fatalf("更新图书失败: %v", err)
+	}
+	if got := indexedIDs(server, &pb.BookFilter{Author: "张三"}); len(got) != 1 || got[0] != ids[1] {
+		t.Errorf("张三的图书应只剩 %s，实际为: %v", ids[1], got)
+	}
+	if got := indexedIDs(server, &pb.BookFilter{Author: "王五"}); len(got) != 1 || got[0] != ids[0] {
+		t.Errorf("王五的图书应为 %s，实际为: %v", ids[0], got)
+	}
+	if got := indexedIDs(server, &pb.BookFilter{PublishYear: 2020}); len(got) != 1 || got[0] != ids[2] {
+		t.Errorf("2020年的图书应只剩 %s，实际为: %v", ids[2], got)
+	}
+
+	// 重命名作者后旧作者名下没有图书，作者匹配不区分大小写
+	if _, err := server.RenameAuthor(ctx, &pb.RenameAuthorRequest{From: "张三", To: "Zhang San"}); err != nil {
+		t.Fatalf("重命名作者失败: %v", err)
+	}
+	if got := indexedIDs(server, &pb.BookFilter{Author: "张三"}); len(got) != 0 {
+		t.Errorf("重命名后张三名下不应有图书，实际为: %v", got)
+	}
+	if got := indexedIDs(server, &pb.BookFilter{Author: "ZHANG SAN", PublishYear: 2021}); len(got) != 1 || got[0] != ids[1] {
+		t.Errorf("期望按作者和年份查到 %s，实际为: %v", ids[1], got)
+	}
+
+	// 删除图书后从索引中移除，空集合被清理
+	if _, err := server.DeleteBook(ctx, &pb.DeleteBookRequest{Id: ids[2]}); err != nil {
+		t.Fatalf("删除图书失败: %v", err)
+	}
+	if got := indexedIDs(server, &pb.BookFilter{Author: "李四"}); len(got) != 0 {
+		t.Errorf("删除后李四名下不应有图书，实际为: %v", got)
+	}
+	if _, exists := server.index.byYear[2020]; exists {
+		t.Errorf("期望清理空的年份集合")
+	}
+}
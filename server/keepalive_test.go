@@ -0,0 +1,45 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	// 导入生成的protobuf代码
+	pb "grpc-basic-server/pb"
+
+	// 导入gRPC相关包
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// TestMaxConnectionIdle 测试连接空闲超过 MaxConnectionIdle 后被服务端关闭
+func TestMaxConnectionIdle(t *testing.T) {
+	conn, _ := startTestConn(t, mustParseConfig(t, "-max-connection-idle", "100ms"))
+
+	// 发起一次调用建立连接
+	if _, err := pb.NewBookServiceClient(conn).GetStats(context.Background(), &emptypb.Empty{}); err != nil {
+		t.Fatalf("调用失败: %v", err)
+	}
+	if state := conn.GetState(); state != connectivity.Ready {
+		t.Fatalf("期望连接状态为Ready，实际为: %v", state)
+	}
+
+	// 保持空闲，等待服务端关闭连接
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if !conn.WaitForStateChange(ctx, connectivity.Ready) {
+		t.Fatalf("空闲连接未被关闭")
+	}
+	if state := conn.GetState(); state != connectivity.Idle {
+		t.Errorf("期望连接被关闭后进入Idle状态，实际为: %v", state)
+	}
+}
+
+// TestKeepaliveParamsUnlimited 测试 0 表示不限制
+func TestKeepaliveParamsUnlimited(t *testing.T) {
+	params := keepaliveParams(mustParseConfig(t, "-max-connection-idle", "0", "-max-connection-age", "0"))
+	if params.MaxConnectionIdle != infinity || params.MaxConnectionAge != infinity {
+		t.Errorf("期望不限制连接时长，实际为: %+v", params)
+	}
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"net"
+	"os"
+	"strings"
+	"time"
+
+	"golang.org/x/net/netutil"
+)
+
+// unixAddrPrefix 使用 Unix 域套接字时监听地址的前缀，如 unix:///tmp/bookstore.sock
+const unixAddrPrefix = "unix://"
+
+// listen 根据地址创建监听器：unix:// 开头时监听 Unix 域套接字，否则监听 TCP。
+// 返回的 cleanup 在关闭服务后调用，用于删除套接字文件
+func listen(addr string) (lis net.Listener, cleanup func(), err error) {
+	path, isUnix := strings.CutPrefix(addr, unixAddrPrefix)
+	if !isUnix {
+		lis, err = net.Listen("tcp", addr)
+		return lis, func() {}, err
+	}
+
+	// 删除上次异常退出残留的套接字文件，否则监听会失败；路径上是其他文件时不删除，避免地址写错时误删文件
+	info, err := os.Lstat(path)
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+	case err != nil:
+		return nil, nil, err
+	case info.Mode()&fs.ModeSocket == 0:
+		return nil, nil, fmt.Errorf("监听地址 %s 已存在且不是套接字文件", path)
+	default:
+		if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return nil, nil, err
+		}
+	}
+	lis, err = net.Listen("unix", path)
+	if err != nil {
+		return nil, nil, err
+	}
+	return lis, func() { os.Remove(path) }, nil
+}
+
+// listenerLimits 监听器层面的连接限制，在 gRPC 之下生效，用于抵御大量连接和慢速客户端耗尽资源
+type listenerLimits struct {
+	// 同时保持的最大连接数，达到上限后新连接留在内核的等待队列中，直到已有连接关闭，0 表示不限制
+	maxConnections int
+
+	// 每次读取连接的截止时间，客户端超过该时间没有发送任何数据时关闭连接，0 表示不限制
+	readTimeout time.Duration
+
+	// 每次写入连接的截止时间，客户端长时间不读取数据、发送缓冲区写满时关闭连接，0 表示不限制
+	writeTimeout time.Duration
+}
+
+// limitListener 按 limits 包装监听器：限制同时保持的连接数，并为接受的连接设置读写截止时间
+func limitListener(lis net.Listener, limits listenerLimits) net.Listener {
+	if limits.readTimeout > 0 || limits.writeTimeout > 0 {
+		lis = &deadlineListener{Listener: lis, readTimeout: limits.readTimeout, writeTimeout: limits.writeTimeout}
+	}
+	if limits.maxConnections > 0 {
+		lis = netutil.LimitListener(lis, limits.maxConnections)
+	}
+	return lis
+}
+
+// deadlineListener 为接受的每个连接设置读写截止时间
+type deadlineListener struct {
+	net.Listener
+	readTimeout  time.Duration
+	writeTimeout time.Duration
+}
+
+// Accept 接受连接并包装为 deadlineConn
+func (l *deadlineListener) Accept() (net.Conn, error) {
+	conn, err := l.Listener.Accept()
+	if err != nil {
+		return nil, err
+	}
+	return &deadlineConn{Conn: conn, readTimeout: l.readTimeout, writeTimeout: l.writeTimeout}, nil
+}
+
+// deadlineConn 每次读写前重新设置截止时间的连接：截止时间限制的是单次读写，
+// 持续收发数据的连接不受影响，只有长时间没有进展的读写会失败并导致 gRPC 关闭连接
+type deadlineConn struct {
+	net.Conn
+	readTimeout  time.Duration
+	writeTimeout time.Duration
+}
+
+// Read 设置读截止时间后读取
+func (c *deadlineConn) Read(b []byte) (int, error) {
+	if c.readTimeout > 0 {
+		if err := c.Conn.SetReadDeadline(time.Now().Add(c.readTimeout)); err != nil {
+			return 0, err
+		}
+	}
+	return c.Conn.Read(b)
+}
+
+// Write 设置写截止时间后写入
+func (c *deadlineConn) Write(b []byte) (int, error) {
+	if c.writeTimeout > 0 {
+		if err := c.Conn.SetWriteDeadline(time.Now().Add(c.writeTimeout)); err != nil {
+			return 0, err
+		}
+	}
+	return c.Conn.Write(b)
+}
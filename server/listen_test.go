@@ -0,0 +1,175 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	// 导入生成的protobuf代码
+	pb "grpc-basic-server/pb"
+
+	// 导入gRPC相关包
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// TestUnixSocketRoundTrip 测试服务端监听Unix域套接字，客户端完成创建和获取图书
+func TestUnixSocketRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bookstore.sock")
+
+	// 残留的套接字文件不应导致监听失败：关闭监听器但不删除套接字文件，模拟上次异常退出
+	stale, err := net.Listen("unix", path)
+	if err != nil {
+		t.Fatalf("创建残留套接字失败: %v", err)
+	}
+	stale.(*net.UnixListener).SetUnlinkOnClose(false)
+	stale.Close()
+
+	lis, cleanup, err := listen(unixAddrPrefix + path)
+	if err != nil {
+		t.Fatalf("监听Unix域套接字失败: %v", err)
+	}
+	s, _, err := newGRPCServer(mustParseConfig(t))
+	if err != nil {
+		t.Fatalf("创建测试服务器失败: %v", err)
+	}
+	go s.Serve(lis)
+
+	conn, err := grpc.NewClient(unixAddrPrefix+path, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("连接测试服务器失败: %v", err)
+	}
+	defer conn.Close()
+	client := pb.NewBookServiceClient(conn)
+
+	ctx := context.Background()
+	created, err := client.CreateBook(ctx, &pb.CreateBookRequest{
+		Book: &pb.Book{Title: "图书", Author: "作者", Price: proto.Float32(10)},
+	})
+	if err != nil {
+		t.Fatalf("创建图书失败: %v", err)
+	}
+	got, err := client.GetBook(ctx, &pb.GetBookRequest{Id: created.GetId()})
+	if err != nil {
+		t.Fatalf("获取图书失败: %v", err)
+	}
+	if got.GetBook().GetTitle() != "图书" {
+		t.Errorf("期望书名为%q，实际为: %q", "图书", got.GetBook().GetTitle())
+	}
+
+	// 关闭服务后套接字文件应被删除
+	s.Stop()
+	cleanup()
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("期望套接字文件已删除，实际为: %v", err)
+	}
+}
+
+// TestUnixListenKeepsRegularFile 测试 unix:// 地址指向普通文件时返回错误，不删除该文件
+func TestUnixListenKeepsRegularFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.txt")
+	if err := os.WriteFile(path, []byte("不能被删除"), 0o600); err != nil {
+		t.Fatalf("创建文件失败: %v", err)
+	}
+
+	if lis, _, err := listen(unixAddrPrefix + path); err == nil {
+		lis.Close()
+		t.Fatal("期望监听普通文件路径时返回错误")
+	}
+	if data, err := os.ReadFile(path); err != nil || string(data) != "不能被删除" {
+		t.Errorf("普通文件不应被删除或修改: %q, %v", data, err)
+	}
+}
+
+// TestListenerConnectionLimit 测试连接数达到上限后新连接排队，已有连接关闭后才被接受
+func TestListenerConnectionLimit(t *testing.T) {
+	silenceLog(t)
+	tcp, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("监听失败: %v", err)
+	}
+	lis := limitListener(tcp, listenerLimits{maxConnections: 2})
+	s, _, err := newGRPCServer(mustParseConfig(t, "-log-level", "error"))
+	if err != nil {
+		t.Fatalf("创建测试服务器失败: %v", err)
+	}
+	go s.Serve(lis)
+	t.Cleanup(s.Stop)
+
+	// 每个客户端使用独立的连接
+	dial := func() (*grpc.ClientConn, pb.BookServiceClient) {
+		conn, err := grpc.NewClient(tcp.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+		if err != nil {
+			t.Fatalf("连接测试服务器失败: %v", err)
+		}
+		t.Cleanup(func() { conn.Close() })
+		return conn, pb.NewBookServiceClient(conn)
+	}
+	list := func(client pb.BookServiceClient, timeout time.Duration) error {
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+		_, err := client.ListBooks(ctx, &pb.ListBooksRequest{Page: 1, PageSize: 10})
+		return err
+	}
+
+	first, firstClient := dial()
+	_, secondClient := dial()
+	for _, client := range []pb.BookServiceClient{firstClient, secondClient} {
+		if err := list(client, 5*time.Second); err != nil {
+			t.Fatalf("上限内的连接调用失败: %v", err)
+		}
+	}
+
+	// 第三个连接没有被接受，调用一直等到超时
+	_, thirdClient := dial()
+	if err := list(thirdClient, 300*time.Millisecond); status.Code(err) != codes.DeadlineExceeded {
+		t.Fatalf("期望超过上限的连接排队直到超时，实际为: %v", err)
+	}
+
+	// 关闭一个已有连接后，排队的连接被接受
+	first.Close()
+	if err := list(thirdClient, 5*time.Second); err != nil {
+		t.Errorf("已有连接关闭后调用仍然失败: %v", err)
+	}
+}
+
+// TestListenerReadTimeout 测试客户端长时间不发送数据时读取超时
+func TestListenerReadTimeout(t *testing.T) {
+	tcp, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("监听失败: %v", err)
+	}
+	lis := limitListener(tcp, listenerLimits{readTimeout: 50 * time.Millisecond})
+	defer lis.Close()
+
+	client, err := net.Dial("tcp", tcp.Addr().String())
+	if err != nil {
+		t.Fatalf("连接失败: %v", err)
+	}
+	defer client.Close()
+
+	conn, err := lis.Accept()
+	if err != nil {
+		t.Fatalf("接受连接失败: %v", err)
+	}
+	defer conn.Close()
+
+	// 有数据时正常读取，之后没有数据时超时
+	client.Write([]byte("ping"))
+	buf := make([]byte, 4)
+	if _, err := conn.Read(buf); err != nil {
+		t.Fatalf("读取失败: %v", err)
+	}
+	_, err = conn.Read(buf)
+	var netErr net.Error
+	if !errors.As(err, &netErr) || !netErr.Timeout() {
+		t.Errorf("期望读取超时，实际为: %v", err)
+	}
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"strings"
+)
+
+// Logger 服务端使用的日志接口，keysAndValues 为交替出现的键和值，
+// 便于接入宿主程序的日志库或在测试中捕获日志
+type Logger interface {
+	Info(msg string, keysAndValues ...interface{})
+	Warn(msg string, keysAndValues ...interface{})
+	Error(msg string, keysAndValues ...interface{})
+}
+
+// logLevel 日志级别，决定每次调用记录的详细程度
+type logLevel string
+
+const (
+	// logLevelDebug 额外以 JSON 形式记录每次调用的请求和响应内容（经过脱敏）
+	logLevelDebug logLevel = "debug"
+
+	// logLevelInfo 记录每次调用的开始和结束（默认）
+	logLevelInfo logLevel = "info"
+
+	// logLevelWarn 只记录警告和错误，如失败的调用
+	logLevelWarn logLevel = "warn"
+
+	// logLevelError 只记录错误，关闭所有逐次调用的日志
+	logLevelError logLevel = "error"
+)
+
+// logLevelSeverity 各日志级别的严重程度，数值越大记录的内容越少
+var logLevelSeverity = map[logLevel]int{
+	logLevelDebug: 0,
+	logLevelInfo:  1,
+	logLevelWarn:  2,
+	logLevelError: 3,
+}
+
+// parseLogLevel 解析日志级别
+func parseLogLevel(value string) (logLevel, error) {
+	level := logLevel(value)
+	if _, ok := logLevelSeverity[level]; !ok {
+		return "", fmt.Errorf("无效的日志级别: %s（可选 debug、info、warn、error）", value)
+	}
+	return level, nil
+}
+
+// enabled 返回在当前级别下是否记录 target 级别的日志
+func (l logLevel) enabled(target logLevel) bool {
+	return logLevelSeverity[target] >= logLevelSeverity[l]
+}
+
+// leveledLogger 按日志级别过滤 Info 和 Warn，Error 总是记录
+type leveledLogger struct {
+	Logger
+	level logLevel
+}
+
+// newLeveledLogger 返回按 level 过滤的日志实现，info 及以下级别不需要过滤，直接返回 logger
+func newLeveledLogger(logger Logger, level logLevel) Logger {
+	if level.enabled(logLevelInfo) {
+		return logger
+	}
+	return leveledLogger{Logger: logger, level: level}
+}
+
+// Info 记录普通信息
+func (l leveledLogger) Info(msg string, keysAndValues ...interface{}) {
+	if l.level.enabled(logLevelInfo) {
+		l.Logger.Info(msg, keysAndValues...)
+	}
+}
+
+// Warn 记录警告信息
+func (l leveledLogger) Warn(msg string, keysAndValues ...interface{}) {
+	if l.level.enabled(logLevelWarn) {
+		l.Logger.Warn(msg, keysAndValues...)
+	}
+}
+
+// stdLogger 基于标准库 log 包的默认日志实现
+type stdLogger struct{}
+
+// Info 记录普通信息
+func (stdLogger) Info(msg string, keysAndValues ...interface{}) {
+	log.Print("[INFO] " + formatLogLine(msg, keysAndValues))
+}
+
+// Warn 记录警告信息
+func (stdLogger) Warn(msg string, keysAndValues ...interface{}) {
+	log.Print("[WARN] " + formatLogLine(msg, keysAndValues))
+}
+
+// Error 记录错误信息
+func (stdLogger) Error(msg string, keysAndValues ...interface{}) {
+	log.Print("[ERROR] " + formatLogLine(msg, keysAndValues))
+}
+
+// WithLogger 设置服务端使用的日志实现，默认使用标准库 log 包
+func WithLogger(logger Logger) ServerOption {
+	return func(s *BookServer) {
+		s.logger = logger
+	}
+}
+
+// formatLogLine 将消息和键值对格式化为一行，如 "成功创建图书 id=book-1"
+func formatLogLine(msg string, keysAndValues []interface{}) string {
+	var b strings.Builder
+	b.WriteString(msg)
+	for i := 0; i < len(keysAndValues); i += 2 {
+		b.WriteByte(' ')
+		if i+1 < len(keysAndValues) {
+			fmt.Fprintf(&b, "%v=%v", keysAndValues[i], keysAndValues[i+1])
+		} else {
+			// 键值对不完整时只输出值
+			fmt.Fprintf(&b, "%v", keysAndValues[i])
+		}
+	}
+	return b.String()
+}
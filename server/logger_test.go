@@ -0,0 +1,210 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	// 导入生成的protobuf代码
+	pb "grpc-basic-server/pb"
+
+	// 导入gRPC相关包
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+)
+
+// captureLogger 测试用的日志实现，记录所有日志行
+type captureLogger struct {
+	mu    sync.Mutex
+	lines []string
+}
+
+// Info 记录普通信息
+func (l *captureLogger) Info(msg string, keysAndValues ...interface{}) {
+	l.record("INFO", msg, keysAndValues)
+}
+
+// Warn 记录警告信息
+func (l *captureLogger) Warn(msg string, keysAndValues ...interface{}) {
+	l.record("WARN", msg, keysAndValues)
+}
+
+// Error 记录错误信息
+func (l *captureLogger) Error(msg string, keysAndValues ...interface{}) {
+	l.record("ERROR", msg, keysAndValues)
+}
+
+// record 保存一行日志
+func (l *captureLogger) record(level, msg string, keysAndValues []interface{}) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.lines = append(l.lines, fmt.Sprintf("%s %s", level, formatLogLine(msg, keysAndValues)))
+}
+
+// contains 判断是否记录过包含 substr 的日志行
+func (l *captureLogger) contains(substr string) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, line := range l.lines {
+		if strings.Contains(line, substr) {
+			return true
+		}
+	}
+	return false
+}
+
+// TestInjectedLogger 测试注入的日志实现记录 CreateBook 的 Info 日志
+func TestInjectedLogger(t *testing.T) {
+	logger := &captureLogger{}
+	server := NewBookServer(WithLogger(logger))
+
+	resp, err := server.CreateBook(context.Background(), &pb.CreateBookRequest{
+		Book: &pb.Book{Title: "图书", Author: "作者", Price: proto.Float32(10)},
+	})
+	if err != nil {
+		t.Fatalf("创建图书失败: %v", err)
+	}
+
+	if want := "INFO 成功创建图书 id=" + resp.Id; !logger.contains(want) {
+		t.Errorf("期望记录日志 %q，实际为: %v", want, logger.lines)
+	}
+}
+
+// TestInjectedLoggerInterceptor 测试日志拦截器同样使用注入的日志实现
+func TestInjectedLoggerInterceptor(t *testing.T) {
+	logger := &captureLogger{}
+	client, _ := startTestServer(t, mustParseConfig(t), WithLogger(logger))
+
+	if _, err := client.GetBook(context.Background(), &pb.GetBookRequest{Id: "book-999"}); err == nil {
+		t.Fatalf("期望返回错误")
+	}
+	if !logger.contains("WARN RPC调用失败 method=/bookstore.BookService/GetBook") {
+		t.Errorf("期望拦截器记录调用失败日志，实际为: %v", logger.lines)
+	}
+}
+
+// TestFormatLogLine 测试日志行格式化
+func TestFormatLogLine(t *testing.T) {
+	tests := []struct {
+		msg  string
+		kvs  []interface{}
+		want string
+	}{
+		{"消息", nil, "消息"},
+		{"消息", []interface{}{"id", "book-1", "count", 2}, "消息 id=book-1 count=2"},
+		{"消息", []interface{}{"id"}, "消息 id"},
+	}
+	for _, tt := range tests {
+		if got := formatLogLine(tt.msg, tt.kvs); got != tt.want {
+			t.Errorf("期望为%q，实际为: %q", tt.want, got)
+		}
+	}
+}
+
+// TestDebugLevelLogsJSON 测试 debug 级别下日志附加脱敏后的 JSON 请求内容，info 级别下不附加
+func TestDebugLevelLogsJSON(t *testing.T) {
+	req := &pb.CreateBookRequest{Book: &pb.Book{Title: "测试图书", Author: "作者", Price: proto.Float32(10), Description: proto.String("机密描述")}}
+
+	logger := &captureLogger{}
+	client, _ := startTestServer(t, mustParseConfig(t, "-log-level", "debug", "-redact-fields", "book.description"), WithLogger(logger))
+	if _, err := client.CreateBook(context.Background(), req); err != nil {
+		t.Fatalf("创建图书失败: %v", err)
+	}
+	if !logger.contains("request={") || !logger.contains(`"book"`) || !logger.contains(`"测试图书"`) || !logger.contains(redactedValue) {
+		t.Errorf("期望日志中包含JSON格式的请求内容，实际为: %v", logger.lines)
+	}
+	if logger.contains("机密描述") {
+		t.Errorf("日志中不应包含被脱敏的字段值: %v", logger.lines)
+	}
+
+	logger = &captureLogger{}
+	client, _ = startTestServer(t, mustParseConfig(t), WithLogger(logger))
+	if _, err := client.CreateBook(context.Background(), req); err != nil {
+		t.Fatalf("创建图书失败: %v", err)
+	}
+	if logger.contains("request={") {
+		t.Errorf("info 级别不应记录请求内容，实际为: %v", logger.lines)
+	}
+}
+
+// TestLogSampling 测试调用详情的采样：100%时每次调用都记录详情，0%时只记录失败的调用
+func TestLogSampling(t *testing.T) {
+	req := &pb.CreateBookRequest{Book: &pb.Book{Title: "采样图书", Author: "作者", Price: proto.Float32(10)}}
+
+	logger := &captureLogger{}
+	client, _ := startTestServer(t, mustParseConfig(t, "-log-sample-rate", "1"), WithLogger(logger))
+	if _, err := client.CreateBook(context.Background(), req); err != nil {
+		t.Fatalf("创建图书失败: %v", err)
+	}
+	if !logger.contains("调用详情") || !logger.contains(`"采样图书"`) || !logger.contains("response={") {
+		t.Errorf("采样比例为100%%时期望记录调用详情，实际为: %v", logger.lines)
+	}
+
+	logger = &captureLogger{}
+	client, _ = startTestServer(t, mustParseConfig(t, "-log-sample-rate", "0"), WithLogger(logger))
+	if _, err := client.CreateBook(context.Background(), req); err != nil {
+		t.Fatalf("创建图书失败: %v", err)
+	}
+	if logger.contains("调用详情") || logger.contains("request={") {
+		t.Errorf("采样比例为0时不应记录调用详情，实际为: %v", logger.lines)
+	}
+	if !logger.contains("RPC调用成功") {
+		t.Errorf("采样比例为0时仍应记录简要日志，实际为: %v", logger.lines)
+	}
+
+	// 失败的调用不受采样限制
+	if _, err := client.GetBook(context.Background(), &pb.GetBookRequest{Id: "不存在"}); err == nil {
+		t.Fatal("期望获取不存在的图书失败")
+	}
+	if !logger.contains("调用详情") || !logger.contains(`"不存在"`) {
+		t.Errorf("失败的调用应总是记录详情，实际为: %v", logger.lines)
+	}
+
+	if _, err := parseConfig([]string{"-log-sample-rate", "1.5"}); err == nil {
+		t.Error("期望采样比例超出范围时返回错误")
+	}
+}
+
+// TestQuietLogging 测试 -quiet 关闭逐次调用的日志，但保留错误日志
+func TestQuietLogging(t *testing.T) {
+	logger := &captureLogger{}
+	client, bookServer := startTestServer(t, mustParseConfig(t, "-quiet"), WithLogger(logger))
+	if _, err := client.CreateBook(context.Background(), &pb.CreateBookRequest{Book: &pb.Book{Title: "图书", Author: "作者", Price: proto.Float32(10)}}); err != nil {
+		t.Fatalf("创建图书失败: %v", err)
+	}
+	if _, err := client.GetBook(context.Background(), &pb.GetBookRequest{Id: "不存在"}); err == nil {
+		t.Fatal("期望获取不存在的图书失败")
+	}
+	bookServer.logger.Error("存储操作失败")
+
+	if len(logger.lines) != 1 || !logger.contains("存储操作失败") {
+		t.Errorf("期望只记录错误日志，实际为: %v", logger.lines)
+	}
+
+	// warn 级别记录失败的调用，不记录成功的调用
+	logger = &captureLogger{}
+	client, _ = startTestServer(t, mustParseConfig(t, "-log-level", "warn"), WithLogger(logger))
+	if _, err := client.GetBook(context.Background(), &pb.GetBookRequest{Id: "不存在"}); err == nil {
+		t.Fatal("期望获取不存在的图书失败")
+	}
+	if logger.contains("开始处理RPC调用") || !logger.contains("RPC调用失败") {
+		t.Errorf("warn 级别期望只记录失败的调用，实际为: %v", logger.lines)
+	}
+}
+
+// TestLogUserAgent 测试日志拦截器记录客户端的 user-agent
+func TestLogUserAgent(t *testing.T) {
+	logger := &captureLogger{}
+	conn, _ := startTestConnWith(t, mustParseConfig(t), testHook{
+		dialOptions: []grpc.DialOption{grpc.WithUserAgent("books-app/2.3.0")},
+	}, WithLogger(logger))
+
+	if _, err := pb.NewBookServiceClient(conn).ListBooks(context.Background(), &pb.ListBooksRequest{}); err != nil {
+		t.Fatalf("列出图书失败: %v", err)
+	}
+	if want := "user_agent=books-app/2.3.0 grpc-go/"; !logger.contains(want) {
+		t.Errorf("期望日志中包含 %q，实际为: %v", want, logger.lines)
+	}
+}
@@ -2,10 +2,17 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"log"
-	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
 	"sync"
+	"sync/atomic"
+	"syscall"
 	"time"
 
 	// 导入生成的protobuf代码
@@ -14,7 +21,11 @@ import (
 	// 导入gRPC相关包
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
 )
 
 // BookServer 实现图书管理服务
@@ -25,69 +36,235 @@ type BookServer struct {
 	// 互斥锁，用于保护并发访问
 	mu sync.RWMutex
 
-	// 内存中的图书存储（实际项目中应该使用数据库）
-	books map[string]*pb.Book
+	// 默认租户的图书存储，未开启多租户时所有图书都保存在这里
+	bookCatalog
 
-	// 用于生成唯一ID的计数器
-	idCounter int64
+	// 其他租户的图书存储，按租户ID划分
+	tenants map[string]*bookCatalog
+
+	// 所有租户共用的修改通知
+	changes *changeNotifier
+
+	// 用于识别租户的元数据键，为空表示不开启多租户
+	tenantKey string
+
+	// 快照相关状态，使用独立的锁，避免与图书存储互相阻塞
+	snapMu      sync.Mutex
+	snapshots   map[string]*snapshot
+	snapshotTTL time.Duration
+
+	// 日志实现，默认使用标准库 log 包
+	logger Logger
+
+	// 时间来源，默认使用系统时间
+	clock Clock
+
+	// 创建图书时可选字段的默认值
+	defaults bookDefaults
+
+	// 最大响应消息大小，列表响应超过时会被截断
+	maxMessageSize int
+
+	// 批量方法单次请求允许的最大条目数
+	maxBatchSize int
+
+	// 一元查询允许返回的最大图书数量，0 表示不限制
+	maxSearchResults int
+
+	// 读取图书时的校验策略
+	readValidation readValidationPolicy
+
+	// 更新图书时不可修改的字段
+	immutableFields []protoreflect.FieldDescriptor
+
+	// 流式请求允许部分结果时的截止时间余量
+	streamGrace time.Duration
+
+	// 管理令牌，为空表示不开启管理接口
+	adminToken string
+
+	// 是否允许创建图书时使用客户端指定的ID
+	allowClientIDs bool
+
+	// 是否信任 v2 请求中客户端提供的创建时间和修改时间
+	trustClientTimestamps bool
+
+	// 检查存储可用性的实现，由 WithPinger 设置，为 nil 时表示内存存储（总是可用）
+	storePinger pinger
+
+	// 健康检查服务，由 newGRPCServer 注册，状态由 runHealthCheck 维护
+	healthServer *health.Server
+
+	// 正在处理中的请求数量（包括流式请求），由 inFlightInterceptor 和 inFlightStreamInterceptor 维护
+	inFlight atomic.Int64
+
+	// 开始优雅关闭时关闭，通知长时间运行的流（如 StreamPriceHistogram）结束
+	shuttingDown chan struct{}
+	shutdownOnce sync.Once
+
+	// 各方法的请求大小分布，由 requestSizeInterceptor 维护
+	requestSizes requestSizeMetrics
+
+	// 各方法被恢复的 panic 次数，由 recoveryInterceptor 维护
+	panics panicMetrics
+
+	// 各方法的请求数和错误数，由 metricsInterceptor 维护，通过调试 HTTP 接口的 /debug/vars 发布
+	calls callMetrics
+
+	// 与 gRPC 服务器相同的一元拦截器链，由 newGRPCServer 设置，供调试 HTTP 接口使用
+	unaryChain grpc.UnaryServerInterceptor
+
+	// 常用响应消息的对象池，由 WithResponsePool 开启
+	responses responsePools
+
+	// 删除记录的保留策略，所有租户的存储共用
+	tombstonePolicy tombstonePolicy
+
+	// 按租户限制写请求速率，由 WithWriteQuota 设置
+	writeQuotas writeQuotas
+
+	// 随机源，默认随机初始化，WithRandSeed 设置固定种子
+	random *lockedRand
+
+	// 访问日志的输出，默认为标准输出
+	accessLogOutput io.Writer
+
+	// 是否在创建和更新时去除描述中的 HTML 标签
+	sanitizeDescriptions bool
+}
+
+// ServerOption 图书服务器的可选配置
+type ServerOption func(*BookServer)
+
+// WithSnapshotTTL 设置快照的有效期
+func WithSnapshotTTL(ttl time.Duration) ServerOption {
+	return func(s *BookServer) {
+		s.snapshotTTL = ttl
+	}
+}
+
+// WithStreamGrace 设置流式请求允许部分结果时的截止时间余量
+func WithStreamGrace(grace time.Duration) ServerOption {
+	return func(s *BookServer) {
+		s.streamGrace = grace
+	}
+}
+
+// WithTenantKey 开启多租户，按指定元数据键的值隔离图书
+func WithTenantKey(key string) ServerOption {
+	return func(s *BookServer) {
+		s.tenantKey = strings.ToLower(key)
+	}
 }
 
 // NewBookServer 创建新的图书服务器实例
-func NewBookServer() *BookServer {
-	return &BookServer{
-		books: make(map[string]*pb.Book),
+func NewBookServer(opts ...ServerOption) *BookServer {
+	changes := newChangeNotifier()
+	s := &BookServer{
+		bookCatalog: bookCatalog{books: make(map[string]*pb.Book), changes: changes},
+		changes:     changes,
+		tenants:     make(map[string]*bookCatalog),
+		snapshots:   make(map[string]*snapshot),
+		snapshotTTL: defaultSnapshotTTL,
+		streamGrace: defaultStreamGrace,
+		logger:      stdLogger{},
+		clock:       realClock{},
+		random:      newLockedRand(),
+
+		shuttingDown: make(chan struct{}),
+
+		accessLogOutput: os.Stdout,
+
+		readValidation: readValidationOff,
+
+		maxMessageSize: defaultMaxMessageSize,
+		maxBatchSize:   defaultMaxBatchSize,
+
+		maxSearchResults: defaultMaxSearchResults,
+
+		defaults: bookDefaults{currency: defaultCurrency},
+	}
+	s.tombstonePolicy = tombstonePolicy{
+		limit:     defaultTombstoneLimit,
+		retention: defaultTombstoneRetention,
+		now:       func() time.Time { return s.clock.Now() },
+	}
+	s.bookCatalog.policy = &s.tombstonePolicy
+	// 默认的不可修改字段一定存在，解析不会失败
+	s.immutableFields, _ = parseImmutableFields(defaultImmutableFields)
+	for _, opt := range opts {
+		opt(s)
 	}
+	return s
 }
 
-// generateID 生成唯一的图书ID
-func (s *BookServer) generateID() string {
-	s.idCounter++
-	return fmt.Sprintf("book-%d", s.idCounter)
+// loadBooks 批量加载图书到默认租户（用于预置数据、基准测试等），为每本图书分配ID并返回
+func (s *BookServer) loadBooks(books []*pb.Book) []string {
+	// 加写锁保护并发访问
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ids := make([]string, 0, len(books))
+	for _, book := range books {
+		book.Id = s.generateID()
+		book.Currency = resolveCurrency(book.GetCurrency(), s.defaults.currency)
+		s.put(book, s.clock.Now())
+		ids = append(ids, book.Id)
+	}
+	return ids
 }
 
 // CreateBook 创建图书
 func (s *BookServer) CreateBook(ctx context.Context, req *pb.CreateBookRequest) (*pb.CreateBookResponse, error) {
 	// 记录请求日志
-	log.Printf("收到创建图书请求: %v", req.GetBook().GetTitle())
+	s.logger.Info("收到创建图书请求", "title", req.GetBook().GetTitle())
 
 	// 获取请求中的图书信息
 	book := req.GetBook()
 
-	// 验证图书信息
-	if book.GetTitle() == "" {
-		return nil, status.Errorf(codes.InvalidArgument, "图书标题不能为空")
-	}
-	if book.GetAuthor() == "" {
-		return nil, status.Errorf(codes.InvalidArgument, "作者不能为空")
-	}
-	if book.GetPrice() <= 0 {
-		return nil, status.Errorf(codes.InvalidArgument, "图书价格必须大于0")
+	// 验证图书信息，按配置清理描述中的 HTML 标签
+	if err := validateBook(book); err != nil {
+		return nil, err
 	}
+	s.sanitizeBook(book)
 
 	// 加写锁保护并发访问
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	// 生成唯一ID
-	bookID := s.generateID()
+	// 在调用方租户内生成唯一ID，允许时使用客户端指定的ID
+	catalog := s.catalogFor(ctx, true)
+	bookID, err := s.assignID(catalog, book.GetId())
+	if err != nil {
+		s.logger.Warn("图书ID不可用", "id", book.GetId(), "error", err)
+		return nil, err
+	}
 	book.Id = bookID
 
+	// 推荐状态只能通过 SetFeatured 设置
+	book.Featured = false
+	book.FeaturedRank = 0
+
+	// 为未设置的可选字段填充默认值
+	now := s.clock.Now()
+	s.defaults.apply(book, now)
+
 	// 存储图书信息
-	s.books[bookID] = book
+	catalog.put(book, now)
 
-	log.Printf("成功创建图书，ID: %s", bookID)
+	s.logger.Info("成功创建图书", "id", bookID)
 
 	// 返回成功响应
-	return &pb.CreateBookResponse{
-		Id:      bookID,
-		Message: "图书创建成功",
-	}, nil
+	resp := pooled[pb.CreateBookResponse](&s.responses, &s.responses.createBook)
+	resp.Id = bookID
+	resp.Message = "图书创建成功"
+	return resp, nil
 }
 
 // GetBook 获取图书信息
 func (s *BookServer) GetBook(ctx context.Context, req *pb.GetBookRequest) (*pb.GetBookResponse, error) {
 	// 记录请求日志
-	log.Printf("收到获取图书请求，ID: %s", req.GetId())
+	s.logger.Info("收到获取图书请求", "id", req.GetId())
 
 	// 验证请求参数
 	if req.GetId() == "" {
@@ -99,67 +276,110 @@ func (s *BookServer) GetBook(ctx context.Context, req *pb.GetBookRequest) (*pb.G
 	defer s.mu.RUnlock()
 
 	// 查找图书
-	book, exists := s.books[req.GetId()]
-	if !exists {
-		log.Printf("图书未找到，ID: %s", req.GetId())
-		return nil, status.Errorf(codes.NotFound, "图书不存在，ID: %s", req.GetId())
+	catalog := s.catalogFor(ctx, false)
+	book, err := catalog.get(req.GetId())
+	if err != nil {
+		s.logger.Warn("图书未找到", "id", req.GetId())
+		return nil, s.storeErrToStatus(err)
+	}
+	if !s.validForRead(book) {
+		return nil, status.Errorf(codes.DataLoss, "图书数据无效，ID: %s", req.GetId())
+	}
+
+	// 客户端缓存的版本仍是最新时不返回图书内容
+	etag := formatETag(catalog.metaFor(req.GetId()).version)
+	setETagHeader(ctx, s.logger, etag)
+	if notModified(ctx, etag) {
+		s.logger.Info("图书未修改", "id", req.GetId(), "etag", etag)
+		resp := pooled[pb.GetBookResponse](&s.responses, &s.responses.getBook)
+		resp.NotModified = true
+		return resp, nil
 	}
 
-	log.Printf("成功获取图书，ID: %s", req.GetId())
+	s.logger.Info("成功获取图书", "id", req.GetId())
 
 	// 返回图书信息
-	return &pb.GetBookResponse{
-		Book: book,
-	}, nil
+	resp := pooled[pb.GetBookResponse](&s.responses, &s.responses.getBook)
+	resp.Book = book
+	return resp, nil
 }
 
-// UpdateBook 更新图书信息
+// UpdateBook 更新图书信息。检查图书是否存在和保存新内容在同一次写锁内完成，
+// 与并发的 DeleteBook 只有两种结果：更新先完成（随后被删除），或删除先完成、更新返回 NotFound，
+// 不会出现被删除的图书因更新而重新出现或只更新了一部分的情况。
+// 未设置的价格、描述和出版年份保留原值，显式设置的值（包括0和空字符串）按新值验证和保存
 func (s *BookServer) UpdateBook(ctx context.Context, req *pb.UpdateBookRequest) (*pb.UpdateBookResponse, error) {
 	// 记录请求日志
-	log.Printf("收到更新图书请求，ID: %s", req.GetBook().GetId())
+	s.logger.Info("收到更新图书请求", "id", req.GetBook().GetId())
 
 	// 获取要更新的图书信息
 	book := req.GetBook()
 
 	// 验证请求参数
+	if book == nil {
+		return nil, errBookRequired
+	}
 	if book.GetId() == "" {
 		return nil, status.Errorf(codes.InvalidArgument, "图书ID不能为空")
 	}
-	if book.GetTitle() == "" {
-		return nil, status.Errorf(codes.InvalidArgument, "图书标题不能为空")
-	}
-	if book.GetAuthor() == "" {
-		return nil, status.Errorf(codes.InvalidArgument, "作者不能为空")
-	}
-	if book.GetPrice() <= 0 {
-		return nil, status.Errorf(codes.InvalidArgument, "图书价格必须大于0")
-	}
 
 	// 加写锁保护并发访问
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
 	// 检查图书是否存在
-	if _, exists := s.books[book.GetId()]; !exists {
-		log.Printf("图书不存在，无法更新，ID: %s", book.GetId())
-		return nil, status.Errorf(codes.NotFound, "图书不存在，ID: %s", book.GetId())
+	catalog := s.catalogFor(ctx, false)
+	existing, err := catalog.get(book.GetId())
+	if err != nil {
+		s.logger.Warn("图书不存在，无法更新", "id", book.GetId())
+		return nil, s.storeErrToStatus(err)
+	}
+	// 携带 if-match 时，图书在读取后被修改过则拒绝更新，避免覆盖并发的修改
+	if preconditionFailed(ctx, formatETag(catalog.metaFor(book.GetId()).version)) {
+		s.logger.Warn("图书版本不一致，拒绝更新", "id", book.GetId())
+		return nil, s.storeErrToStatus(fmt.Errorf("%w，ID: %s", ErrConflict, book.GetId()))
+	}
+
+	// 未设置的可选字段保留原值，合并后再验证
+	if book.Price == nil {
+		book.Price = proto.Float32(existing.GetPrice())
+	}
+	if book.Description == nil && existing.Description != nil {
+		book.Description = proto.String(existing.GetDescription())
 	}
+	if book.PublishYear == nil && existing.PublishYear != nil {
+		book.PublishYear = proto.Int32(existing.GetPublishYear())
+	}
+	if err := validateBook(book); err != nil {
+		return nil, err
+	}
+	s.sanitizeBook(book)
+	if err := s.checkImmutableFields(existing, book); err != nil {
+		s.logger.Warn("试图修改不可修改的字段", "id", book.GetId(), "error", err)
+		return nil, err
+	}
+
+	// 保留原有的推荐状态和库存，它们只能通过专门的RPC修改；未指定币种时保留原有币种
+	book.Featured = existing.GetFeatured()
+	book.FeaturedRank = existing.GetFeaturedRank()
+	book.Stock = existing.GetStock()
+	book.Currency = resolveCurrency(book.GetCurrency(), existing.GetCurrency())
 
 	// 更新图书信息
-	s.books[book.GetId()] = book
+	catalog.put(book, s.clock.Now())
 
-	log.Printf("成功更新图书，ID: %s", book.GetId())
+	s.logger.Info("成功更新图书", "id", book.GetId())
 
 	// 返回成功响应
-	return &pb.UpdateBookResponse{
-		Message: "图书更新成功",
-	}, nil
+	resp := pooled[pb.UpdateBookResponse](&s.responses, &s.responses.updateBook)
+	resp.Message = "图书更新成功"
+	return resp, nil
 }
 
-// DeleteBook 删除图书
+// DeleteBook 删除图书，图书未确认的预留一并删除；与并发修改的语义见 UpdateBook
 func (s *BookServer) DeleteBook(ctx context.Context, req *pb.DeleteBookRequest) (*pb.DeleteBookResponse, error) {
 	// 记录请求日志
-	log.Printf("收到删除图书请求，ID: %s", req.GetId())
+	s.logger.Info("收到删除图书请求", "id", req.GetId())
 
 	// 验证请求参数
 	if req.GetId() == "" {
@@ -171,26 +391,35 @@ func (s *BookServer) DeleteBook(ctx context.Context, req *pb.DeleteBookRequest)
 	defer s.mu.Unlock()
 
 	// 检查图书是否存在
-	if _, exists := s.books[req.GetId()]; !exists {
-		log.Printf("图书不存在，无法删除，ID: %s", req.GetId())
-		return nil, status.Errorf(codes.NotFound, "图书不存在，ID: %s", req.GetId())
+	catalog := s.catalogFor(ctx, false)
+	if _, err := catalog.get(req.GetId()); err != nil {
+		// 幂等删除：图书已不存在时视为成功，便于重试和清理脚本
+		if errors.Is(err, ErrNotFound) && req.GetIgnoreNotFound() {
+			s.logger.Info("图书不存在，忽略删除", "id", req.GetId())
+			resp := pooled[pb.DeleteBookResponse](&s.responses, &s.responses.deleteBook)
+			resp.Message = "图书不存在，无需删除"
+			return resp, nil
+		}
+		s.logger.Warn("图书不存在，无法删除", "id", req.GetId())
+		return nil, s.storeErrToStatus(err)
 	}
 
 	// 删除图书
-	delete(s.books, req.GetId())
+	catalog.remove(req.GetId())
 
-	log.Printf("成功删除图书，ID: %s", req.GetId())
+	s.logger.Info("成功删除图书", "id", req.GetId())
 
 	// 返回成功响应
-	return &pb.DeleteBookResponse{
-		Message: "图书删除成功",
-	}, nil
+	resp := pooled[pb.DeleteBookResponse](&s.responses, &s.responses.deleteBook)
+	resp.Message = "图书删除成功"
+	resp.Deleted = true
+	return resp, nil
 }
 
 // ListBooks 列出所有图书（支持分页）
 func (s *BookServer) ListBooks(ctx context.Context, req *pb.ListBooksRequest) (*pb.ListBooksResponse, error) {
 	// 记录请求日志
-	log.Printf("收到列出图书请求，页码: %d, 每页大小: %d", req.GetPage(), req.GetPageSize())
+	s.logger.Info("收到列出图书请求", "page", req.GetPage(), "page_size", req.GetPageSize())
 
 	// 设置默认分页参数
 	page := req.GetPage()
@@ -206,40 +435,57 @@ func (s *BookServer) ListBooks(ctx context.Context, req *pb.ListBooksRequest) (*
 		pageSize = 100 // 限制最大页面大小
 	}
 
-	// 加读锁保护并发访问
-	s.mu.RLock()
-	defer s.mu.RUnlock()
+	// 获取待分页的图书：指定快照时使用快照，否则使用当前存储
+	allBooks, err := s.booksForRead(ctx, req.GetSnapshotToken())
+	if err != nil {
+		return nil, err
+	}
 
 	// 计算总数量
-	total := int32(len(s.books))
+	total := int32(len(allBooks))
 
-	// 计算分页参数
-	start := (page - 1) * pageSize
-	end := start + pageSize
+	// 计算分页参数（使用 int64 避免页码过大时溢出）
+	start := int64(page-1) * int64(pageSize)
+	end := start + int64(pageSize)
+	if start > int64(total) {
+		start = int64(total)
+	}
+	if end > int64(total) {
+		end = int64(total)
+	}
 
-	// 收集图书列表
-	var books []*pb.Book
-	count := int32(0)
-	for _, book := range s.books {
-		if count >= start && count < end {
-			books = append(books, book)
+	// 截取当前页的图书列表
+	books := allBooks[start:end]
+	recordScan(ctx, len(allBooks), len(books))
+
+	// 响应超过消息大小上限时截断当前页，而不是让客户端收到难以理解的错误
+	truncated := false
+	if fit := fitBooks(books, s.maxMessageSize); fit < len(books) {
+		if fit == 0 {
+			return nil, status.Errorf(codes.ResourceExhausted, "图书过大，无法在单个响应中返回，ID: %s", books[0].GetId())
 		}
-		count++
+		s.logger.Warn("响应超过消息大小上限，截断当前页", "books", fit)
+		books = books[:fit]
+		pageSize = int32(fit)
+		truncated = true
 	}
 
-	log.Printf("成功列出图书，总数: %d, 当前页: %d", total, page)
+	s.logger.Info("成功列出图书", "total", total, "page", page)
 
 	// 返回图书列表
 	return &pb.ListBooksResponse{
-		Books: books,
-		Total: total,
+		Books:     books,
+		Total:     total,
+		Truncated: truncated,
+		PageSize:  pageSize,
 	}, nil
 }
 
-// SearchBooksByPrice 按价格区间查询图书
+// SearchBooksByPrice 按价格区间查询图书，区间两端都包含在内：
+// 最低价格和最高价格相等时返回价格恰好等于该值的图书
 func (s *BookServer) SearchBooksByPrice(ctx context.Context, req *pb.SearchBooksByPriceRequest) (*pb.SearchBooksByPriceResponse, error) {
 	// 记录请求日志
-	log.Printf("收到按价格查询图书请求，价格区间: %.2f - %.2f", req.GetMinPrice(), req.GetMaxPrice())
+	s.logger.Info("收到按价格查询图书请求", "min_price", req.GetMinPrice(), "max_price", req.GetMaxPrice())
 
 	// 验证价格参数
 	minPrice := req.GetMinPrice()
@@ -251,21 +497,52 @@ func (s *BookServer) SearchBooksByPrice(ctx context.Context, req *pb.SearchBooks
 	if maxPrice < minPrice {
 		return nil, status.Errorf(codes.InvalidArgument, "最高价格不能小于最低价格")
 	}
+	if !isKnownCurrency(req.GetCurrency()) {
+		return nil, status.Errorf(codes.InvalidArgument, "不支持的币种: %s", req.GetCurrency())
+	}
+	currency := normalizeCurrency(req.GetCurrency())
+
+	// 查找价格符合条件的图书：指定快照时遍历快照，否则通过当前存储的价格索引查找
+	var inRange []*pb.Book
+	var scanned int
+	if token := req.GetSnapshotToken(); token != "" {
+		allBooks, err := s.booksForRead(ctx, token)
+		if err != nil {
+			return nil, err
+		}
+		for _, book := range allBooks {
+			if price := book.GetPrice(); price >= minPrice && price <= maxPrice {
+				inRange = append(inRange, book)
+			}
+		}
+		scanned = len(allBooks)
+	} else {
+		s.mu.RLock()
+		inRange = s.catalogFor(ctx, false).booksInPriceRange(minPrice, maxPrice)
+		s.mu.RUnlock()
+
+		// 通过索引只需遍历价格区间内的图书；结果与遍历时一样按ID排序
+		scanned = len(inRange)
+		sortBooksByID(inRange)
+		inRange = s.filterForRead(inRange)
+	}
 
-	// 加读锁保护并发访问
-	s.mu.RLock()
-	defer s.mu.RUnlock()
-
-	// 查找符合条件的图书
 	var books []*pb.Book
-	for _, book := range s.books {
-		price := book.GetPrice()
-		if price >= minPrice && price <= maxPrice {
+	for _, book := range inRange {
+		if currency == "" || book.GetCurrency() == currency {
 			books = append(books, book)
 		}
 	}
 
-	log.Printf("按价格查询完成，找到 %d 本图书", len(books))
+	recordScan(ctx, scanned, len(books))
+
+	// 结果过多时要求改用流式方法
+	if err := s.checkSearchResults(len(books)); err != nil {
+		s.logger.Warn("按价格查询结果过多", "count", len(books))
+		return nil, err
+	}
+
+	s.logger.Info("按价格查询完成", "count", len(books))
 
 	// 返回查询结果
 	return &pb.SearchBooksByPriceResponse{
@@ -273,45 +550,152 @@ func (s *BookServer) SearchBooksByPrice(ctx context.Context, req *pb.SearchBooks
 	}, nil
 }
 
-// 日志拦截器 - 记录所有RPC调用的日志
-func logInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
-	start := time.Now()
+// logInterceptorOptions 日志拦截器的配置
+type logInterceptorOptions struct {
+	// level 为 debug 时在调用的开始和成功日志中附加 JSON 格式的请求和响应内容
+	level logLevel
 
-	// 记录请求开始
-	log.Printf("开始处理RPC调用: %s", info.FullMethod)
+	// logPayloads 为 true 时同时记录请求和响应内容
+	logPayloads bool
 
-	// 调用实际的处理器
-	resp, err := handler(ctx, req)
+	// 记录的请求和响应内容都会先经过 redactor 脱敏
+	redactor *fieldRedactor
 
-	// 记录请求结束和耗时
-	duration := time.Since(start)
-	if err != nil {
-		log.Printf("RPC调用失败: %s, 耗时: %v, 错误: %v", info.FullMethod, duration, err)
-	} else {
-		log.Printf("RPC调用成功: %s, 耗时: %v", info.FullMethod, duration)
+	// trailers 为 true 时在响应尾部附加服务端版本、请求ID和处理耗时
+	trailers bool
+
+	// sampleRate 记录调用详情的采样比例（0~1），失败的调用总是记录详情
+	sampleRate float64
+
+	// random 采样使用的随机源，为 nil 时使用全局随机源
+	random *lockedRand
+
+	// slowQueryThreshold 查询类调用的耗时达到该值时记录扫描详情，0 表示不记录
+	slowQueryThreshold time.Duration
+}
+
+// newLogInterceptor 创建日志拦截器 - 记录所有RPC调用的日志
+func newLogInterceptor(logger Logger, opts logInterceptorOptions) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		start := time.Now()
+		reqID := requestID(ctx)
+		sampled := opts.sampleRate > 0 && randFloat64(opts.random) < opts.sampleRate
+		var scan *scanStats
+		if opts.slowQueryThreshold > 0 {
+			ctx, scan = withScanStats(ctx)
+		}
+
+		// 记录请求开始
+		fields := []interface{}{"method", info.FullMethod, "request_id", reqID, "peer", peerAddr(ctx), "user_agent", userAgent(ctx)}
+		if opts.level == logLevelDebug {
+			fields = appendJSONField(fields, "request", req, opts.redactor)
+		}
+		logger.Info("开始处理RPC调用", fields...)
+		if opts.logPayloads {
+			if msg, ok := req.(proto.Message); ok {
+				logger.Info("请求内容", "method", info.FullMethod, "request", opts.redactor.redact(msg))
+			}
+		}
+
+		// 调用实际的处理器
+		resp, err := handler(ctx, req)
+
+		// 记录请求结束和耗时
+		duration := time.Since(start)
+		if opts.trailers {
+			setDebugTrailer(ctx, logger, reqID, duration)
+		}
+		logSlowQuery(logger, opts, info.FullMethod, reqID, req, duration, scan)
+		if err != nil {
+			logger.Warn("RPC调用失败", "method", info.FullMethod, "request_id", reqID, "duration", duration, "error", err)
+
+			// 失败的调用不受采样限制，总是记录详情
+			details := []interface{}{"method", info.FullMethod, "request_id", reqID, "duration", duration}
+			details = appendJSONField(details, "request", req, opts.redactor)
+			logger.Warn("调用详情", append(details, "error", err)...)
+		} else {
+			fields := []interface{}{"method", info.FullMethod, "request_id", reqID, "duration", duration}
+			if opts.level == logLevelDebug {
+				fields = appendJSONField(fields, "response", resp, opts.redactor)
+			}
+			logger.Info("RPC调用成功", fields...)
+			if opts.logPayloads {
+				if msg, ok := resp.(proto.Message); ok {
+					logger.Info("响应内容", "method", info.FullMethod, "response", opts.redactor.redact(msg))
+				}
+			}
+			if sampled {
+				details := []interface{}{"method", info.FullMethod, "request_id", reqID, "duration", duration}
+				details = appendJSONField(details, "request", req, opts.redactor)
+				details = appendJSONField(details, "response", resp, opts.redactor)
+				logger.Info("调用详情", details...)
+			}
+		}
+
+		return resp, err
 	}
+}
 
-	return resp, err
+// appendJSONField 将脱敏后的消息以 JSON 字符串的形式追加为日志字段，非 protobuf 消息或序列化失败时忽略
+func appendJSONField(fields []interface{}, key string, v interface{}, redactor *fieldRedactor) []interface{} {
+	msg, ok := v.(proto.Message)
+	if !ok {
+		return fields
+	}
+	data, err := protojson.Marshal(redactor.redact(msg))
+	if err != nil {
+		return fields
+	}
+	return append(fields, key, string(data))
 }
 
 func main() {
-	// 设置监听地址和端口
-	lis, err := net.Listen("tcp", ":50051")
+	// 解析命令行参数
+	cfg, err := parseConfig(os.Args[1:])
+	if err != nil {
+		log.Fatalf("解析参数失败: %v", err)
+	}
+
+	// 收到 SIGINT/SIGTERM 时取消 ctx，触发优雅关闭
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// 设置监听地址：TCP 地址或 unix:// 开头的 Unix 域套接字
+	lis, cleanup, err := listen(cfg.addr)
 	if err != nil {
 		log.Fatalf("启动监听失败: %v", err)
 	}
+	defer cleanup()
 
-	// 创建gRPC服务器，添加日志拦截器
-	s := grpc.NewServer(
-		grpc.UnaryInterceptor(logInterceptor),
-	)
+	// 在 gRPC 之下限制连接数和慢速客户端
+	lis = limitListener(lis, cfg.listenerLimits)
 
-	// 注册图书服务
-	bookServer := NewBookServer()
-	pb.RegisterBookServiceServer(s, bookServer)
+	// 创建gRPC服务器并注册图书服务
+	s, bookServer, err := newGRPCServer(cfg)
+	if err != nil {
+		log.Fatalf("创建服务失败: %v", err)
+	}
+
+	// 启动过期快照和过期预留的后台回收
+	go bookServer.runSnapshotJanitor(ctx, cfg.snapshotTTL)
+	go bookServer.runReservationJanitor(ctx, reservationJanitorInterval)
+
+	// 需要预热时先预热存储，成功后再启动存储可用性检查，维护健康检查状态；预热失败则退出
+	go func() {
+		if cfg.warmup {
+			if err := runWarmup(ctx, bookServer.logger, bookServer.clock, bookServer, cfg.warmupAttempts, warmupInitialBackoff); err != nil {
+				if ctx.Err() == nil {
+					log.Printf("存储预热失败，服务退出: %v", err)
+					stop()
+				}
+				return
+			}
+		}
+		runHealthCheck(ctx, bookServer.logger, bookServer.clock, bookServer.healthServer, bookServer.healthPinger(), cfg.healthInterval)
+	}()
 
 	// 打印启动信息
-	log.Printf("图书管理服务启动成功，监听地址: %v", lis.Addr())
+	log.Printf("图书管理服务启动成功，版本: %s, 监听地址: %v", serverVersion, lis.Addr())
 	log.Printf("服务提供以下功能:")
 	log.Printf("- 创建图书 (CreateBook)")
 	log.Printf("- 获取图书 (GetBook)")
@@ -319,9 +703,51 @@ func main() {
 	log.Printf("- 删除图书 (DeleteBook)")
 	log.Printf("- 列出图书 (ListBooks)")
 	log.Printf("- 按价格查询 (SearchBooksByPrice)")
+	log.Printf("- 按多个价格区间查询 (SearchBooksByPriceRanges)")
+	log.Printf("- 按多个标题查询 (GetBooksByTitles)")
+	log.Printf("- 价格统计 (GetPriceStats)")
+	log.Printf("- 流式价格分布 (StreamPriceHistogram)")
+	log.Printf("- 打开快照 (OpenSnapshot)")
+	log.Printf("- 运行状态 (GetStats)")
+	log.Printf("- 批量调价 (AdjustPrices)")
+	log.Printf("- 重命名作者 (RenameAuthor)")
+	log.Printf("- 全量替换图书目录 (ReplaceCatalog)")
+	log.Printf("- 查找重复图书 (FindDuplicates)")
+	log.Printf("- 随机获取图书 (GetRandomBook)")
+	log.Printf("- 推荐图书 (SetFeatured/UnsetFeatured/ListFeaturedBooks)")
+	log.Printf("- 库存管理 (PurchaseBook/RestockBook)")
+	log.Printf("- 库存预留 (ReserveBook/ConfirmReservation/CancelReservation)")
+	log.Printf("- 流式获取图书 (StreamBooks)")
+	log.Printf("- 流式导出图书 (StreamExport)")
+	log.Printf("- 流式批量获取图书 (GetBooksBatchStream)")
+	log.Printf("- v2 图书服务 (BookServiceV2: 标签、时间戳、版本号)")
+	if cfg.readOnly {
+		log.Printf("只读模式已开启，修改类方法将被拒绝")
+	}
 
-	// 启动服务器
-	if err := s.Serve(lis); err != nil {
-		log.Fatalf("服务启动失败: %v", err)
+	// 启动调试 HTTP 接口
+	if cfg.debugHTTP != "" {
+		debugServer := &http.Server{Addr: cfg.debugHTTP, Handler: newDebugHTTPHandler(bookServer), ReadHeaderTimeout: 5 * time.Second}
+		go func() {
+			if err := debugServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+				log.Printf("调试 HTTP 接口启动失败: %v", err)
+			}
+		}()
+		defer debugServer.Close()
+		log.Printf("调试 HTTP 接口已开启，监听地址: %s，仅用于本地调试", cfg.debugHTTP)
 	}
+
+	// 启动服务器
+	go func() {
+		if err := s.Serve(lis); err != nil {
+			log.Fatalf("服务启动失败: %v", err)
+		}
+	}()
+
+	// 等待退出信号后优雅关闭
+	<-ctx.Done()
+	log.Printf("收到退出信号，开始优雅关闭")
+	bookServer.healthServer.Shutdown()
+	gracefulShutdown(s, bookServer, cfg.shutdownTimeout, time.Second)
+	log.Printf("服务已关闭")
 }
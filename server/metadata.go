@@ -0,0 +1,93 @@
+package main
+
+import (
+	"context"
+	"strings"
+
+	// 导入gRPC相关包
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// healthServicePrefix 健康检查服务的方法前缀，不要求携带元数据
+const healthServicePrefix = "/grpc.health.v1.Health/"
+
+// metadataValuesKey 在 context 中保存已提取元数据的键
+type metadataValuesKey struct{}
+
+// newRequiredMetadataInterceptor 创建必需元数据拦截器：
+// 缺少任一必需键的请求返回 InvalidArgument，提取到的值存入 context 供处理器读取
+func newRequiredMetadataInterceptor(keys []string) grpc.UnaryServerInterceptor {
+	required := lowerKeys(keys)
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, err := withRequiredMetadata(ctx, info.FullMethod, required)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// newRequiredMetadataStreamInterceptor 创建流式方法的必需元数据拦截器，规则与一元方法相同
+func newRequiredMetadataStreamInterceptor(keys []string) grpc.StreamServerInterceptor {
+	required := lowerKeys(keys)
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := withRequiredMetadata(ss.Context(), info.FullMethod, required)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &contextServerStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// withRequiredMetadata 检查必需元数据，并返回保存了提取值的 context
+func withRequiredMetadata(ctx context.Context, fullMethod string, required []string) (context.Context, error) {
+	if len(required) == 0 || strings.HasPrefix(fullMethod, healthServicePrefix) {
+		return ctx, nil
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := make(map[string]string, len(required))
+	for _, key := range required {
+		vals := md.Get(key)
+		if len(vals) == 0 || vals[0] == "" {
+			return nil, status.Errorf(codes.InvalidArgument, "缺少必需的元数据: %s", key)
+		}
+		values[key] = vals[0]
+	}
+	return context.WithValue(ctx, metadataValuesKey{}, values), nil
+}
+
+// lowerKeys 将元数据键统一为小写
+func lowerKeys(keys []string) []string {
+	lowered := make([]string, 0, len(keys))
+	for _, key := range keys {
+		lowered = append(lowered, strings.ToLower(key))
+	}
+	return lowered
+}
+
+// contextServerStream 替换 Context() 返回值的服务端流，用于在流式拦截器中传递 context
+type contextServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+// Context 返回替换后的 context
+func (s *contextServerStream) Context() context.Context {
+	return s.ctx
+}
+
+// metadataValue 读取必需元数据拦截器提取的值，不存在时返回空字符串
+func metadataValue(ctx context.Context, key string) string {
+	values, _ := ctx.Value(metadataValuesKey{}).(map[string]string)
+	return values[strings.ToLower(key)]
+}
+
+// userAgent 返回调用方的 user-agent（客户端名称和版本，gRPC 会在其后追加 grpc-go 等版本），未携带时返回空字符串
+func userAgent(ctx context.Context) string {
+	md, _ := metadata.FromIncomingContext(ctx)
+	return strings.Join(md.Get("user-agent"), " ")
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	// 导入生成的protobuf代码
+	pb "grpc-basic-server/pb"
+
+	// 导入gRPC相关包
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// TestRequiredMetadata 测试缺少必需元数据的请求被拒绝
+func TestRequiredMetadata(t *testing.T) {
+	client, _ := startTestServer(t, mustParseConfig(t, "-required-metadata", "x-tenant-id"))
+
+	// 不携带 x-tenant-id 的请求应被拒绝
+	_, err := client.ListBooks(context.Background(), &pb.ListBooksRequest{})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("期望错误码为InvalidArgument，实际为: %v", status.Code(err))
+	}
+
+	// 携带 x-tenant-id 的请求应成功
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "x-tenant-id", "tenant-a")
+	if _, err := client.ListBooks(ctx, &pb.ListBooksRequest{}); err != nil {
+		t.Errorf("携带必需元数据的请求应成功: %v", err)
+	}
+}
+
+// TestMetadataValue 测试处理器可以读取拦截器提取的元数据
+func TestMetadataValue(t *testing.T) {
+	interceptor := newRequiredMetadataInterceptor([]string{"X-Tenant-ID"})
+	info := &grpc.UnaryServerInfo{FullMethod: "/bookstore.BookService/ListBooks"}
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-tenant-id", "tenant-a"))
+	var got string
+	_, err := interceptor(ctx, nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		got = metadataValue(ctx, "x-tenant-id")
+		return nil, nil
+	})
+	if err != nil {
+		t.Fatalf("调用失败: %v", err)
+	}
+	if got != "tenant-a" {
+		t.Errorf("期望读取到 tenant-a，实际为: %q", got)
+	}
+
+	// 健康检查不要求携带元数据
+	healthInfo := &grpc.UnaryServerInfo{FullMethod: "/grpc.health.v1.Health/Check"}
+	_, err = interceptor(context.Background(), nil, healthInfo, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, nil
+	})
+	if err != nil {
+		t.Errorf("健康检查不应被拒绝: %v", err)
+	}
+}
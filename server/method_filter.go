@@ -0,0 +1,79 @@
+package main
+
+import (
+	"context"
+
+	// 导入生成的protobuf代码
+	pb "grpc-basic-server/pb"
+	pbv2 "grpc-basic-server/pb/v2"
+
+	// 导入gRPC相关包
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// mutatingMethodPrefixes 只读模式下需要拒绝的方法名前缀
+var mutatingMethodPrefixes = []string{"Create", "Update", "Delete", "Patch", "Batch", "Adjust", "Set", "Unset", "Purchase", "Restock", "Reserve", "Confirm", "Cancel", "Add", "Remove", "Rename", "Replace"}
+
+// mutatingMethods 返回图书服务（v1 和 v2）中所有修改类方法的完整方法名，包括流式方法（如 ReplaceCatalog）
+func mutatingMethods() []string {
+	var methods []string
+	for _, desc := range []grpc.ServiceDesc{pb.BookService_ServiceDesc, pbv2.BookServiceV2_ServiceDesc} {
+		names := make([]string, 0, len(desc.Methods)+len(desc.Streams))
+		for _, method := range desc.Methods {
+			names = append(names, method.MethodName)
+		}
+		for _, stream := range desc.Streams {
+			names = append(names, stream.StreamName)
+		}
+		for _, name := range names {
+			if fullMethod := "/" + desc.ServiceName + "/" + name; isMutatingMethod(fullMethod) {
+				methods = append(methods, fullMethod)
+			}
+		}
+	}
+	return methods
+}
+
+// newMethodFilterInterceptor 创建方法访问控制拦截器
+// allow 非空时只允许列表中的方法；deny 中的方法总是被拒绝
+func newMethodFilterInterceptor(allow, deny []string) grpc.UnaryServerInterceptor {
+	check := newMethodFilter(allow, deny)
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := check(info.FullMethod); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// newMethodFilterStreamInterceptor 创建流式方法的访问控制拦截器，规则与一元方法相同
+func newMethodFilterStreamInterceptor(allow, deny []string) grpc.StreamServerInterceptor {
+	check := newMethodFilter(allow, deny)
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := check(info.FullMethod); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
+
+// newMethodFilter 返回检查方法是否允许调用的函数，被禁用时返回 PermissionDenied
+func newMethodFilter(allow, deny []string) func(fullMethod string) error {
+	allowed := make(map[string]bool, len(allow))
+	for _, method := range allow {
+		allowed[method] = true
+	}
+	denied := make(map[string]bool, len(deny))
+	for _, method := range deny {
+		denied[method] = true
+	}
+
+	return func(fullMethod string) error {
+		if denied[fullMethod] || (len(allowed) > 0 && !allowed[fullMethod]) {
+			return status.Errorf(codes.PermissionDenied, "方法已被禁用: %s", fullMethod)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	// 导入生成的protobuf代码
+	pb "grpc-basic-server/pb"
+
+	// 导入gRPC相关包
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// TestReadOnlyMode 测试只读模式拒绝修改类方法但允许查询
+func TestReadOnlyMode(t *testing.T) {
+	client, server := startTestServer(t, mustParseConfig(t, "-readonly"))
+
+	// 直接在存储中放入一本图书，供只读查询使用
+	resp, err := server.CreateBook(context.Background(), &pb.CreateBookRequest{
+		Book: &pb.Book{Title: "测试图书", Author: "测试作者", Price: proto.Float32(29.99)},
+	})
+	if err != nil {
+		t.Fatalf("创建图书失败: %v", err)
+	}
+
+	// 通过gRPC创建图书应被拒绝
+	_, err = client.CreateBook(context.Background(), &pb.CreateBookRequest{
+		Book: &pb.Book{Title: "新图书", Author: "作者", Price: proto.Float32(10)},
+	})
+	if status.Code(err) != codes.PermissionDenied {
+		t.Errorf("期望错误码为PermissionDenied，实际为: %v", status.Code(err))
+	}
+
+	// 查询图书应正常返回
+	getResp, err := client.GetBook(context.Background(), &pb.GetBookRequest{Id: resp.Id})
+	if err != nil {
+		t.Fatalf("获取图书失败: %v", err)
+	}
+	if getResp.Book.Title != "测试图书" {
+		t.Errorf("图书标题不匹配，实际为: %s", getResp.Book.Title)
+	}
+}
+
+// TestMethodAllowList 测试白名单之外的方法被拒绝
+func TestMethodAllowList(t *testing.T) {
+	client, _ := startTestServer(t, mustParseConfig(t, "-allow-methods", "/bookstore.BookService/ListBooks"))
+
+	if _, err := client.ListBooks(context.Background(), &pb.ListBooksRequest{}); err != nil {
+		t.Errorf("白名单中的方法应允许调用: %v", err)
+	}
+
+	_, err := client.GetBook(context.Background(), &pb.GetBookRequest{Id: "book-1"})
+	if status.Code(err) != codes.PermissionDenied {
+		t.Errorf("期望错误码为PermissionDenied，实际为: %v", status.Code(err))
+	}
+}
+
+// TestReadOnlyReplaceCatalog 测试只读模式同样拒绝修改类的流式方法：空目录的替换请求不能删除已有图书
+func TestReadOnlyReplaceCatalog(t *testing.T) {
+	client, server := startTestServer(t, mustParseConfig(t, "-readonly"))
+
+	resp, err := server.CreateBook(context.Background(), &pb.CreateBookRequest{
+		Book: &pb.Book{Title: "测试图书", Author: "测试作者", Price: proto.Float32(29.99)},
+	})
+	if err != nil {
+		t.Fatalf("创建图书失败: %v", err)
+	}
+
+	if _, err := replaceCatalog(t, client, nil); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("期望错误码为PermissionDenied，实际为: %v", status.Code(err))
+	}
+	if _, err := client.GetBook(context.Background(), &pb.GetBookRequest{Id: resp.Id}); err != nil {
+		t.Errorf("只读模式下图书不应被删除: %v", err)
+	}
+}
@@ -0,0 +1,837 @@
+// 定义protobuf语法版本
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v3.19.3
+// source: protos/bookstore_v2.proto
+
+// 定义包名，v2 与 v1 使用不同的包，两个版本的服务可以同时注册
+
+package bookstorev2
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// 图书信息消息定义，在 v1 Book 的基础上增加标签、时间戳和版本号
+// 字段编号与 v1 保持一致，v1 客户端看到的仍是原来的字段
+type Book struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`                                          // 图书唯一标识符
+	Title         string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`                                    // 图书标题
+	Author        string                 `protobuf:"bytes,3,opt,name=author,proto3" json:"author,omitempty"`                                  // 作者
+	Price         float32                `protobuf:"fixed32,4,opt,name=price,proto3" json:"price,omitempty"`                                  // 价格
+	Description   string                 `protobuf:"bytes,5,opt,name=description,proto3" json:"description,omitempty"`                        // 图书描述
+	PublishYear   int32                  `protobuf:"varint,6,opt,name=publish_year,json=publishYear,proto3" json:"publish_year,omitempty"`    // 出版年份
+	Featured      bool                   `protobuf:"varint,7,opt,name=featured,proto3" json:"featured,omitempty"`                             // 是否为推荐图书，只读
+	FeaturedRank  int32                  `protobuf:"varint,8,opt,name=featured_rank,json=featuredRank,proto3" json:"featured_rank,omitempty"` // 推荐排序，只读
+	Stock         int32                  `protobuf:"varint,9,opt,name=stock,proto3" json:"stock,omitempty"`                                   // 库存数量，只读
+	Tags          []string               `protobuf:"bytes,10,rep,name=tags,proto3" json:"tags,omitempty"`                                     // 标签
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`          // 创建时间，由服务端设置
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`          // 最后修改时间，由服务端设置
+	Version       int64                  `protobuf:"varint,13,opt,name=version,proto3" json:"version,omitempty"`                              // 版本号，每次修改后递增
+	Isbn          string                 `protobuf:"bytes,14,opt,name=isbn,proto3" json:"isbn,omitempty"`                                     // ISBN，设置后不可修改
+	Currency      string                 `protobuf:"bytes,15,opt,name=currency,proto3" json:"currency,omitempty"`                             // 价格的币种，ISO 4217 代码
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Book) Reset() {
+	*x = Book{}
+	mi := &file_protos_bookstore_v2_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Book) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Book) ProtoMessage() {}
+
+func (x *Book) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_bookstore_v2_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Book.ProtoReflect.Descriptor instead.
+func (*Book) Descriptor() ([]byte, []int) {
+	return file_protos_bookstore_v2_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Book) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Book) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *Book) GetAuthor() string {
+	if x != nil {
+		return x.Author
+	}
+	return ""
+}
+
+func (x *Book) GetPrice() float32 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *Book) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Book) GetPublishYear() int32 {
+	if x != nil {
+		return x.PublishYear
+	}
+	return 0
+}
+
+func (x *Book) GetFeatured() bool {
+	if x != nil {
+		return x.Featured
+	}
+	return false
+}
+
+func (x *Book) GetFeaturedRank() int32 {
+	if x != nil {
+		return x.FeaturedRank
+	}
+	return 0
+}
+
+func (x *Book) GetStock() int32 {
+	if x != nil {
+		return x.Stock
+	}
+	return 0
+}
+
+func (x *Book) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *Book) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Book) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *Book) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *Book) GetIsbn() string {
+	if x != nil {
+		return x.Isbn
+	}
+	return ""
+}
+
+func (x *Book) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+// 创建图书请求消息
+type CreateBookRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Book          *Book                  `protobuf:"bytes,1,opt,name=book,proto3" json:"book,omitempty"` // 要创建的图书信息
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateBookRequest) Reset() {
+	*x = CreateBookRequest{}
+	mi := &file_protos_bookstore_v2_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateBookRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateBookRequest) ProtoMessage() {}
+
+func (x *CreateBookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_bookstore_v2_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateBookRequest.ProtoReflect.Descriptor instead.
+func (*CreateBookRequest) Descriptor() ([]byte, []int) {
+	return file_protos_bookstore_v2_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CreateBookRequest) GetBook() *Book {
+	if x != nil {
+		return x.Book
+	}
+	return nil
+}
+
+// 获取图书请求消息
+type GetBookRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"` // 要获取的图书ID
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBookRequest) Reset() {
+	*x = GetBookRequest{}
+	mi := &file_protos_bookstore_v2_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBookRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBookRequest) ProtoMessage() {}
+
+func (x *GetBookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_bookstore_v2_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBookRequest.ProtoReflect.Descriptor instead.
+func (*GetBookRequest) Descriptor() ([]byte, []int) {
+	return file_protos_bookstore_v2_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetBookRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+// 更新图书请求消息
+type UpdateBookRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Book          *Book                  `protobuf:"bytes,1,opt,name=book,proto3" json:"book,omitempty"` // 更新的图书信息；version 非0时必须与当前版本一致，否则返回 Aborted
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateBookRequest) Reset() {
+	*x = UpdateBookRequest{}
+	mi := &file_protos_bookstore_v2_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateBookRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateBookRequest) ProtoMessage() {}
+
+func (x *UpdateBookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_bookstore_v2_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateBookRequest.ProtoReflect.Descriptor instead.
+func (*UpdateBookRequest) Descriptor() ([]byte, []int) {
+	return file_protos_bookstore_v2_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *UpdateBookRequest) GetBook() *Book {
+	if x != nil {
+		return x.Book
+	}
+	return nil
+}
+
+// 列出图书请求消息
+type ListBooksRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Page          int32                  `protobuf:"varint,1,opt,name=page,proto3" json:"page,omitempty"`                         // 页码
+	PageSize      int32                  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"` // 每页大小
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListBooksRequest) Reset() {
+	*x = ListBooksRequest{}
+	mi := &file_protos_bookstore_v2_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBooksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBooksRequest) ProtoMessage() {}
+
+func (x *ListBooksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_bookstore_v2_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBooksRequest.ProtoReflect.Descriptor instead.
+func (*ListBooksRequest) Descriptor() ([]byte, []int) {
+	return file_protos_bookstore_v2_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ListBooksRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListBooksRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+// 列出图书响应消息
+type ListBooksResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Books         []*Book                `protobuf:"bytes,1,rep,name=books,proto3" json:"books,omitempty"`  // 图书列表
+	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"` // 总数量
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListBooksResponse) Reset() {
+	*x = ListBooksResponse{}
+	mi := &file_protos_bookstore_v2_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBooksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBooksResponse) ProtoMessage() {}
+
+func (x *ListBooksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_bookstore_v2_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBooksResponse.ProtoReflect.Descriptor instead.
+func (*ListBooksResponse) Descriptor() ([]byte, []int) {
+	return file_protos_bookstore_v2_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ListBooksResponse) GetBooks() []*Book {
+	if x != nil {
+		return x.Books
+	}
+	return nil
+}
+
+func (x *ListBooksResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+// 调试导出的单条记录
+type DebugDumpEntry struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tenant        string                 `protobuf:"bytes,1,opt,name=tenant,proto3" json:"tenant,omitempty"` // 图书所属租户，默认租户为空
+	Book          *Book                  `protobuf:"bytes,2,opt,name=book,proto3" json:"book,omitempty"`     // 图书及其元信息
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DebugDumpEntry) Reset() {
+	*x = DebugDumpEntry{}
+	mi := &file_protos_bookstore_v2_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DebugDumpEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DebugDumpEntry) ProtoMessage() {}
+
+func (x *DebugDumpEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_bookstore_v2_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DebugDumpEntry.ProtoReflect.Descriptor instead.
+func (*DebugDumpEntry) Descriptor() ([]byte, []int) {
+	return file_protos_bookstore_v2_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *DebugDumpEntry) GetTenant() string {
+	if x != nil {
+		return x.Tenant
+	}
+	return ""
+}
+
+func (x *DebugDumpEntry) GetBook() *Book {
+	if x != nil {
+		return x.Book
+	}
+	return nil
+}
+
+// 批量添加标签请求
+type AddTagsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ids           []string               `protobuf:"bytes,1,rep,name=ids,proto3" json:"ids,omitempty"`   // 图书ID列表
+	Tags          []string               `protobuf:"bytes,2,rep,name=tags,proto3" json:"tags,omitempty"` // 要添加的标签
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddTagsRequest) Reset() {
+	*x = AddTagsRequest{}
+	mi := &file_protos_bookstore_v2_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddTagsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddTagsRequest) ProtoMessage() {}
+
+func (x *AddTagsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_bookstore_v2_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddTagsRequest.ProtoReflect.Descriptor instead.
+func (*AddTagsRequest) Descriptor() ([]byte, []int) {
+	return file_protos_bookstore_v2_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *AddTagsRequest) GetIds() []string {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+func (x *AddTagsRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+// 批量删除标签请求
+type RemoveTagsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ids           []string               `protobuf:"bytes,1,rep,name=ids,proto3" json:"ids,omitempty"`   // 图书ID列表
+	Tags          []string               `protobuf:"bytes,2,rep,name=tags,proto3" json:"tags,omitempty"` // 要删除的标签
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveTagsRequest) Reset() {
+	*x = RemoveTagsRequest{}
+	mi := &file_protos_bookstore_v2_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveTagsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveTagsRequest) ProtoMessage() {}
+
+func (x *RemoveTagsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_bookstore_v2_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveTagsRequest.ProtoReflect.Descriptor instead.
+func (*RemoveTagsRequest) Descriptor() ([]byte, []int) {
+	return file_protos_bookstore_v2_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *RemoveTagsRequest) GetIds() []string {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+func (x *RemoveTagsRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+// 单本图书的标签修改结果
+type TagsResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`        // 图书ID
+	Found         bool                   `protobuf:"varint,2,opt,name=found,proto3" json:"found,omitempty"` // 图书是否存在，不存在时不做修改
+	Tags          []string               `protobuf:"bytes,3,rep,name=tags,proto3" json:"tags,omitempty"`    // 修改后的标签
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TagsResult) Reset() {
+	*x = TagsResult{}
+	mi := &file_protos_bookstore_v2_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TagsResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TagsResult) ProtoMessage() {}
+
+func (x *TagsResult) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_bookstore_v2_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TagsResult.ProtoReflect.Descriptor instead.
+func (*TagsResult) Descriptor() ([]byte, []int) {
+	return file_protos_bookstore_v2_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *TagsResult) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *TagsResult) GetFound() bool {
+	if x != nil {
+		return x.Found
+	}
+	return false
+}
+
+func (x *TagsResult) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+// 批量修改标签响应，结果顺序与请求中的ID顺序一致
+type TagsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Results       []*TagsResult          `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TagsResponse) Reset() {
+	*x = TagsResponse{}
+	mi := &file_protos_bookstore_v2_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TagsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TagsResponse) ProtoMessage() {}
+
+func (x *TagsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_bookstore_v2_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TagsResponse.ProtoReflect.Descriptor instead.
+func (*TagsResponse) Descriptor() ([]byte, []int) {
+	return file_protos_bookstore_v2_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *TagsResponse) GetResults() []*TagsResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+var File_protos_bookstore_v2_proto protoreflect.FileDescriptor
+
+const file_protos_bookstore_v2_proto_rawDesc = "" +
+	"\n" +
+	"\x19protos/bookstore_v2.proto\x12\fbookstore.v2\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xca\x03\n" +
+	"\x04Book\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12\x16\n" +
+	"\x06author\x18\x03 \x01(\tR\x06author\x12\x14\n" +
+	"\x05price\x18\x04 \x01(\x02R\x05price\x12 \n" +
+	"\vdescription\x18\x05 \x01(\tR\vdescription\x12!\n" +
+	"\fpublish_year\x18\x06 \x01(\x05R\vpublishYear\x12\x1a\n" +
+	"\bfeatured\x18\a \x01(\bR\bfeatured\x12#\n" +
+	"\rfeatured_rank\x18\b \x01(\x05R\ffeaturedRank\x12\x14\n" +
+	"\x05stock\x18\t \x01(\x05R\x05stock\x12\x12\n" +
+	"\x04tags\x18\n" +
+	" \x03(\tR\x04tags\x129\n" +
+	"\n" +
+	"created_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12\x18\n" +
+	"\aversion\x18\r \x01(\x03R\aversion\x12\x12\n" +
+	"\x04isbn\x18\x0e \x01(\tR\x04isbn\x12\x1a\n" +
+	"\bcurrency\x18\x0f \x01(\tR\bcurrency\";\n" +
+	"\x11CreateBookRequest\x12&\n" +
+	"\x04book\x18\x01 \x01(\v2\x12.bookstore.v2.BookR\x04book\" \n" +
+	"\x0eGetBookRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\";\n" +
+	"\x11UpdateBookRequest\x12&\n" +
+	"\x04book\x18\x01 \x01(\v2\x12.bookstore.v2.BookR\x04book\"C\n" +
+	"\x10ListBooksRequest\x12\x12\n" +
+	"\x04page\x18\x01 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\"S\n" +
+	"\x11ListBooksResponse\x12(\n" +
+	"\x05books\x18\x01 \x03(\v2\x12.bookstore.v2.BookR\x05books\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total\"P\n" +
+	"\x0eDebugDumpEntry\x12\x16\n" +
+	"\x06tenant\x18\x01 \x01(\tR\x06tenant\x12&\n" +
+	"\x04book\x18\x02 \x01(\v2\x12.bookstore.v2.BookR\x04book\"6\n" +
+	"\x0eAddTagsRequest\x12\x10\n" +
+	"\x03ids\x18\x01 \x03(\tR\x03ids\x12\x12\n" +
+	"\x04tags\x18\x02 \x03(\tR\x04tags\"9\n" +
+	"\x11RemoveTagsRequest\x12\x10\n" +
+	"\x03ids\x18\x01 \x03(\tR\x03ids\x12\x12\n" +
+	"\x04tags\x18\x02 \x03(\tR\x04tags\"F\n" +
+	"\n" +
+	"TagsResult\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05found\x18\x02 \x01(\bR\x05found\x12\x12\n" +
+	"\x04tags\x18\x03 \x03(\tR\x04tags\"B\n" +
+	"\fTagsResponse\x122\n" +
+	"\aresults\x18\x01 \x03(\v2\x18.bookstore.v2.TagsResultR\aresults2\xf5\x03\n" +
+	"\rBookServiceV2\x12A\n" +
+	"\n" +
+	"CreateBook\x12\x1f.bookstore.v2.CreateBookRequest\x1a\x12.bookstore.v2.Book\x12;\n" +
+	"\aGetBook\x12\x1c.bookstore.v2.GetBookRequest\x1a\x12.bookstore.v2.Book\x12A\n" +
+	"\n" +
+	"UpdateBook\x12\x1f.bookstore.v2.UpdateBookRequest\x1a\x12.bookstore.v2.Book\x12L\n" +
+	"\tListBooks\x12\x1e.bookstore.v2.ListBooksRequest\x1a\x1f.bookstore.v2.ListBooksResponse\x12C\n" +
+	"\aAddTags\x12\x1c.bookstore.v2.AddTagsRequest\x1a\x1a.bookstore.v2.TagsResponse\x12I\n" +
+	"\n" +
+	"RemoveTags\x12\x1f.bookstore.v2.RemoveTagsRequest\x1a\x1a.bookstore.v2.TagsResponse\x12C\n" +
+	"\tDebugDump\x12\x16.google.protobuf.Empty\x1a\x1c.bookstore.v2.DebugDumpEntry0\x01B\x1dZ\x1bpb/bookstore/v2;bookstorev2b\x06proto3"
+
+var (
+	file_protos_bookstore_v2_proto_rawDescOnce sync.Once
+	file_protos_bookstore_v2_proto_rawDescData []byte
+)
+
+func file_protos_bookstore_v2_proto_rawDescGZIP() []byte {
+	file_protos_bookstore_v2_proto_rawDescOnce.Do(func() {
+		file_protos_bookstore_v2_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_protos_bookstore_v2_proto_rawDesc), len(file_protos_bookstore_v2_proto_rawDesc)))
+	})
+	return file_protos_bookstore_v2_proto_rawDescData
+}
+
+var file_protos_bookstore_v2_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_protos_bookstore_v2_proto_goTypes = []any{
+	(*Book)(nil),                  // 0: bookstore.v2.Book
+	(*CreateBookRequest)(nil),     // 1: bookstore.v2.CreateBookRequest
+	(*GetBookRequest)(nil),        // 2: bookstore.v2.GetBookRequest
+	(*UpdateBookRequest)(nil),     // 3: bookstore.v2.UpdateBookRequest
+	(*ListBooksRequest)(nil),      // 4: bookstore.v2.ListBooksRequest
+	(*ListBooksResponse)(nil),     // 5: bookstore.v2.ListBooksResponse
+	(*DebugDumpEntry)(nil),        // 6: bookstore.v2.DebugDumpEntry
+	(*AddTagsRequest)(nil),        // 7: bookstore.v2.AddTagsRequest
+	(*RemoveTagsRequest)(nil),     // 8: bookstore.v2.RemoveTagsRequest
+	(*TagsResult)(nil),            // 9: bookstore.v2.TagsResult
+	(*TagsResponse)(nil),          // 10: bookstore.v2.TagsResponse
+	(*timestamppb.Timestamp)(nil), // 11: google.protobuf.Timestamp
+	(*emptypb.Empty)(nil),         // 12: google.protobuf.Empty
+}
+var file_protos_bookstore_v2_proto_depIdxs = []int32{
+	11, // 0: bookstore.v2.Book.created_at:type_name -> google.protobuf.Timestamp
+	11, // 1: bookstore.v2.Book.updated_at:type_name -> google.protobuf.Timestamp
+	0,  // 2: bookstore.v2.CreateBookRequest.book:type_name -> bookstore.v2.Book
+	0,  // 3: bookstore.v2.UpdateBookRequest.book:type_name -> bookstore.v2.Book
+	0,  // 4: bookstore.v2.ListBooksResponse.books:type_name -> bookstore.v2.Book
+	0,  // 5: bookstore.v2.DebugDumpEntry.book:type_name -> bookstore.v2.Book
+	9,  // 6: bookstore.v2.TagsResponse.results:type_name -> bookstore.v2.TagsResult
+	1,  // 7: bookstore.v2.BookServiceV2.CreateBook:input_type -> bookstore.v2.CreateBookRequest
+	2,  // 8: bookstore.v2.BookServiceV2.GetBook:input_type -> bookstore.v2.GetBookRequest
+	3,  // 9: bookstore.v2.BookServiceV2.UpdateBook:input_type -> bookstore.v2.UpdateBookRequest
+	4,  // 10: bookstore.v2.BookServiceV2.ListBooks:input_type -> bookstore.v2.ListBooksRequest
+	7,  // 11: bookstore.v2.BookServiceV2.AddTags:input_type -> bookstore.v2.AddTagsRequest
+	8,  // 12: bookstore.v2.BookServiceV2.RemoveTags:input_type -> bookstore.v2.RemoveTagsRequest
+	12, // 13: bookstore.v2.BookServiceV2.DebugDump:input_type -> google.protobuf.Empty
+	0,  // 14: bookstore.v2.BookServiceV2.CreateBook:output_type -> bookstore.v2.Book
+	0,  // 15: bookstore.v2.BookServiceV2.GetBook:output_type -> bookstore.v2.Book
+	0,  // 16: bookstore.v2.BookServiceV2.UpdateBook:output_type -> bookstore.v2.Book
+	5,  // 17: bookstore.v2.BookServiceV2.ListBooks:output_type -> bookstore.v2.ListBooksResponse
+	10, // 18: bookstore.v2.BookServiceV2.AddTags:output_type -> bookstore.v2.TagsResponse
+	10, // 19: bookstore.v2.BookServiceV2.RemoveTags:output_type -> bookstore.v2.TagsResponse
+	6,  // 20: bookstore.v2.BookServiceV2.DebugDump:output_type -> bookstore.v2.DebugDumpEntry
+	14, // [14:21] is the sub-list for method output_type
+	7,  // [7:14] is the sub-list for method input_type
+	7,  // [7:7] is the sub-list for extension type_name
+	7,  // [7:7] is the sub-list for extension extendee
+	0,  // [0:7] is the sub-list for field type_name
+}
+
+func init() { file_protos_bookstore_v2_proto_init() }
+func file_protos_bookstore_v2_proto_init() {
+	if File_protos_bookstore_v2_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_protos_bookstore_v2_proto_rawDesc), len(file_protos_bookstore_v2_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   11,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_protos_bookstore_v2_proto_goTypes,
+		DependencyIndexes: file_protos_bookstore_v2_proto_depIdxs,
+		MessageInfos:      file_protos_bookstore_v2_proto_msgTypes,
+	}.Build()
+	File_protos_bookstore_v2_proto = out.File
+	file_protos_bookstore_v2_proto_goTypes = nil
+	file_protos_bookstore_v2_proto_depIdxs = nil
+}
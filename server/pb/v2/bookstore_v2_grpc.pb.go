@@ -0,0 +1,376 @@
+// 定义protobuf语法版本
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v3.19.3
+// source: protos/bookstore_v2.proto
+
+// 定义包名，v2 与 v1 使用不同的包，两个版本的服务可以同时注册
+
+package bookstorev2
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	BookServiceV2_CreateBook_FullMethodName = "/bookstore.v2.BookServiceV2/CreateBook"
+	BookServiceV2_GetBook_FullMethodName    = "/bookstore.v2.BookServiceV2/GetBook"
+	BookServiceV2_UpdateBook_FullMethodName = "/bookstore.v2.BookServiceV2/UpdateBook"
+	BookServiceV2_ListBooks_FullMethodName  = "/bookstore.v2.BookServiceV2/ListBooks"
+	BookServiceV2_AddTags_FullMethodName    = "/bookstore.v2.BookServiceV2/AddTags"
+	BookServiceV2_RemoveTags_FullMethodName = "/bookstore.v2.BookServiceV2/RemoveTags"
+	BookServiceV2_DebugDump_FullMethodName  = "/bookstore.v2.BookServiceV2/DebugDump"
+)
+
+// BookServiceV2Client is the client API for BookServiceV2 service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// 图书服务 v2，与 v1 共享同一份图书存储
+type BookServiceV2Client interface {
+	// 创建图书，返回创建后的完整图书 - 一元RPC
+	CreateBook(ctx context.Context, in *CreateBookRequest, opts ...grpc.CallOption) (*Book, error)
+	// 获取图书信息 - 一元RPC
+	GetBook(ctx context.Context, in *GetBookRequest, opts ...grpc.CallOption) (*Book, error)
+	// 更新图书信息，返回更新后的完整图书 - 一元RPC
+	UpdateBook(ctx context.Context, in *UpdateBookRequest, opts ...grpc.CallOption) (*Book, error)
+	// 列出图书（支持分页） - 一元RPC
+	ListBooks(ctx context.Context, in *ListBooksRequest, opts ...grpc.CallOption) (*ListBooksResponse, error)
+	// 为多本图书添加标签 - 一元RPC
+	AddTags(ctx context.Context, in *AddTagsRequest, opts ...grpc.CallOption) (*TagsResponse, error)
+	// 从多本图书删除标签 - 一元RPC
+	RemoveTags(ctx context.Context, in *RemoveTagsRequest, opts ...grpc.CallOption) (*TagsResponse, error)
+	// 导出所有租户的图书及元信息，用于排查问题；仅管理员可调用 - 服务端流式RPC
+	DebugDump(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (grpc.ServerStreamingClient[DebugDumpEntry], error)
+}
+
+type bookServiceV2Client struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewBookServiceV2Client(cc grpc.ClientConnInterface) BookServiceV2Client {
+	return &bookServiceV2Client{cc}
+}
+
+func (c *bookServiceV2Client) CreateBook(ctx context.Context, in *CreateBookRequest, opts ...grpc.CallOption) (*Book, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Book)
+	err := c.cc.Invoke(ctx, BookServiceV2_CreateBook_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookServiceV2Client) GetBook(ctx context.Context, in *GetBookRequest, opts ...grpc.CallOption) (*Book, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Book)
+	err := c.cc.Invoke(ctx, BookServiceV2_GetBook_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookServiceV2Client) UpdateBook(ctx context.Context, in *UpdateBookRequest, opts ...grpc.CallOption) (*Book, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Book)
+	err := c.cc.Invoke(ctx, BookServiceV2_UpdateBook_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookServiceV2Client) ListBooks(ctx context.Context, in *ListBooksRequest, opts ...grpc.CallOption) (*ListBooksResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListBooksResponse)
+	err := c.cc.Invoke(ctx, BookServiceV2_ListBooks_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookServiceV2Client) AddTags(ctx context.Context, in *AddTagsRequest, opts ...grpc.CallOption) (*TagsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TagsResponse)
+	err := c.cc.Invoke(ctx, BookServiceV2_AddTags_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookServiceV2Client) RemoveTags(ctx context.Context, in *RemoveTagsRequest, opts ...grpc.CallOption) (*TagsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TagsResponse)
+	err := c.cc.Invoke(ctx, BookServiceV2_RemoveTags_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookServiceV2Client) DebugDump(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (grpc.ServerStreamingClient[DebugDumpEntry], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &BookServiceV2_ServiceDesc.Streams[0], BookServiceV2_DebugDump_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[emptypb.Empty, DebugDumpEntry]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type BookServiceV2_DebugDumpClient = grpc.ServerStreamingClient[DebugDumpEntry]
+
+// BookServiceV2Server is the server API for BookServiceV2 service.
+// All implementations must embed UnimplementedBookServiceV2Server
+// for forward compatibility.
+//
+// 图书服务 v2，与 v1 共享同一份图书存储
+type BookServiceV2Server interface {
+	// 创建图书，返回创建后的完整图书 - 一元RPC
+	CreateBook(context.Context, *CreateBookRequest) (*Book, error)
+	// 获取图书信息 - 一元RPC
+	GetBook(context.Context, *GetBookRequest) (*Book, error)
+	// 更新图书信息，返回更新后的完整图书 - 一元RPC
+	UpdateBook(context.Context, *UpdateBookRequest) (*Book, error)
+	// 列出图书（支持分页） - 一元RPC
+	ListBooks(context.Context, *ListBooksRequest) (*ListBooksResponse, error)
+	// 为多本图书添加标签 - 一元RPC
+	AddTags(context.Context, *AddTagsRequest) (*TagsResponse, error)
+	// 从多本图书删除标签 - 一元RPC
+	RemoveTags(context.Context, *RemoveTagsRequest) (*TagsResponse, error)
+	// 导出所有租户的图书及元信息，用于排查问题；仅管理员可调用 - 服务端流式RPC
+	DebugDump(*emptypb.Empty, grpc.ServerStreamingServer[DebugDumpEntry]) error
+	mustEmbedUnimplementedBookServiceV2Server()
+}
+
+// UnimplementedBookServiceV2Server must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedBookServiceV2Server struct{}
+
+func (UnimplementedBookServiceV2Server) CreateBook(context.Context, *CreateBookRequest) (*Book, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateBook not implemented")
+}
+func (UnimplementedBookServiceV2Server) GetBook(context.Context, *GetBookRequest) (*Book, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBook not implemented")
+}
+func (UnimplementedBookServiceV2Server) UpdateBook(context.Context, *UpdateBookRequest) (*Book, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateBook not implemented")
+}
+func (UnimplementedBookServiceV2Server) ListBooks(context.Context, *ListBooksRequest) (*ListBooksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListBooks not implemented")
+}
+func (UnimplementedBookServiceV2Server) AddTags(context.Context, *AddTagsRequest) (*TagsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddTags not implemented")
+}
+func (UnimplementedBookServiceV2Server) RemoveTags(context.Context, *RemoveTagsRequest) (*TagsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveTags not implemented")
+}
+func (UnimplementedBookServiceV2Server) DebugDump(*emptypb.Empty, grpc.ServerStreamingServer[DebugDumpEntry]) error {
+	return status.Errorf(codes.Unimplemented, "method DebugDump not implemented")
+}
+func (UnimplementedBookServiceV2Server) mustEmbedUnimplementedBookServiceV2Server() {}
+func (UnimplementedBookServiceV2Server) testEmbeddedByValue()                       {}
+
+// UnsafeBookServiceV2Server may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to BookServiceV2Server will
+// result in compilation errors.
+type UnsafeBookServiceV2Server interface {
+	mustEmbedUnimplementedBookServiceV2Server()
+}
+
+func RegisterBookServiceV2Server(s grpc.ServiceRegistrar, srv BookServiceV2Server) {
+	// If the following call pancis, it indicates UnimplementedBookServiceV2Server was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&BookServiceV2_ServiceDesc, srv)
+}
+
+func _BookServiceV2_CreateBook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateBookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookServiceV2Server).CreateBook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BookServiceV2_CreateBook_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookServiceV2Server).CreateBook(ctx, req.(*CreateBookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookServiceV2_GetBook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookServiceV2Server).GetBook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BookServiceV2_GetBook_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookServiceV2Server).GetBook(ctx, req.(*GetBookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookServiceV2_UpdateBook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateBookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookServiceV2Server).UpdateBook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BookServiceV2_UpdateBook_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookServiceV2Server).UpdateBook(ctx, req.(*UpdateBookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookServiceV2_ListBooks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListBooksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookServiceV2Server).ListBooks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BookServiceV2_ListBooks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookServiceV2Server).ListBooks(ctx, req.(*ListBooksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookServiceV2_AddTags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddTagsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookServiceV2Server).AddTags(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BookServiceV2_AddTags_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookServiceV2Server).AddTags(ctx, req.(*AddTagsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookServiceV2_RemoveTags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveTagsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookServiceV2Server).RemoveTags(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BookServiceV2_RemoveTags_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookServiceV2Server).RemoveTags(ctx, req.(*RemoveTagsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookServiceV2_DebugDump_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(emptypb.Empty)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(BookServiceV2Server).DebugDump(m, &grpc.GenericServerStream[emptypb.Empty, DebugDumpEntry]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type BookServiceV2_DebugDumpServer = grpc.ServerStreamingServer[DebugDumpEntry]
+
+// BookServiceV2_ServiceDesc is the grpc.ServiceDesc for BookServiceV2 service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var BookServiceV2_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "bookstore.v2.BookServiceV2",
+	HandlerType: (*BookServiceV2Server)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateBook",
+			Handler:    _BookServiceV2_CreateBook_Handler,
+		},
+		{
+			MethodName: "GetBook",
+			Handler:    _BookServiceV2_GetBook_Handler,
+		},
+		{
+			MethodName: "UpdateBook",
+			Handler:    _BookServiceV2_UpdateBook_Handler,
+		},
+		{
+			MethodName: "ListBooks",
+			Handler:    _BookServiceV2_ListBooks_Handler,
+		},
+		{
+			MethodName: "AddTags",
+			Handler:    _BookServiceV2_AddTags_Handler,
+		},
+		{
+			MethodName: "RemoveTags",
+			Handler:    _BookServiceV2_RemoveTags_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "DebugDump",
+			Handler:       _BookServiceV2_DebugDump_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "protos/bookstore_v2.proto",
+}
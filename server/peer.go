@@ -0,0 +1,94 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/netip"
+
+	// 导入gRPC相关包
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// peerAddr 返回调用方的网络地址，无法获取时返回空字符串
+func peerAddr(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+	return p.Addr.String()
+}
+
+// peerIP 返回调用方的IP地址，非IP连接（如 Unix 域套接字）返回 false
+func peerIP(ctx context.Context) (netip.Addr, bool) {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return netip.Addr{}, false
+	}
+	if tcpAddr, ok := p.Addr.(*net.TCPAddr); ok {
+		addr, ok := netip.AddrFromSlice(tcpAddr.IP)
+		return addr.Unmap(), ok
+	}
+	addrPort, err := netip.ParseAddrPort(p.Addr.String())
+	if err != nil {
+		return netip.Addr{}, false
+	}
+	return addrPort.Addr().Unmap(), true
+}
+
+// parseCIDRs 解析 CIDR 列表，如 10.0.0.0/8、::1/128
+func parseCIDRs(cidrs []string) ([]netip.Prefix, error) {
+	prefixes := make([]netip.Prefix, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		prefix, err := netip.ParsePrefix(cidr)
+		if err != nil {
+			return nil, fmt.Errorf("无效的网段 %q: %v", cidr, err)
+		}
+		prefixes = append(prefixes, prefix.Masked())
+	}
+	return prefixes, nil
+}
+
+// newPeerFilterInterceptor 创建调用方IP访问控制拦截器，allow 为空表示不限制
+func newPeerFilterInterceptor(allow []netip.Prefix) grpc.UnaryServerInterceptor {
+	check := newPeerFilter(allow)
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := check(ctx); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// newPeerFilterStreamInterceptor 创建流式方法的调用方IP访问控制拦截器，规则与一元方法相同
+func newPeerFilterStreamInterceptor(allow []netip.Prefix) grpc.StreamServerInterceptor {
+	check := newPeerFilter(allow)
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := check(ss.Context()); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
+
+// newPeerFilter 返回检查调用方IP是否在允许网段内的函数，不在时返回 PermissionDenied
+// 设置了允许网段时，无法确定IP的连接（如 Unix 域套接字）同样被拒绝
+func newPeerFilter(allow []netip.Prefix) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		if len(allow) == 0 {
+			return nil
+		}
+		ip, ok := peerIP(ctx)
+		if ok {
+			for _, prefix := range allow {
+				if prefix.Contains(ip) {
+					return nil
+				}
+			}
+		}
+		return status.Errorf(codes.PermissionDenied, "调用方地址不在允许的网段内: %s", peerAddr(ctx))
+	}
+}
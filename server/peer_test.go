@@ -0,0 +1,81 @@
+package main
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	// 导入生成的protobuf代码
+	pb "grpc-basic-server/pb"
+
+	// 导入gRPC相关包
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+)
+
+// startTCPTestServer 在本机回环地址上启动测试服务器，用于需要真实调用方IP的测试
+func startTCPTestServer(t *testing.T, cfg *config, opts ...ServerOption) pb.BookServiceClient {
+	t.Helper()
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("启动监听失败: %v", err)
+	}
+	s, _, err := newGRPCServer(cfg, opts...)
+	if err != nil {
+		t.Fatalf("创建测试服务器失败: %v", err)
+	}
+	go s.Serve(lis)
+	t.Cleanup(s.Stop)
+
+	conn, err := grpc.NewClient(lis.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("连接测试服务器失败: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return pb.NewBookServiceClient(conn)
+}
+
+// TestPeerAddrLogged 测试日志中记录调用方地址
+func TestPeerAddrLogged(t *testing.T) {
+	logger := &captureLogger{}
+	client := startTCPTestServer(t, mustParseConfig(t, "-allow-cidrs", "127.0.0.0/8"), WithLogger(logger))
+
+	if _, err := client.ListBooks(context.Background(), &pb.ListBooksRequest{}); err != nil {
+		t.Fatalf("允许网段内的调用不应失败: %v", err)
+	}
+	if !logger.contains("peer=127.0.0.1:") {
+		t.Errorf("期望日志记录调用方地址，实际为: %v", logger.lines)
+	}
+}
+
+// TestPeerAllowList 测试调用方不在允许网段内时返回 PermissionDenied
+func TestPeerAllowList(t *testing.T) {
+	client := startTCPTestServer(t, mustParseConfig(t, "-allow-cidrs", "10.0.0.0/8,192.168.0.0/16"))
+
+	_, err := client.ListBooks(context.Background(), &pb.ListBooksRequest{})
+	if status.Code(err) != codes.PermissionDenied {
+		t.Errorf("期望错误码为PermissionDenied，实际为: %v", err)
+	}
+
+	// 流式方法同样被拒绝
+	stream, err := client.StreamBooks(context.Background(), &pb.StreamBooksRequest{})
+	if err == nil {
+		_, err = stream.Recv()
+	}
+	if status.Code(err) != codes.PermissionDenied {
+		t.Errorf("期望流式调用错误码为PermissionDenied，实际为: %v", err)
+	}
+}
+
+// TestParseCIDRs 测试无效的网段配置被拒绝
+func TestParseCIDRs(t *testing.T) {
+	if _, err := parseConfig([]string{"-allow-cidrs", "10.0.0.0/33"}); err == nil {
+		t.Errorf("期望无效网段返回错误")
+	}
+	if _, err := parseConfig([]string{"-allow-cidrs", "10.1.2.3/8"}); err != nil {
+		t.Errorf("期望网段解析成功，实际为: %v", err)
+	}
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	// 导入生成的protobuf代码
+	pb "grpc-basic-server/pb"
+
+	// 导入gRPC相关包
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// TestUpdateBookOmittedFields 测试更新时未设置的价格、描述和出版年份保留原值
+func TestUpdateBookOmittedFields(t *testing.T) {
+	client, _ := startTestServer(t, mustParseConfig(t))
+	ctx := context.Background()
+
+	created, err := client.CreateBook(ctx, &pb.CreateBookRequest{Book: &pb.Book{
+		Title: "图书", Author: "作者", Price: proto.Float32(29.99), Description: proto.String("描述"), PublishYear: proto.Int32(2001),
+	}})
+	if err != nil {
+		t.Fatalf("创建图书失败: %v", err)
+	}
+
+	// 只修改标题，价格等字段不设置
+	if _, err := client.UpdateBook(ctx, &pb.UpdateBookRequest{Book: &pb.Book{Id: created.Id, Title: "新标题", Author: "作者"}}); err != nil {
+		t.Fatalf("更新图书失败: %v", err)
+	}
+	resp, err := client.GetBook(ctx, &pb.GetBookRequest{Id: created.Id})
+	if err != nil {
+		t.Fatalf("获取图书失败: %v", err)
+	}
+	book := resp.GetBook()
+	if book.GetTitle() != "新标题" {
+		t.Errorf("期望标题被更新，实际为: %s", book.GetTitle())
+	}
+	if book.Price == nil || !floatEquals(book.GetPrice(), 29.99) {
+		t.Errorf("未设置的价格应保留原值，实际为: %v", book.Price)
+	}
+	if book.GetDescription() != "描述" || book.GetPublishYear() != 2001 {
+		t.Errorf("未设置的描述和出版年份应保留原值，实际为: %v", book)
+	}
+
+	// 显式设置的空描述会清空原有描述
+	if _, err := client.UpdateBook(ctx, &pb.UpdateBookRequest{Book: &pb.Book{Id: created.Id, Title: "新标题", Author: "作者", Description: proto.String("")}}); err != nil {
+		t.Fatalf("更新图书失败: %v", err)
+	}
+	resp, err = client.GetBook(ctx, &pb.GetBookRequest{Id: created.Id})
+	if err != nil {
+		t.Fatalf("获取图书失败: %v", err)
+	}
+	if resp.GetBook().GetDescription() != "" || resp.GetBook().GetPublishYear() != 2001 {
+		t.Errorf("期望只清空描述，实际为: %v", resp.GetBook())
+	}
+}
+
+// TestUpdateBookExplicitZeroPrice 测试更新时显式设置的价格0不视为未设置，被验证拒绝
+func TestUpdateBookExplicitZeroPrice(t *testing.T) {
+	client, _ := startTestServer(t, mustParseConfig(t))
+	ctx := context.Background()
+
+	created, err := client.CreateBook(ctx, &pb.CreateBookRequest{Book: &pb.Book{Title: "图书", Author: "作者", Price: proto.Float32(10)}})
+	if err != nil {
+		t.Fatalf("创建图书失败: %v", err)
+	}
+
+	_, err = client.UpdateBook(ctx, &pb.UpdateBookRequest{Book: &pb.Book{Id: created.Id, Title: "图书", Author: "作者", Price: proto.Float32(0)}})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("期望错误码为InvalidArgument，实际为: %v", err)
+	}
+
+	resp, err := client.GetBook(ctx, &pb.GetBookRequest{Id: created.Id})
+	if err != nil {
+		t.Fatalf("获取图书失败: %v", err)
+	}
+	if !floatEquals(resp.GetBook().GetPrice(), 10) {
+		t.Errorf("被拒绝的更新不应修改价格，实际为: %v", resp.GetBook().GetPrice())
+	}
+
+	// 创建时价格同样必须显式设置
+	_, err = client.CreateBook(ctx, &pb.CreateBookRequest{Book: &pb.Book{Title: "图书", Author: "作者"}})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("期望未设置价格时创建失败，实际为: %v", err)
+	}
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"context"
+	"math"
+	"sort"
+	"time"
+
+	// 导入生成的protobuf代码
+	pb "grpc-basic-server/pb"
+
+	// 导入gRPC相关包
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// priceHistogramCoalesce 收到修改通知后等待的时间，期间的多次修改合并为一次更新
+const priceHistogramCoalesce = 100 * time.Millisecond
+
+// errShuttingDown 服务开始关闭时长时间运行的流返回的错误
+var errShuttingDown = status.Error(codes.Unavailable, "服务正在关闭，请重新连接")
+
+// StreamPriceHistogram 先发送调用方租户当前的价格分布，之后每当图书修改使分布变化时发送新的分布，
+// 直到客户端取消或服务开始关闭（返回 Unavailable，客户端可以重新连接到其他实例）
+func (s *BookServer) StreamPriceHistogram(req *pb.StreamPriceHistogramRequest, stream grpc.ServerStreamingServer[pb.PriceHistogram]) error {
+	ctx := stream.Context()
+
+	// 记录请求日志
+	s.logger.Info("收到流式价格分布请求", "bucket_width", req.GetBucketWidth())
+
+	// 验证请求参数
+	width := req.GetBucketWidth()
+	if !isFinite(width) || width <= 0 {
+		return status.Errorf(codes.InvalidArgument, "价格区间宽度必须大于0")
+	}
+	// 区间宽度过小时区间序号超出 int64 范围，无法表示
+	if maxBookPrice/float64(width) > math.MaxInt64 {
+		return status.Errorf(codes.InvalidArgument, "价格区间宽度过小: %g", width)
+	}
+
+	var last *pb.PriceHistogram
+	for {
+		// 先取得通知通道再读取图书，读取期间发生的修改也会触发下一次更新
+		changed := s.changes.wait()
+		histogram := s.priceHistogram(ctx, width)
+		if last == nil || !proto.Equal(last, histogram) {
+			if err := stream.Send(histogram); err != nil {
+				return err
+			}
+			last = histogram
+		}
+
+		select {
+		case <-ctx.Done():
+			return status.FromContextError(ctx.Err()).Err()
+		case <-s.shuttingDown:
+			return errShuttingDown
+		case <-changed:
+		}
+
+		// 合并短时间内的多次修改
+		timer := time.NewTimer(priceHistogramCoalesce)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return status.FromContextError(ctx.Err()).Err()
+		case <-s.shuttingDown:
+			timer.Stop()
+			return errShuttingDown
+		case <-timer.C:
+		}
+	}
+}
+
+// priceHistogram 统计调用方租户的图书在各价格区间中的数量，只返回非空的区间
+func (s *BookServer) priceHistogram(ctx context.Context, width float32) *pb.PriceHistogram {
+	// 在读锁内只统计各区间的数量，排序放到锁外进行
+	s.mu.RLock()
+	catalog := s.catalogFor(ctx, false)
+	counts := make(map[int64]int32)
+	var total int32
+	for _, book := range catalog.books {
+		if s.validForRead(book) {
+			counts[int64(math.Floor(float64(book.GetPrice())/float64(width)))]++
+			total++
+		}
+	}
+	s.mu.RUnlock()
+
+	indexes := make([]int64, 0, len(counts))
+	for index := range counts {
+		indexes = append(indexes, index)
+	}
+	sort.Slice(indexes, func(i, j int) bool { return indexes[i] < indexes[j] })
+
+	histogram := &pb.PriceHistogram{Total: total}
+	for _, index := range indexes {
+		histogram.Buckets = append(histogram.Buckets, &pb.PriceBucket{
+			Lower: float32(float64(index) * float64(width)),
+			Upper: float32(float64(index+1) * float64(width)),
+			Count: counts[index],
+		})
+	}
+	return histogram
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	// 导入生成的protobuf代码
+	pb "grpc-basic-server/pb"
+
+	// 导入gRPC相关包
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// TestStreamPriceHistogram 测试价格分布先发送当前状态，创建图书后发送合并后的更新
+func TestStreamPriceHistogram(t *testing.T) {
+	client, _ := startTestServer(t, mustParseConfig(t))
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	create := func(price float32) {
+		if _, err := client.CreateBook(ctx, &pb.CreateBookRequest{Book: &pb.Book{Title: "图书", Author: "作者", Price: proto.Float32(price)}}); err != nil {
+			t.Fatalf("创建图书失败: %v", err)
+		}
+	}
+	create(5)
+
+	stream, err := client.StreamPriceHistogram(ctx, &pb.StreamPriceHistogramRequest{BucketWidth: 10})
+	if err != nil {
+		t.Fatalf("打开价格分布流失败: %v", err)
+	}
+	initial, err := stream.Recv()
+	if err != nil {
+		t.Fatalf("接收价格分布失败: %v", err)
+	}
+	if initial.GetTotal() != 1 || len(initial.GetBuckets()) != 1 || initial.GetBuckets()[0].GetUpper() != 10 {
+		t.Errorf("期望初始分布为 [0,10) 中1本，实际为: %v", initial)
+	}
+
+	// 短时间内的两次创建合并为一次更新，最终反映所有修改
+	create(15)
+	create(18)
+	var updated *pb.PriceHistogram
+	for updated.GetTotal() != 3 {
+		if updated, err = stream.Recv(); err != nil {
+			t.Fatalf("接收价格分布失败: %v", err)
+		}
+	}
+	buckets := updated.GetBuckets()
+	if len(buckets) != 2 || buckets[1].GetLower() != 10 || buckets[1].GetCount() != 2 {
+		t.Errorf("期望 [10,20) 中有2本图书，实际为: %v", updated)
+	}
+
+	// 取消后流正常结束
+	cancel()
+	if _, err := stream.Recv(); status.Code(err) != codes.Canceled {
+		t.Errorf("期望取消后返回 Canceled，实际为: %v", err)
+	}
+}
+
+// TestStreamPriceHistogramInvalidWidth 测试区间宽度不合法时返回 InvalidArgument
+func TestStreamPriceHistogramInvalidWidth(t *testing.T) {
+	client, _ := startTestServer(t, mustParseConfig(t))
+	stream, err := client.StreamPriceHistogram(context.Background(), &pb.StreamPriceHistogramRequest{})
+	if err != nil {
+		t.Fatalf("打开价格分布流失败: %v", err)
+	}
+	if _, err := stream.Recv(); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("期望返回 InvalidArgument，实际为: %v", err)
+	}
+}
+
+// TestStreamPriceHistogramTinyWidth 测试区间宽度过小、区间序号会溢出时返回 InvalidArgument
+func TestStreamPriceHistogramTinyWidth(t *testing.T) {
+	client, _ := startTestServer(t, mustParseConfig(t))
+	stream, err := client.StreamPriceHistogram(context.Background(), &pb.StreamPriceHistogramRequest{BucketWidth: 1e-30})
+	if err != nil {
+		t.Fatalf("打开价格分布流失败: %v", err)
+	}
+	if _, err := stream.Recv(); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("期望返回 InvalidArgument，实际为: %v", err)
+	}
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"math"
+	"slices"
+	"sort"
+)
+
+// maxPriceChunkSize 价格索引每个分块的最大条目数，超过时一分为二
+const maxPriceChunkSize = 1024
+
+// priceEntry 价格索引的条目，按价格排序，价格相同时按ID的自然顺序排序
+type priceEntry struct {
+	price float32
+	id    string
+}
+
+// less 比较两个条目的顺序
+func (e priceEntry) less(other priceEntry) bool {
+	if e.price != other.price {
+		return e.price < other.price
+	}
+	return idLess(e.id, other.id)
+}
+
+// priceIndex 按价格排序的图书索引。条目排序后分块保存（类似只有两层的 B 树）：
+// 插入和删除只移动一个分块内的条目，范围查询二分定位到最低价格后顺序遍历，只访问价格在范围内的条目
+type priceIndex struct {
+	// 每个分块非空且内部有序，前一个分块的所有条目都排在后一个分块之前
+	chunks [][]priceEntry
+}
+
+// locate 返回条目 e 应在的分块下标和分块内的下标：即第一个不小于 e 的条目的位置
+func (p *priceIndex) locate(e priceEntry) (int, int) {
+	ci := sort.Search(len(p.chunks), func(i int) bool {
+		chunk := p.chunks[i]
+		return !chunk[len(chunk)-1].less(e)
+	})
+	if ci == len(p.chunks) {
+		return ci, 0
+	}
+	chunk := p.chunks[ci]
+	return ci, sort.Search(len(chunk), func(i int) bool { return !chunk[i].less(e) })
+}
+
+// insert 加入条目，价格为 NaN 的图书不参与任何价格比较，不加入索引
+func (p *priceIndex) insert(price float32, id string) {
+	if math.IsNaN(float64(price)) {
+		return
+	}
+	e := priceEntry{price: price, id: id}
+	if len(p.chunks) == 0 {
+		p.chunks = [][]priceEntry{{e}}
+		return
+	}
+
+	// 比所有条目都大时放入最后一个分块
+	ci, i := p.locate(e)
+	if ci == len(p.chunks) {
+		ci = len(p.chunks) - 1
+		i = len(p.chunks[ci])
+	}
+	chunk := slices.Insert(p.chunks[ci], i, e)
+
+	// 分块过大时一分为二，两半不共享底层数组，避免之后的插入互相覆盖
+	if len(chunk) > maxPriceChunkSize {
+		half := len(chunk) / 2
+		right := append([]priceEntry(nil), chunk[half:]...)
+		p.chunks[ci] = chunk[:half:half]
+		p.chunks = slices.Insert(p.chunks, ci+1, right)
+		return
+	}
+	p.chunks[ci] = chunk
+}
+
+// delete 删除条目，条目不存在时不做任何操作
+func (p *priceIndex) delete(price float32, id string) {
+	e := priceEntry{price: price, id: id}
+	ci, i := p.locate(e)
+	if ci == len(p.chunks) || i == len(p.chunks[ci]) || p.chunks[ci][i] != e {
+		return
+	}
+	if chunk := slices.Delete(p.chunks[ci], i, i+1); len(chunk) > 0 {
+		p.chunks[ci] = chunk
+	} else {
+		p.chunks = slices.Delete(p.chunks, ci, ci+1)
+	}
+}
+
+// ascend 按价格从低到高依次对价格在 [minPrice, maxPrice] 内的图书ID调用 fn
+func (p *priceIndex) ascend(minPrice, maxPrice float32, fn func(id string)) {
+	// 与 NaN 比较总是不成立，没有图书在范围内
+	if math.IsNaN(float64(minPrice)) || math.IsNaN(float64(maxPrice)) {
+		return
+	}
+
+	// ID 为空的条目排在同价格的所有图书之前
+	ci, i := p.locate(priceEntry{price: minPrice})
+	for ; ci < len(p.chunks); ci, i = ci+1, 0 {
+		for _, e := range p.chunks[ci][i:] {
+			if e.price > maxPrice {
+				return
+			}
+			fn(e.id)
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	// 导入生成的protobuf代码
+	pb "grpc-basic-server/pb"
+
+	// 导入gRPC相关包
+	"google.golang.org/protobuf/proto"
+)
+
+// scanPriceRange 遍历所有图书查找价格区间内的图书，作为价格索引的对照实现
+func scanPriceRange(t testing.TB, server *BookServer, minPrice, maxPrice float32) []string {
+	t.Helper()
+
+	books, err := server.booksForRead(context.Background(), "")
+	if err != nil {
+		t.Fatalf("读取图书失败: %v", err)
+	}
+	var ids []string
+	for _, book := range books {
+		if book.GetPrice() >= minPrice && book.GetPrice() <= maxPrice {
+			ids = append(ids, book.GetId())
+		}
+	}
+	return ids
+}
+
+// TestPriceIndexMatchesScan 测试经过大量创建、修改价格和删除后，按价格索引查询的结果与遍历的结果完全一致
+func TestPriceIndexMatchesScan(t *testing.T) {
+	silenceLog(t)
+	server := NewBookServer()
+	ctx := context.Background()
+	rng := rand.New(rand.NewPCG(1, 2))
+
+	// 价格只取少数几个值，保证有大量相同价格的图书，分块会多次拆分
+	books := make([]*pb.Book, 5000)
+	for i := range books {
+		books[i] = &pb.Book{Title: fmt.Sprintf("图书%d", i), Author: "作者", Price: proto.Float32(float32(rng.IntN(50) + 1))}
+	}
+	ids := server.loadBooks(books)
+
+	for i := 0; i < 3000; i++ {
+		id := ids[rng.IntN(len(ids))]
+		if rng.IntN(3) == 0 {
+			server.DeleteBook(ctx, &pb.DeleteBookRequest{Id: id})
+			continue
+		}
+		server.UpdateBook(ctx, &pb.UpdateBookRequest{Book: &pb.Book{
+			Id: id, Title: "图书", Author: "作者", Price: proto.Float32(float32(rng.IntN(60)+1) / 2),
+		}})
+	}
+
+	ranges := [][2]float32{{0, 0}, {1, 1}, {0, 100}, {10, 20}, {10.5, 10.5}, {29, 1000}, {0, float32(math.Inf(1))}}
+	for i := 0; i < 50; i++ {
+		low := float32(rng.IntN(60)) / 2
+		ranges = append(ranges, [2]float32{low, low + float32(rng.IntN(20))})
+	}
+	for _, r := range ranges {
+		resp, err := server.SearchBooksByPrice(ctx, &pb.SearchBooksByPriceRequest{MinPrice: r[0], MaxPrice: r[1]})
+		if err != nil {
+			t.Fatalf("按价格查询图书失败: %v", err)
+		}
+		got := make([]string, 0, len(resp.GetBooks()))
+		for _, book := range resp.GetBooks() {
+			got = append(got, book.GetId())
+		}
+		if want := scanPriceRange(t, server, r[0], r[1]); !slices.Equal(got, want) {
+			t.Errorf("价格区间 %v: 索引查询返回%d本图书，遍历返回%d本", r, len(got), len(want))
+		}
+	}
+}
+
+// TestPriceIndexNaN 测试价格为 NaN 的图书不进入索引，NaN 价格区间不匹配任何图书
+func TestPriceIndexNaN(t *testing.T) {
+	var idx priceIndex
+	idx.insert(float32(math.NaN()), "book-1")
+	idx.insert(10, "book-2")
+
+	var ids []string
+	idx.ascend(0, 100, func(id string) { ids = append(ids, id) })
+	idx.ascend(float32(math.NaN()), 100, func(id string) { ids = append(ids, id) })
+	if !slices.Equal(ids, []string{"book-2"}) {
+		t.Errorf("期望只返回 book-2，实际为: %v", ids)
+	}
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"context"
+
+	// 导入生成的protobuf代码
+	pb "grpc-basic-server/pb"
+
+	// 导入gRPC相关包
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// maxPriceRanges 单次请求允许的最大价格区间数量
+const maxPriceRanges = 100
+
+// SearchBooksByPriceRanges 一次遍历图书存储，按多个价格区间分别返回匹配的图书
+func (s *BookServer) SearchBooksByPriceRanges(ctx context.Context, req *pb.SearchBooksByPriceRangesRequest) (*pb.SearchBooksByPriceRangesResponse, error) {
+	// 记录请求日志
+	s.logger.Info("收到按多个价格区间查询图书请求", "ranges", len(req.GetRanges()))
+
+	// 验证每个价格区间
+	ranges := req.GetRanges()
+	if len(ranges) == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "价格区间不能为空")
+	}
+	if len(ranges) > maxPriceRanges {
+		return nil, status.Errorf(codes.InvalidArgument, "价格区间数量不能超过%d", maxPriceRanges)
+	}
+	for i, r := range ranges {
+		if !isFinite(r.GetMinPrice()) || !isFinite(r.GetMaxPrice()) {
+			return nil, status.Errorf(codes.InvalidArgument, "第%d个区间的价格必须是有效数字", i+1)
+		}
+		if r.GetMinPrice() < 0 {
+			return nil, status.Errorf(codes.InvalidArgument, "第%d个区间的最低价格不能为负数", i+1)
+		}
+		if r.GetMaxPrice() < r.GetMinPrice() {
+			return nil, status.Errorf(codes.InvalidArgument, "第%d个区间的最高价格不能小于最低价格", i+1)
+		}
+	}
+
+	// 获取按ID排序的图书，结果中的图书因此保持ID顺序
+	books, err := s.booksForRead(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]*pb.RangeResult, len(ranges))
+	for i, r := range ranges {
+		results[i] = &pb.RangeResult{Range: r}
+	}
+
+	// 只遍历一次图书，将每本图书放入所有包含其价格的区间
+	matched := 0
+	for _, book := range books {
+		price := book.GetPrice()
+		for i, r := range ranges {
+			if price < r.GetMinPrice() || price > r.GetMaxPrice() {
+				continue
+			}
+			results[i].Count++
+			matched++
+			if !req.GetCountsOnly() {
+				results[i].Books = append(results[i].Books, book)
+			}
+		}
+	}
+	recordScan(ctx, len(books), matched)
+
+	s.logger.Info("按多个价格区间查询完成", "ranges", len(results))
+
+	return &pb.SearchBooksByPriceRangesResponse{
+		Results: results,
+	}, nil
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	// 导入生成的protobuf代码
+	pb "grpc-basic-server/pb"
+
+	// 导入gRPC相关包
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// TestSearchBooksByPriceRanges 测试按三个价格区间分组
+func TestSearchBooksByPriceRanges(t *testing.T) {
+	// 创建服务器实例
+	server := NewBookServer()
+	ids := server.loadBooks([]*pb.Book{
+		{Title: "图书1", Author: "作者", Price: proto.Float32(5)},
+		{Title: "图书2", Author: "作者", Price: proto.Float32(15)},
+		{Title: "图书3", Author: "作者", Price: proto.Float32(20)},
+		{Title: "图书4", Author: "作者", Price: proto.Float32(35)},
+		{Title: "图书5", Author: "作者", Price: proto.Float32(80)},
+	})
+
+	resp, err := server.SearchBooksByPriceRanges(context.Background(), &pb.SearchBooksByPriceRangesRequest{
+		Ranges: []*pb.PriceRange{
+			{MinPrice: 0, MaxPrice: 10},
+			{MinPrice: 10, MaxPrice: 20},
+			{MinPrice: 20, MaxPrice: 50},
+		},
+	})
+	if err != nil {
+		t.Fatalf("按价格区间查询失败: %v", err)
+	}
+
+	// 区间包含两端，价格为20的图书同时属于第二和第三个区间；价格为80的图书不属于任何区间
+	expected := [][]string{
+		{ids[0]},
+		{ids[1], ids[2]},
+		{ids[2], ids[3]},
+	}
+	if len(resp.Results) != len(expected) {
+		t.Fatalf("期望返回%d个区间结果，实际为: %d", len(expected), len(resp.Results))
+	}
+	for i, result := range resp.Results {
+		var got []string
+		for _, book := range result.Books {
+			got = append(got, book.GetId())
+		}
+		if !equalIDs(got, expected[i]) || int(result.Count) != len(expected[i]) {
+			t.Errorf("第%d个区间期望为%v，实际为: %v（数量 %d）", i+1, expected[i], got, result.Count)
+		}
+	}
+}
+
+// TestSearchBooksByPriceRangesCountsOnly 测试只返回数量
+func TestSearchBooksByPriceRangesCountsOnly(t *testing.T) {
+	server := NewBookServer()
+	server.loadBooks([]*pb.Book{
+		{Title: "图书1", Author: "作者", Price: proto.Float32(5)},
+		{Title: "图书2", Author: "作者", Price: proto.Float32(15)},
+	})
+
+	resp, err := server.SearchBooksByPriceRanges(context.Background(), &pb.SearchBooksByPriceRangesRequest{
+		Ranges:     []*pb.PriceRange{{MinPrice: 0, MaxPrice: 100}},
+		CountsOnly: true,
+	})
+	if err != nil {
+		t.Fatalf("按价格区间查询失败: %v", err)
+	}
+	if resp.Results[0].Count != 2 || len(resp.Results[0].Books) != 0 {
+		t.Errorf("期望只返回数量2，实际为: %v", resp.Results[0])
+	}
+}
+
+// TestSearchBooksByPriceRangesInvalid 测试无效的价格区间
+func TestSearchBooksByPriceRangesInvalid(t *testing.T) {
+	server := NewBookServer()
+
+	tests := []struct {
+		name   string
+		ranges []*pb.PriceRange
+	}{
+		{"空区间列表", nil},
+		{"负数价格", []*pb.PriceRange{{MinPrice: -1, MaxPrice: 10}}},
+		{"最高价格小于最低价格", []*pb.PriceRange{{MinPrice: 0, MaxPrice: 10}, {MinPrice: 20, MaxPrice: 10}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := server.SearchBooksByPriceRanges(context.Background(), &pb.SearchBooksByPriceRangesRequest{Ranges: tt.ranges})
+			if status.Code(err) != codes.InvalidArgument {
+				t.Errorf("期望错误码为InvalidArgument，实际为: %v", status.Code(err))
+			}
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"context"
+	"sort"
+
+	// 导入生成的protobuf代码
+	pb "grpc-basic-server/pb"
+)
+
+// GetPriceStats 统计图书价格（数量、最低、最高、平均、中位数）
+func (s *BookServer) GetPriceStats(ctx context.Context, req *pb.GetPriceStatsRequest) (*pb.PriceStatsResponse, error) {
+	// 记录请求日志
+	s.logger.Info("收到价格统计请求", "filter", req.GetFilter())
+
+	// 验证过滤条件
+	if err := validateFilter(req.GetFilter()); err != nil {
+		return nil, err
+	}
+
+	// 在读锁内只复制价格，排序等计算放到锁外进行
+	s.mu.RLock()
+	catalog := s.catalogFor(ctx, false)
+	books := catalog.candidates(req.GetFilter())
+	prices := make([]float32, 0, len(books))
+	for _, book := range books {
+		if matchFilter(book, req.GetFilter()) && s.validForRead(book) {
+			prices = append(prices, book.GetPrice())
+		}
+	}
+	s.mu.RUnlock()
+
+	// 没有图书时返回全零结果，避免除以零
+	if len(prices) == 0 {
+		s.logger.Info("价格统计完成，没有符合条件的图书")
+		return &pb.PriceStatsResponse{}, nil
+	}
+
+	sort.Slice(prices, func(i, j int) bool { return prices[i] < prices[j] })
+
+	// 使用 float64 累加，减少精度损失
+	var sum float64
+	for _, price := range prices {
+		sum += float64(price)
+	}
+
+	n := len(prices)
+	median := prices[n/2]
+	if n%2 == 0 {
+		median = (prices[n/2-1] + prices[n/2]) / 2
+	}
+
+	s.logger.Info("价格统计完成", "count", n)
+
+	return &pb.PriceStatsResponse{
+		Count:  int32(n),
+		Min:    prices[0],
+		Max:    prices[n-1],
+		Avg:    float32(sum / float64(n)),
+		Median: median,
+	}, nil
+}
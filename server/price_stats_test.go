@@ -0,0 +1,80 @@
+package main
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	// 导入生成的protobuf代码
+	pb "grpc-basic-server/pb"
+
+	// 导入gRPC相关包
+	"google.golang.org/protobuf/proto"
+)
+
+// floatEquals 比较两个价格是否近似相等
+func floatEquals(a, b float32) bool {
+	return math.Abs(float64(a-b)) < 0.001
+}
+
+// TestGetPriceStats 测试价格统计功能
+func TestGetPriceStats(t *testing.T) {
+	// 创建服务器实例
+	server := NewBookServer()
+
+	// 创建不同价格的图书
+	books := []*pb.Book{
+		{Title: "图书1", Author: "作者1", Price: proto.Float32(10), PublishYear: proto.Int32(2021)},
+		{Title: "图书2", Author: "作者1", Price: proto.Float32(20), PublishYear: proto.Int32(2022)},
+		{Title: "图书3", Author: "作者2", Price: proto.Float32(30), PublishYear: proto.Int32(2023)},
+		{Title: "图书4", Author: "作者2", Price: proto.Float32(60), PublishYear: proto.Int32(2024)},
+	}
+	for _, book := range books {
+		if _, err := server.CreateBook(context.Background(), &pb.CreateBookRequest{Book: book}); err != nil {
+			t.Fatalf("创建图书失败: %v", err)
+		}
+	}
+
+	// 统计所有图书
+	resp, err := server.GetPriceStats(context.Background(), &pb.GetPriceStatsRequest{})
+	if err != nil {
+		t.Fatalf("价格统计失败: %v", err)
+	}
+	if resp.Count != 4 {
+		t.Errorf("期望数量为4，实际为: %d", resp.Count)
+	}
+	if !floatEquals(resp.Min, 10) || !floatEquals(resp.Max, 60) {
+		t.Errorf("最低/最高价格不正确，实际为: %.2f/%.2f", resp.Min, resp.Max)
+	}
+	if !floatEquals(resp.Avg, 30) {
+		t.Errorf("期望平均价格为30，实际为: %.2f", resp.Avg)
+	}
+	if !floatEquals(resp.Median, 25) {
+		t.Errorf("期望中位数为25，实际为: %.2f", resp.Median)
+	}
+
+	// 按作者过滤后统计
+	resp, err = server.GetPriceStats(context.Background(), &pb.GetPriceStatsRequest{
+		Filter: &pb.BookFilter{Author: "作者2"},
+	})
+	if err != nil {
+		t.Fatalf("价格统计失败: %v", err)
+	}
+	if resp.Count != 2 || !floatEquals(resp.Median, 45) {
+		t.Errorf("过滤后统计不正确，数量: %d, 中位数: %.2f", resp.Count, resp.Median)
+	}
+}
+
+// TestGetPriceStatsEmpty 测试空存储时的价格统计
+func TestGetPriceStatsEmpty(t *testing.T) {
+	// 创建服务器实例
+	server := NewBookServer()
+
+	resp, err := server.GetPriceStats(context.Background(), &pb.GetPriceStatsRequest{})
+	if err != nil {
+		t.Fatalf("价格统计失败: %v", err)
+	}
+	if resp.Count != 0 || resp.Min != 0 || resp.Max != 0 || resp.Avg != 0 || resp.Median != 0 {
+		t.Errorf("空存储应返回全零结果，实际为: %v", resp)
+	}
+}
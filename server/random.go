@@ -0,0 +1,52 @@
+package main
+
+import (
+	"math/rand/v2"
+	"sync"
+)
+
+// lockedRand 可并发使用的随机数生成器，GetRandomBook 和日志采样等随机行为都从这里取随机数。
+// 设置种子后随机序列可重复，用于测试和演示
+type lockedRand struct {
+	mu sync.Mutex
+	r  *rand.Rand
+}
+
+// newLockedRand 使用随机种子创建随机数生成器，种子取自启动时随机初始化的全局随机源
+func newLockedRand() *lockedRand {
+	return newSeededRand(rand.Uint64(), rand.Uint64())
+}
+
+// newSeededRand 使用固定种子创建随机数生成器
+func newSeededRand(seed1, seed2 uint64) *lockedRand {
+	return &lockedRand{r: rand.New(rand.NewPCG(seed1, seed2))}
+}
+
+// WithRandSeed 使用固定种子初始化服务端的随机源，相同的存储和调用顺序下随机结果可重复
+func WithRandSeed(seed uint64) ServerOption {
+	return func(s *BookServer) {
+		s.random = newSeededRand(seed, seed)
+	}
+}
+
+// randFloat64 从 r 取 [0, 1) 内的随机浮点数，r 为 nil 时使用全局随机源
+func randFloat64(r *lockedRand) float64 {
+	if r == nil {
+		return rand.Float64()
+	}
+	return r.Float64()
+}
+
+// IntN 返回 [0, n) 内的随机整数，n 必须大于0
+func (l *lockedRand) IntN(n int) int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.r.IntN(n)
+}
+
+// Float64 返回 [0, 1) 内的随机浮点数
+func (l *lockedRand) Float64() float64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.r.Float64()
+}
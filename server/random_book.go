@@ -0,0 +1,49 @@
+package main
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	// 导入生成的protobuf代码
+	pb "grpc-basic-server/pb"
+
+	// 导入gRPC相关包
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// GetRandomBook 从所有图书（或符合过滤条件的图书）中等概率随机返回一本
+func (s *BookServer) GetRandomBook(ctx context.Context, req *pb.GetRandomBookRequest) (*pb.GetRandomBookResponse, error) {
+	// 记录请求日志
+	s.logger.Info("收到随机获取图书请求", "filter", req.GetFilter())
+
+	// 验证过滤条件
+	if err := validateFilter(req.GetFilter()); err != nil {
+		return nil, err
+	}
+
+	// 加读锁保护并发访问，先收集所有候选图书再选择，保证每本被选中的概率相同
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	catalog := s.catalogFor(ctx, false)
+	books := catalog.candidates(req.GetFilter())
+	candidates := make([]*pb.Book, 0, len(books))
+	for _, book := range books {
+		if matchFilter(book, req.GetFilter()) && s.validForRead(book) {
+			candidates = append(candidates, book)
+		}
+	}
+	if len(candidates) == 0 {
+		return nil, status.Errorf(codes.NotFound, "没有符合条件的图书")
+	}
+
+	// 候选图书来自 map，先按ID排序使固定种子下的结果可重复；IntN 不存在取模偏差
+	slices.SortFunc(candidates, func(a, b *pb.Book) int { return strings.Compare(a.GetId(), b.GetId()) })
+	book := candidates[s.random.IntN(len(candidates))]
+
+	s.logger.Info("随机选中图书", "id", book.GetId())
+
+	return &pb.GetRandomBookResponse{Book: book}, nil
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	// 导入生成的protobuf代码
+	pb "grpc-basic-server/pb"
+
+	// 导入gRPC相关包
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// TestGetRandomBook 测试随机返回的图书来自存储，并且多次调用能覆盖所有候选图书
+func TestGetRandomBook(t *testing.T) {
+	// 创建服务器实例
+	server := NewBookServer()
+	ids := server.loadBooks([]*pb.Book{
+		{Title: "便宜图书", Author: "作者1", Price: proto.Float32(10)},
+		{Title: "中等图书", Author: "作者2", Price: proto.Float32(30)},
+		{Title: "昂贵图书", Author: "作者3", Price: proto.Float32(100)},
+	})
+
+	seen := make(map[string]bool)
+	for i := 0; i < 200; i++ {
+		resp, err := server.GetRandomBook(context.Background(), &pb.GetRandomBookRequest{})
+		if err != nil {
+			t.Fatalf("随机获取图书失败: %v", err)
+		}
+		if _, exists := server.books[resp.GetBook().GetId()]; !exists {
+			t.Fatalf("返回的图书不在存储中: %v", resp.GetBook())
+		}
+		seen[resp.GetBook().GetId()] = true
+	}
+	if len(seen) != len(ids) {
+		t.Errorf("期望多次调用覆盖所有图书，实际只返回了: %v", seen)
+	}
+
+	// 过滤后只剩一本图书
+	resp, err := server.GetRandomBook(context.Background(), &pb.GetRandomBookRequest{Filter: &pb.BookFilter{MinPrice: 50}})
+	if err != nil {
+		t.Fatalf("随机获取图书失败: %v", err)
+	}
+	if resp.GetBook().GetId() != ids[2] {
+		t.Errorf("期望返回 %s，实际为: %s", ids[2], resp.GetBook().GetId())
+	}
+}
+
+// TestGetRandomBookEmpty 测试存储为空时返回 NotFound
+func TestGetRandomBookEmpty(t *testing.T) {
+	_, err := NewBookServer().GetRandomBook(context.Background(), &pb.GetRandomBookRequest{})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("期望返回 NotFound，实际为: %v", err)
+	}
+}
+
+// TestGetRandomBookSeeded 测试固定种子下相同存储上连续调用返回相同的随机序列
+func TestGetRandomBookSeeded(t *testing.T) {
+	books := []*pb.Book{
+		{Title: "图书1", Author: "作者", Price: proto.Float32(10)},
+		{Title: "图书2", Author: "作者", Price: proto.Float32(20)},
+		{Title: "图书3", Author: "作者", Price: proto.Float32(30)},
+		{Title: "图书4", Author: "作者", Price: proto.Float32(40)},
+		{Title: "图书5", Author: "作者", Price: proto.Float32(50)},
+	}
+	sequence := func(cfg *config) []string {
+		t.Helper()
+		client, server := startTestServer(t, cfg)
+		server.loadBooks(books)
+
+		var ids []string
+		for i := 0; i < 20; i++ {
+			resp, err := client.GetRandomBook(context.Background(), &pb.GetRandomBookRequest{})
+			if err != nil {
+				t.Fatalf("随机获取图书失败: %v", err)
+			}
+			ids = append(ids, resp.GetBook().GetId())
+		}
+		return ids
+	}
+
+	first := sequence(mustParseConfig(t, "-rand-seed", "42"))
+	if second := sequence(mustParseConfig(t, "-rand-seed", "42")); !slices.Equal(first, second) {
+		t.Errorf("相同种子期望相同的随机序列，实际为: %v 和 %v", first, second)
+	}
+	if other := sequence(mustParseConfig(t, "-rand-seed", "7")); slices.Equal(first, other) {
+		t.Errorf("不同种子期望不同的随机序列，实际都为: %v", first)
+	}
+	if _, err := parseConfig([]string{"-rand-seed", "abc"}); err == nil {
+		t.Error("期望无效的随机种子解析失败")
+	}
+}
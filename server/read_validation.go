@@ -0,0 +1,76 @@
+package main
+
+import (
+	"fmt"
+
+	// 导入生成的protobuf代码
+	pb "grpc-basic-server/pb"
+
+	// 导入gRPC相关包
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// readValidationPolicy 读取图书时的校验策略，防止外部导入的无效数据被返回给客户端
+type readValidationPolicy string
+
+const (
+	// readValidationOff 不校验（默认）
+	readValidationOff readValidationPolicy = "off"
+
+	// readValidationLog 校验并记录无效图书，但仍然返回
+	readValidationLog readValidationPolicy = "log"
+
+	// readValidationSkip 校验并跳过无效图书，单本查询返回 DataLoss
+	readValidationSkip readValidationPolicy = "skip"
+)
+
+// parseReadValidation 解析读取校验策略
+func parseReadValidation(value string) (readValidationPolicy, error) {
+	switch policy := readValidationPolicy(value); policy {
+	case readValidationOff, readValidationLog, readValidationSkip:
+		return policy, nil
+	default:
+		return "", fmt.Errorf("无效的读取校验策略: %s（可选 off、log、skip）", value)
+	}
+}
+
+// WithReadValidation 设置读取图书时的校验策略
+func WithReadValidation(policy readValidationPolicy) ServerOption {
+	return func(s *BookServer) {
+		s.readValidation = policy
+	}
+}
+
+// validForRead 按读取校验策略检查存储中的图书，返回 false 表示应跳过该图书
+func (s *BookServer) validForRead(book *pb.Book) bool {
+	if s.readValidation == readValidationOff || s.readValidation == "" {
+		return true
+	}
+
+	err := validateBook(book)
+	if err == nil && book.GetId() == "" {
+		err = status.Errorf(codes.InvalidArgument, "图书ID不能为空")
+	}
+	if err == nil {
+		return true
+	}
+
+	s.logger.Warn("存储中的图书无效", "id", book.GetId(), "error", status.Convert(err).Message())
+	return s.readValidation != readValidationSkip
+}
+
+// filterForRead 按读取校验策略过滤图书列表，不修改原列表
+func (s *BookServer) filterForRead(books []*pb.Book) []*pb.Book {
+	if s.readValidation == readValidationOff || s.readValidation == "" {
+		return books
+	}
+
+	valid := make([]*pb.Book, 0, len(books))
+	for _, book := range books {
+		if s.validForRead(book) {
+			valid = append(valid, book)
+		}
+	}
+	return valid
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	// 导入生成的protobuf代码
+	pb "grpc-basic-server/pb"
+
+	// 导入gRPC相关包
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// newServerWithInvalidBook 创建服务器并直接向存储注入一本无效图书（价格为负数），返回其ID
+func newServerWithInvalidBook(t *testing.T, opts ...ServerOption) (*BookServer, string) {
+	t.Helper()
+
+	server := NewBookServer(append([]ServerOption{WithLogger(&captureLogger{})}, opts...)...)
+	server.loadBooks([]*pb.Book{{Title: "有效图书", Author: "作者", Price: proto.Float32(10)}})
+	ids := server.loadBooks([]*pb.Book{{Title: "无效图书", Author: "作者", Price: proto.Float32(-1)}})
+	return server, ids[0]
+}
+
+// TestReadValidationSkip 测试 skip 策略下读取时跳过无效图书
+func TestReadValidationSkip(t *testing.T) {
+	server, invalidID := newServerWithInvalidBook(t, WithReadValidation(readValidationSkip))
+	ctx := context.Background()
+
+	resp, err := server.ListBooks(ctx, &pb.ListBooksRequest{Page: 1, PageSize: 10})
+	if err != nil {
+		t.Fatalf("列出图书失败: %v", err)
+	}
+	if len(resp.Books) != 1 || resp.Books[0].GetId() == invalidID {
+		t.Errorf("期望只返回有效图书，实际为: %v", resp.Books)
+	}
+
+	_, err = server.GetBook(ctx, &pb.GetBookRequest{Id: invalidID})
+	if status.Code(err) != codes.DataLoss {
+		t.Errorf("期望错误码为DataLoss，实际为: %v", status.Code(err))
+	}
+}
+
+// TestReadValidationLog 测试 log 策略下记录无效图书但仍然返回
+func TestReadValidationLog(t *testing.T) {
+	server, invalidID := newServerWithInvalidBook(t, WithReadValidation(readValidationLog))
+
+	resp, err := server.ListBooks(context.Background(), &pb.ListBooksRequest{Page: 1, PageSize: 10})
+	if err != nil {
+		t.Fatalf("列出图书失败: %v", err)
+	}
+	if len(resp.Books) != 2 {
+		t.Errorf("期望返回2本图书，实际为: %d", len(resp.Books))
+	}
+	if logger := server.logger.(*captureLogger); !logger.contains("存储中的图书无效 id=" + invalidID) {
+		t.Errorf("期望记录无效图书，实际为: %v", logger.lines)
+	}
+}
+
+// TestReadValidationOff 测试默认不校验
+func TestReadValidationOff(t *testing.T) {
+	server, invalidID := newServerWithInvalidBook(t)
+
+	if _, err := server.GetBook(context.Background(), &pb.GetBookRequest{Id: invalidID}); err != nil {
+		t.Errorf("默认不校验时应返回图书，实际错误为: %v", err)
+	}
+}
+
+// TestParseReadValidation 测试解析读取校验策略
+func TestParseReadValidation(t *testing.T) {
+	for _, value := range []string{"off", "log", "skip"} {
+		if _, err := parseReadValidation(value); err != nil {
+			t.Errorf("策略 %s 应有效: %v", value, err)
+		}
+	}
+	if _, err := parseReadValidation("strict"); err == nil {
+		t.Errorf("期望无效策略返回错误")
+	}
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"context"
+	"strings"
+
+	// 导入生成的protobuf代码
+	pb "grpc-basic-server/pb"
+	pbv2 "grpc-basic-server/pb/v2"
+
+	// 导入gRPC相关包
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// readinessExemptMethods 不检查存储可用性的方法：运行状态不读取存储，存储不可用时也要能查看
+var readinessExemptMethods = map[string]bool{
+	"/" + pb.BookService_ServiceDesc.ServiceName + "/GetStats": true,
+}
+
+// needsStore 判断方法是否需要访问存储：图书服务（v1 和 v2）中除运行状态外的方法，
+// 健康检查等其他服务不需要
+func needsStore(fullMethod string) bool {
+	if readinessExemptMethods[fullMethod] {
+		return false
+	}
+	for _, service := range []string{pb.BookService_ServiceDesc.ServiceName, pbv2.BookServiceV2_ServiceDesc.ServiceName} {
+		if strings.HasPrefix(fullMethod, "/"+service+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// checkReady 需要访问存储的方法在存储不可用时返回 Unavailable
+func checkReady(ctx context.Context, store pinger, fullMethod string) error {
+	if !needsStore(fullMethod) {
+		return nil
+	}
+	if err := store.Ping(ctx); err != nil {
+		return status.Errorf(codes.Unavailable, "存储尚未就绪: %v", err)
+	}
+	return nil
+}
+
+// newReadinessInterceptor 创建存储可用性检查拦截器，存储的 Ping 失败时直接返回 Unavailable，
+// 避免请求进入处理器后得到难以理解的错误；store 为 nil（内存存储）时不检查
+func newReadinessInterceptor(store pinger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if store == nil {
+			return handler(ctx, req)
+		}
+		if err := checkReady(ctx, store, info.FullMethod); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// newReadinessStreamInterceptor 创建流式方法的存储可用性检查拦截器，规则与一元方法相同
+func newReadinessStreamInterceptor(store pinger) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if store == nil {
+			return handler(srv, ss)
+		}
+		if err := checkReady(ss.Context(), store, info.FullMethod); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
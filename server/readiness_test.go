@@ -0,0 +1,41 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	// 导入生成的protobuf代码
+	pb "grpc-basic-server/pb"
+
+	// 导入gRPC相关包
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// TestReadinessInterceptor 测试存储不可用时 GetBook 返回 Unavailable，
+// 运行状态和健康检查不受影响，存储恢复后请求正常处理
+func TestReadinessInterceptor(t *testing.T) {
+	store := &failingStore{}
+	store.failing.Store(true)
+
+	conn, _ := startTestConn(t, mustParseConfig(t), WithPinger(store))
+	client := pb.NewBookServiceClient(conn)
+
+	if _, err := client.GetBook(context.Background(), &pb.GetBookRequest{Id: "book-1"}); status.Code(err) != codes.Unavailable {
+		t.Errorf("期望错误码为Unavailable，实际为: %v", err)
+	}
+	if _, err := client.GetStats(context.Background(), &emptypb.Empty{}); err != nil {
+		t.Errorf("存储不可用时运行状态应能查询: %v", err)
+	}
+	if _, err := healthpb.NewHealthClient(conn).Check(context.Background(), &healthpb.HealthCheckRequest{}); err != nil {
+		t.Errorf("存储不可用时健康检查应能调用: %v", err)
+	}
+
+	// 存储恢复后请求进入处理器
+	store.failing.Store(false)
+	if _, err := client.GetBook(context.Background(), &pb.GetBookRequest{Id: "book-1"}); status.Code(err) != codes.NotFound {
+		t.Errorf("期望错误码为NotFound，实际为: %v", err)
+	}
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"context"
+	"runtime/debug"
+	"sync"
+
+	// 导入gRPC相关包
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// panicMetrics 按方法统计被恢复的 panic 次数
+type panicMetrics struct {
+	mu       sync.Mutex
+	byMethod map[string]int64
+}
+
+// inc 记录一次 panic
+func (m *panicMetrics) inc(method string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.byMethod == nil {
+		m.byMethod = make(map[string]int64)
+	}
+	m.byMethod[method]++
+}
+
+// snapshot 返回各方法 panic 次数的副本
+func (m *panicMetrics) snapshot() map[string]int64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	counts := make(map[string]int64, len(m.byMethod))
+	for method, n := range m.byMethod {
+		counts[method] = n
+	}
+	return counts
+}
+
+// recoveryInterceptor 恢复一元处理器中的 panic：记录调用栈、增加计数并返回 Internal，
+// 避免单个请求的错误导致整个进程退出
+func (s *BookServer) recoveryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = s.recovered(info.FullMethod, r)
+		}
+	}()
+	return handler(ctx, req)
+}
+
+// recoveryStreamInterceptor 恢复流式处理器中的 panic，规则与一元方法相同
+func (s *BookServer) recoveryStreamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = s.recovered(info.FullMethod, r)
+		}
+	}()
+	return handler(srv, ss)
+}
+
+// recovered 记录被恢复的 panic 并返回给客户端的错误，不向客户端暴露 panic 的内容
+func (s *BookServer) recovered(method string, r interface{}) error {
+	s.panics.inc(method)
+	s.logger.Error("处理请求时发生panic", "method", method, "panic", r, "stack", string(debug.Stack()))
+	return status.Errorf(codes.Internal, "服务器内部错误")
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	// 导入生成的protobuf代码
+	pb "grpc-basic-server/pb"
+
+	// 导入gRPC相关包
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// TestRecoveryInterceptor 测试处理器 panic 时返回 Internal，并按方法增加 panic 计数
+func TestRecoveryInterceptor(t *testing.T) {
+	logger := &captureLogger{}
+	server := NewBookServer(WithLogger(logger))
+
+	info := &grpc.UnaryServerInfo{FullMethod: "/bookstore.BookService/GetBook"}
+	panicking := func(ctx context.Context, req interface{}) (interface{}, error) {
+		panic("处理器出错")
+	}
+	for i := 0; i < 2; i++ {
+		_, err := server.recoveryInterceptor(context.Background(), nil, info, panicking)
+		if status.Code(err) != codes.Internal {
+			t.Fatalf("期望返回 Internal，实际为: %v", err)
+		}
+	}
+
+	resp, err := server.GetStats(context.Background(), &emptypb.Empty{})
+	if err != nil {
+		t.Fatalf("获取运行状态失败: %v", err)
+	}
+	if n := resp.GetPanicsTotal()[info.FullMethod]; n != 2 {
+		t.Errorf("期望 panic 计数为2，实际为: %d", n)
+	}
+	if !logger.contains("处理器出错") || !logger.contains("stack=") {
+		t.Errorf("期望记录 panic 内容和调用栈，实际为: %v", logger.lines)
+	}
+
+	// 正常的请求不影响计数
+	ok := func(ctx context.Context, req interface{}) (interface{}, error) { return "ok", nil }
+	if _, err := server.recoveryInterceptor(context.Background(), nil, info, ok); err != nil {
+		t.Errorf("正常请求不应返回错误: %v", err)
+	}
+	if n := server.panics.snapshot()[info.FullMethod]; n != 2 {
+		t.Errorf("正常请求后 panic 计数应保持为2，实际为: %d", n)
+	}
+}
+
+// panickingStreamDesc 测试用的流式服务：发送一本图书后 panic
+var panickingStreamDesc = grpc.ServiceDesc{
+	ServiceName: "test.Panicking",
+	HandlerType: (*interface{})(nil),
+	Streams: []grpc.StreamDesc{{
+		StreamName:    "Stream",
+		ServerStreams: true,
+		Handler: func(srv interface{}, stream grpc.ServerStream) error {
+			if err := stream.RecvMsg(&emptypb.Empty{}); err != nil {
+				return err
+			}
+			if err := stream.SendMsg(&pb.Book{Id: "book-1"}); err != nil {
+				return err
+			}
+			panic("流式处理器出错")
+		},
+	}},
+}
+
+// TestStreamRecovery 测试流式处理器在发送过程中 panic 时客户端收到 Internal，服务器继续正常工作
+func TestStreamRecovery(t *testing.T) {
+	silenceLog(t)
+	conn, bookServer := startTestConnWith(t, mustParseConfig(t), testHook{
+		register: func(s *grpc.Server) { s.RegisterService(&panickingStreamDesc, struct{}{}) },
+	})
+
+	ctx := context.Background()
+	stream, err := conn.NewStream(ctx, &panickingStreamDesc.Streams[0], "/test.Panicking/Stream")
+	if err != nil {
+		t.Fatalf("打开流失败: %v", err)
+	}
+	if err := stream.SendMsg(&emptypb.Empty{}); err != nil {
+		t.Fatalf("发送请求失败: %v", err)
+	}
+	stream.CloseSend()
+
+	// panic 之前发送的图书仍能收到，之后流以 Internal 结束
+	book := &pb.Book{}
+	if err := stream.RecvMsg(book); err != nil || book.GetId() != "book-1" {
+		t.Fatalf("期望先收到图书，实际为: %v, %v", book, err)
+	}
+	if err := stream.RecvMsg(book); status.Code(err) != codes.Internal {
+		t.Fatalf("期望返回 Internal，实际为: %v", err)
+	}
+
+	// 服务器继续正常处理请求，panic 被计数
+	client := pb.NewBookServiceClient(conn)
+	if _, err := client.CreateBook(ctx, &pb.CreateBookRequest{Book: &pb.Book{Title: "图书", Author: "作者", Price: proto.Float32(10)}}); err != nil {
+		t.Errorf("panic 后创建图书失败: %v", err)
+	}
+	if n := bookServer.panics.snapshot()["/test.Panicking/Stream"]; n != 1 {
+		t.Errorf("期望 panic 计数为1，实际为: %d", n)
+	}
+}
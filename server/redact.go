@@ -0,0 +1,85 @@
+package main
+
+import (
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// redactedValue 脱敏后替换的字符串
+const redactedValue = "***"
+
+// fieldRedactor 按字段路径（如 book.description）对 proto 消息进行脱敏
+type fieldRedactor struct {
+	paths [][]string
+}
+
+// newFieldRedactor 根据字段路径列表创建脱敏器，路径从消息根开始，使用 proto 字段名
+func newFieldRedactor(paths []string) *fieldRedactor {
+	r := &fieldRedactor{}
+	for _, path := range paths {
+		path = strings.TrimSpace(path)
+		if path == "" {
+			continue
+		}
+		r.paths = append(r.paths, strings.Split(path, "."))
+	}
+	return r
+}
+
+// redact 返回脱敏后的消息副本，原消息不会被修改
+func (r *fieldRedactor) redact(msg proto.Message) proto.Message {
+	if r == nil || len(r.paths) == 0 || msg == nil {
+		return msg
+	}
+
+	clone := proto.Clone(msg)
+	for _, path := range r.paths {
+		redactPath(clone.ProtoReflect(), path)
+	}
+	return clone
+}
+
+// redactPath 沿字段路径递归查找并脱敏，重复的消息字段会对每个元素生效
+func redactPath(m protoreflect.Message, path []string) {
+	fd := m.Descriptor().Fields().ByName(protoreflect.Name(path[0]))
+	if fd == nil || !m.Has(fd) {
+		return
+	}
+
+	// 到达路径末端，替换字段值
+	if len(path) == 1 {
+		redactField(m, fd)
+		return
+	}
+
+	// 继续深入子消息
+	if fd.Kind() != protoreflect.MessageKind || fd.IsMap() {
+		return
+	}
+	if fd.IsList() {
+		list := m.Mutable(fd).List()
+		for i := 0; i < list.Len(); i++ {
+			redactPath(list.Get(i).Message(), path[1:])
+		}
+		return
+	}
+	redactPath(m.Mutable(fd).Message(), path[1:])
+}
+
+// redactField 字符串字段替换为 ***，其他类型的字段直接清除
+func redactField(m protoreflect.Message, fd protoreflect.FieldDescriptor) {
+	if fd.Kind() != protoreflect.StringKind || fd.IsMap() {
+		m.Clear(fd)
+		return
+	}
+	if fd.IsList() {
+		list := m.Mutable(fd).List()
+		for i := 0; i < list.Len(); i++ {
+			list.Set(i, protoreflect.ValueOfString(redactedValue))
+		}
+		return
+	}
+	m.Set(fd, protoreflect.ValueOfString(redactedValue))
+}
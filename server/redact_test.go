@@ -0,0 +1,67 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"os"
+	"strings"
+	"testing"
+
+	// 导入生成的protobuf代码
+	pb "grpc-basic-server/pb"
+
+	// 导入gRPC相关包
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+)
+
+// captureLog 在 fn 执行期间捕获标准日志输出
+func captureLog(t *testing.T, fn func()) string {
+	t.Helper()
+
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	defer log.SetOutput(os.Stderr)
+
+	fn()
+	return buf.String()
+}
+
+// TestLogInterceptorRedact 测试日志拦截器对配置字段进行脱敏
+func TestLogInterceptorRedact(t *testing.T) {
+	// 创建服务器实例和带脱敏规则的日志拦截器
+	server := NewBookServer()
+	redactor := newFieldRedactor([]string{"book.description", "books.description"})
+	interceptor := newLogInterceptor(stdLogger{}, logInterceptorOptions{level: logLevelInfo, logPayloads: true, redactor: redactor})
+
+	req := &pb.CreateBookRequest{Book: &pb.Book{
+		Title:       "测试图书",
+		Author:      "测试作者",
+		Price:       proto.Float32(29.99),
+		Description: proto.String("机密描述"),
+	}}
+	info := &grpc.UnaryServerInfo{FullMethod: "/bookstore.BookService/CreateBook"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return server.CreateBook(ctx, req.(*pb.CreateBookRequest))
+	}
+
+	output := captureLog(t, func() {
+		if _, err := interceptor(context.Background(), req, info, handler); err != nil {
+			t.Fatalf("创建图书失败: %v", err)
+		}
+	})
+
+	// 验证日志中不包含敏感字段的值，但包含脱敏标记和其他字段
+	if strings.Contains(output, "机密描述") {
+		t.Errorf("日志中不应包含被脱敏的字段值: %s", output)
+	}
+	if !strings.Contains(output, redactedValue) || !strings.Contains(output, "测试图书") {
+		t.Errorf("日志中应包含脱敏标记和未脱敏字段: %s", output)
+	}
+
+	// 验证原请求没有被修改
+	if req.Book.GetDescription() != "机密描述" {
+		t.Errorf("脱敏不应修改原请求，实际描述为: %s", req.Book.GetDescription())
+	}
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"unicode/utf8"
+
+	// 导入生成的protobuf代码
+	pb "grpc-basic-server/pb"
+
+	// 导入gRPC相关包
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// RenameAuthor 将作者名与 from 匹配（不区分大小写）的图书统一改为 to，返回修改的图书数量
+func (s *BookServer) RenameAuthor(ctx context.Context, req *pb.RenameAuthorRequest) (*pb.RenameAuthorResponse, error) {
+	// 记录请求日志
+	s.logger.Info("收到重命名作者请求", "from", req.GetFrom(), "to", req.GetTo())
+
+	// 验证请求参数
+	if strings.TrimSpace(req.GetFrom()) == "" || strings.TrimSpace(req.GetTo()) == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "原作者名和新作者名不能为空")
+	}
+	if !utf8.ValidString(req.GetTo()) {
+		return nil, status.Errorf(codes.InvalidArgument, "新作者名包含无效的UTF-8字符")
+	}
+
+	// 加写锁，保证所有匹配图书在一次操作中完成修改
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.clock.Now()
+	resp := &pb.RenameAuthorResponse{}
+	catalog := s.catalogFor(ctx, false)
+	for _, book := range catalog.candidates(&pb.BookFilter{Author: req.GetFrom()}) {
+		// 作者名已经是新名称的图书不需要修改
+		if !strings.EqualFold(book.GetAuthor(), req.GetFrom()) || book.GetAuthor() == req.GetTo() {
+			continue
+		}
+
+		// 替换为新的副本，不原地修改已存储的图书
+		updated := proto.Clone(book).(*pb.Book)
+		updated.Author = req.GetTo()
+		catalog.put(updated, now)
+		resp.UpdatedCount++
+	}
+
+	s.logger.Info("重命名作者完成", "updated", resp.UpdatedCount)
+
+	return resp, nil
+}
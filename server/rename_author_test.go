@@ -0,0 +1,47 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	// 导入生成的protobuf代码
+	pb "grpc-basic-server/pb"
+
+	// 导入gRPC相关包
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// TestRenameAuthor 测试将两种写法的作者名合并为一种
+func TestRenameAuthor(t *testing.T) {
+	// 创建服务器实例
+	server := NewBookServer()
+	ids := server.loadBooks([]*pb.Book{
+		{Title: "图书1", Author: "R. Martin", Price: proto.Float32(10)},
+		{Title: "图书2", Author: "r. martin", Price: proto.Float32(20)},
+		{Title: "图书3", Author: "Robert C. Martin", Price: proto.Float32(30)},
+		{Title: "图书4", Author: "R. Martinez", Price: proto.Float32(40)},
+	})
+
+	resp, err := server.RenameAuthor(context.Background(), &pb.RenameAuthorRequest{From: "R. MARTIN", To: "Robert C. Martin"})
+	if err != nil {
+		t.Fatalf("重命名作者失败: %v", err)
+	}
+	if resp.GetUpdatedCount() != 2 {
+		t.Errorf("期望修改2本图书，实际为: %d", resp.GetUpdatedCount())
+	}
+
+	// 验证修改后的作者，不完全匹配的作者保持不变
+	expected := []string{"Robert C. Martin", "Robert C. Martin", "Robert C. Martin", "R. Martinez"}
+	for i, id := range ids {
+		if author := server.books[id].GetAuthor(); author != expected[i] {
+			t.Errorf("图书 %s 期望作者为 %s，实际为: %s", id, expected[i], author)
+		}
+	}
+
+	_, err = server.RenameAuthor(context.Background(), &pb.RenameAuthorRequest{From: "R. Martin", To: " "})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("期望新作者名为空时返回 InvalidArgument，实际为: %v", err)
+	}
+}
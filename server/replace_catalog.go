@@ -0,0 +1,121 @@
+package main
+
+import (
+	"io"
+
+	// 导入生成的protobuf代码
+	pb "grpc-basic-server/pb"
+
+	// 导入gRPC相关包
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// ReplaceCatalog 用客户端发送的完整目录替换调用方租户的图书。
+// 图书按规范化的标题和作者（与 FindDuplicates 相同）匹配已有图书：匹配到的图书内容有变化时更新，
+// 未匹配到的新建，新目录中没有的已有图书被删除。先接收并检查所有图书，再在一次写锁内完成所有修改，
+// 任何一本图书不合法时不做任何修改
+func (s *BookServer) ReplaceCatalog(stream grpc.ClientStreamingServer[pb.Book, pb.ReplaceCatalogResponse]) error {
+	ctx := stream.Context()
+
+	// 记录请求日志
+	s.logger.Info("收到全量替换图书目录请求")
+
+	// 接收并验证所有图书，新目录中的标题和作者不能重复
+	var incoming []*pb.Book
+	keys := make(map[string]bool)
+	for {
+		book, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if err := validateBook(book); err != nil {
+			return status.Errorf(codes.InvalidArgument, "第%d本图书无效: %s", len(incoming)+1, status.Convert(err).Message())
+		}
+		s.sanitizeBook(book)
+		key := duplicateKey(book, pb.DuplicateStrategy_DUPLICATE_STRATEGY_TITLE_AUTHOR)
+		if keys[key] {
+			return status.Errorf(codes.InvalidArgument, "第%d本图书的标题和作者与之前的图书重复: %s", len(incoming)+1, book.GetTitle())
+		}
+		keys[key] = true
+		incoming = append(incoming, book)
+	}
+
+	// 加写锁，保证计算差异和应用修改是原子操作
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	catalog := s.catalogFor(ctx, true)
+
+	// 按ID顺序为每个键选出一本已有图书，同一个键的其余图书视为不在新目录中
+	existing := make([]*pb.Book, 0, len(catalog.books))
+	for _, book := range catalog.books {
+		existing = append(existing, book)
+	}
+	sortBooksByID(existing)
+	byKey := make(map[string]*pb.Book, len(existing))
+	for _, book := range existing {
+		key := duplicateKey(book, pb.DuplicateStrategy_DUPLICATE_STRATEGY_TITLE_AUTHOR)
+		if _, exists := byKey[key]; !exists {
+			byKey[key] = book
+		}
+	}
+
+	// 先计算所有修改并检查不可修改的字段，全部通过后再应用
+	var creates, updates []*pb.Book
+	kept := make(map[string]bool, len(incoming))
+	for _, book := range incoming {
+		book.Featured = false
+		book.FeaturedRank = 0
+
+		old, exists := byKey[duplicateKey(book, pb.DuplicateStrategy_DUPLICATE_STRATEGY_TITLE_AUTHOR)]
+		if !exists {
+			creates = append(creates, book)
+			continue
+		}
+		kept[old.GetId()] = true
+
+		// 保留原有的ID、推荐状态和库存，与 UpdateBook 的规则相同；变更序号由服务端维护，比较前同样保留
+		book.Id = old.GetId()
+		book.Featured = old.GetFeatured()
+		book.FeaturedRank = old.GetFeaturedRank()
+		book.Stock = old.GetStock()
+		book.Currency = resolveCurrency(book.GetCurrency(), old.GetCurrency())
+		book.LastModifiedSeq = old.GetLastModifiedSeq()
+		if err := s.checkImmutableFields(old, book); err != nil {
+			s.logger.Warn("试图修改不可修改的字段", "id", old.GetId(), "error", err)
+			return err
+		}
+		if !proto.Equal(old, book) {
+			updates = append(updates, book)
+		}
+	}
+
+	now := s.clock.Now()
+	resp := &pb.ReplaceCatalogResponse{}
+	for _, book := range existing {
+		if !kept[book.GetId()] {
+			catalog.remove(book.GetId())
+			resp.Deleted++
+		}
+	}
+	for _, book := range updates {
+		catalog.put(book, now)
+		resp.Updated++
+	}
+	for _, book := range creates {
+		book.Id = catalog.generateID()
+		s.defaults.apply(book, now)
+		catalog.put(book, now)
+		resp.Created++
+	}
+
+	s.logger.Info("全量替换图书目录完成", "created", resp.Created, "updated", resp.Updated, "deleted", resp.Deleted)
+
+	return stream.SendAndClose(resp)
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	// 导入生成的protobuf代码
+	pb "grpc-basic-server/pb"
+
+	// 导入gRPC相关包
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// replaceCatalog 通过客户端流发送完整目录
+func replaceCatalog(t *testing.T, client pb.BookServiceClient, books []*pb.Book) (*pb.ReplaceCatalogResponse, error) {
+	t.Helper()
+
+	stream, err := client.ReplaceCatalog(context.Background())
+	if err != nil {
+		t.Fatalf("打开替换目录流失败: %v", err)
+	}
+	for _, book := range books {
+		if err := stream.Send(book); err != nil {
+			t.Fatalf("发送图书失败: %v", err)
+		}
+	}
+	return stream.CloseAndRecv()
+}
+
+// TestReplaceCatalog 测试用两本图书的目录替换三本图书的目录：一本新建、一本更新、两本删除
+func TestReplaceCatalog(t *testing.T) {
+	client, server := startTestServer(t, mustParseConfig(t))
+	ids := server.loadBooks([]*pb.Book{
+		{Title: "Go语言编程", Author: "作者1", Price: proto.Float32(10)},
+		{Title: "gRPC实战", Author: "作者2", Price: proto.Float32(20)},
+		{Title: "数据库原理", Author: "作者3", Price: proto.Float32(30)},
+	})
+
+	resp, err := replaceCatalog(t, client, []*pb.Book{
+		{Title: "go语言编程", Author: "作者1", Price: proto.Float32(15)},
+		{Title: "分布式系统", Author: "作者4", Price: proto.Float32(40)},
+	})
+	if err != nil {
+		t.Fatalf("替换目录失败: %v", err)
+	}
+	if resp.GetCreated() != 1 || resp.GetUpdated() != 1 || resp.GetDeleted() != 2 {
+		t.Errorf("期望新建1本、更新1本、删除2本，实际为: %v", resp)
+	}
+
+	// 匹配到的图书保留原来的ID
+	got, err := client.GetBook(context.Background(), &pb.GetBookRequest{Id: ids[0]})
+	if err != nil {
+		t.Fatalf("获取更新后的图书失败: %v", err)
+	}
+	if got.GetBook().GetPrice() != 15 {
+		t.Errorf("期望价格更新为15，实际为: %v", got.GetBook().GetPrice())
+	}
+	for _, id := range ids[1:] {
+		if _, err := client.GetBook(context.Background(), &pb.GetBookRequest{Id: id}); status.Code(err) != codes.NotFound {
+			t.Errorf("期望图书 %s 已被删除，实际为: %v", id, err)
+		}
+	}
+
+	// 再次发送相同的目录不产生任何修改
+	resp, err = replaceCatalog(t, client, []*pb.Book{
+		{Title: "go语言编程", Author: "作者1", Price: proto.Float32(15)},
+		{Title: "分布式系统", Author: "作者4", Price: proto.Float32(40)},
+	})
+	if err != nil {
+		t.Fatalf("替换目录失败: %v", err)
+	}
+	if resp.GetCreated() != 0 || resp.GetUpdated() != 0 || resp.GetDeleted() != 0 {
+		t.Errorf("期望没有修改，实际为: %v", resp)
+	}
+}
+
+// TestReplaceCatalogInvalid 测试目录中有无效或重复的图书时不做任何修改
+func TestReplaceCatalogInvalid(t *testing.T) {
+	client, server := startTestServer(t, mustParseConfig(t))
+	server.loadBooks([]*pb.Book{{Title: "Go语言编程", Author: "作者1", Price: proto.Float32(10)}})
+
+	for _, books := range [][]*pb.Book{
+		{{Title: "新书", Author: "作者", Price: proto.Float32(10)}, {Title: "", Author: "作者", Price: proto.Float32(10)}},
+		{{Title: "新书", Author: "作者", Price: proto.Float32(10)}, {Title: "新书", Author: "作者", Price: proto.Float32(20)}},
+	} {
+		if _, err := replaceCatalog(t, client, books); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("期望返回 InvalidArgument，实际为: %v", err)
+		}
+	}
+	if n := server.bookCount(); n != 1 {
+		t.Errorf("期望目录保持不变，实际图书数量为: %d", n)
+	}
+}
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"sync/atomic"
+
+	// 导入生成的protobuf代码
+	pb "grpc-basic-server/pb"
+
+	// 导入gRPC相关包
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/stats"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// defaultMaxRecvMessageSize 默认的最大请求消息大小，与 gRPC 的默认值一致
+const defaultMaxRecvMessageSize = 4 << 20
+
+// requestSizeBuckets 请求大小分布各桶的上限（字节）
+var requestSizeBuckets = []int64{64, 256, 1 << 10, 4 << 10, 16 << 10, 64 << 10, 256 << 10, 1 << 20, 4 << 20}
+
+// sizeHistogram 单个方法的请求大小分布
+type sizeHistogram struct {
+	counts []int64
+	count  int64
+	sum    int64
+}
+
+// requestSizeMetrics 按方法统计的请求大小分布
+type requestSizeMetrics struct {
+	mu       sync.Mutex
+	byMethod map[string]*sizeHistogram
+}
+
+// observe 记录一次请求的大小
+func (m *requestSizeMetrics) observe(method string, size int64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.byMethod == nil {
+		m.byMethod = make(map[string]*sizeHistogram)
+	}
+	h, exists := m.byMethod[method]
+	if !exists {
+		h = &sizeHistogram{counts: make([]int64, len(requestSizeBuckets)+1)}
+		m.byMethod[method] = h
+	}
+
+	// 找到第一个上限不小于 size 的桶，超过所有上限时落入最后一个桶
+	bucket := sort.Search(len(requestSizeBuckets), func(i int) bool { return requestSizeBuckets[i] >= size })
+	h.counts[bucket]++
+	h.count++
+	h.sum += size
+}
+
+// snapshot 返回按方法名排序的分布副本
+func (m *requestSizeMetrics) snapshot() []*pb.RequestSizeHistogram {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	histograms := make([]*pb.RequestSizeHistogram, 0, len(m.byMethod))
+	for method, h := range m.byMethod {
+		histograms = append(histograms, &pb.RequestSizeHistogram{
+			Method:       method,
+			BucketBounds: requestSizeBuckets,
+			BucketCounts: append([]int64(nil), h.counts...),
+			Count:        h.count,
+			Sum:          h.sum,
+		})
+	}
+	sort.Slice(histograms, func(i, j int) bool { return histograms[i].Method < histograms[j].Method })
+	return histograms
+}
+
+// requestSizeInterceptor 统计一元请求的消息大小
+func (s *BookServer) requestSizeInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if msg, ok := req.(proto.Message); ok {
+		s.requestSizes.observe(info.FullMethod, int64(proto.Size(msg)))
+	}
+	return handler(ctx, req)
+}
+
+// oversizeLogger 记录因超过最大接收消息大小而被拒绝的请求。
+// 这类请求在解码阶段就被 gRPC 拒绝，拦截器不会被调用，因此通过 stats.Handler 观察
+type oversizeLogger struct {
+	logger  Logger
+	maxSize int
+}
+
+// rpcReceived 记录调用是否成功收到过请求消息
+type rpcReceived struct {
+	method   string
+	received atomic.Bool
+}
+
+// rpcReceivedKey 在 context 中保存 rpcReceived 的键
+type rpcReceivedKey struct{}
+
+// TagRPC 为每次调用附加接收状态
+func (h *oversizeLogger) TagRPC(ctx context.Context, info *stats.RPCTagInfo) context.Context {
+	return context.WithValue(ctx, rpcReceivedKey{}, &rpcReceived{method: info.FullMethodName})
+}
+
+// HandleRPC 调用结束时，没有收到任何请求消息且返回 ResourceExhausted 即为请求过大被拒绝
+func (h *oversizeLogger) HandleRPC(ctx context.Context, s stats.RPCStats) {
+	rpc, ok := ctx.Value(rpcReceivedKey{}).(*rpcReceived)
+	if !ok {
+		return
+	}
+	switch s := s.(type) {
+	case *stats.InPayload:
+		rpc.received.Store(true)
+	case *stats.End:
+		if !rpc.received.Load() && status.Code(s.Error) == codes.ResourceExhausted {
+			h.logger.Warn("请求超过最大接收消息大小，已被拒绝", "method", rpc.method, "max_size", h.maxSize, "error", s.Error)
+		}
+	}
+}
+
+// TagConn 不需要连接级别的信息
+func (h *oversizeLogger) TagConn(ctx context.Context, _ *stats.ConnTagInfo) context.Context {
+	return ctx
+}
+
+// HandleConn 不需要处理连接事件
+func (h *oversizeLogger) HandleConn(context.Context, stats.ConnStats) {}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	// 导入生成的protobuf代码
+	pb "grpc-basic-server/pb"
+
+	// 导入gRPC相关包
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// requestSizeHistogram 从运行状态中查找指定方法的请求大小分布
+func requestSizeHistogram(t *testing.T, client pb.BookServiceClient, method string) *pb.RequestSizeHistogram {
+	t.Helper()
+
+	stats, err := client.GetStats(context.Background(), &emptypb.Empty{})
+	if err != nil {
+		t.Fatalf("获取运行状态失败: %v", err)
+	}
+	for _, h := range stats.GetRequestSizes() {
+		if h.GetMethod() == method {
+			return h
+		}
+	}
+	return nil
+}
+
+// TestRequestSizeHistogram 测试 CreateBook 的请求大小被记录到对应的桶中
+func TestRequestSizeHistogram(t *testing.T) {
+	client, _ := startTestServer(t, mustParseConfig(t))
+	const method = "/bookstore.BookService/CreateBook"
+
+	small := &pb.CreateBookRequest{Book: &pb.Book{Title: "图书", Author: "作者", Price: proto.Float32(10)}}
+	large := &pb.CreateBookRequest{Book: &pb.Book{Title: "图书", Author: "作者", Price: proto.Float32(10), Description: proto.String(strings.Repeat("x", 2000))}}
+	for _, req := range []*pb.CreateBookRequest{small, large} {
+		if _, err := client.CreateBook(context.Background(), req); err != nil {
+			t.Fatalf("创建图书失败: %v", err)
+		}
+	}
+
+	h := requestSizeHistogram(t, client, method)
+	if h == nil {
+		t.Fatalf("期望记录CreateBook的请求大小")
+	}
+	if h.GetCount() != 2 || h.GetSum() < 2000 {
+		t.Errorf("期望记录2次请求且总大小超过2000字节，实际为: count=%d sum=%d", h.GetCount(), h.GetSum())
+	}
+	// 小请求落入 64 字节的桶，大请求落入 4KB 的桶
+	if counts := h.GetBucketCounts(); counts[0] != 1 || counts[3] != 1 {
+		t.Errorf("期望两个请求分别落入第1和第4个桶，实际为: %v", counts)
+	}
+}
+
+// TestOversizedRequestLogged 测试超过最大请求大小的请求被拒绝并记录警告日志
+func TestOversizedRequestLogged(t *testing.T) {
+	logger := &captureLogger{}
+	client, _ := startTestServer(t, mustParseConfig(t, "-max-recv-message-size", "1024"), WithLogger(logger))
+
+	_, err := client.CreateBook(context.Background(), &pb.CreateBookRequest{Book: &pb.Book{
+		Title: "图书", Author: "作者", Price: proto.Float32(10), Description: proto.String(strings.Repeat("x", 2000)),
+	}})
+	if status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("期望错误码为ResourceExhausted，实际为: %v", err)
+	}
+	// 状态先于 stats.End 事件发送给客户端，需要等待日志写入
+	const want = "WARN 请求超过最大接收消息大小，已被拒绝 method=/bookstore.BookService/CreateBook"
+	for deadline := time.Now().Add(time.Second); !logger.contains(want) && time.Now().Before(deadline); {
+		time.Sleep(5 * time.Millisecond)
+	}
+	if !logger.contains(want) {
+		t.Errorf("期望记录请求过大的警告日志，实际为: %v", logger.lines)
+	}
+
+	// 被拒绝的请求没有经过拦截器，不计入请求大小分布
+	if h := requestSizeHistogram(t, client, "/bookstore.BookService/CreateBook"); h != nil {
+		t.Errorf("期望被拒绝的请求不计入分布，实际为: %v", h)
+	}
+}
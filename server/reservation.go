@@ -0,0 +1,209 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	// 导入生成的protobuf代码
+	pb "grpc-basic-server/pb"
+
+	// 导入gRPC相关包
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// defaultReservationTTL 未指定有效期时的默认预留有效期
+	defaultReservationTTL = 10 * time.Minute
+
+	// maxReservationTTL 预留有效期上限，避免库存被长期占用
+	maxReservationTTL = time.Hour
+
+	// reservationJanitorInterval 后台回收过期预留的间隔
+	reservationJanitorInterval = time.Second
+)
+
+// reservation 对某本图书库存的临时预留
+type reservation struct {
+	// 预留的图书ID
+	bookID string
+
+	// 预留数量
+	quantity int32
+
+	// 过期时间，过期后预留自动释放
+	expiresAt time.Time
+}
+
+// reservedStock 返回图书当前被有效预留占用的库存，调用方需持有 s.mu
+func (c *bookCatalog) reservedStock(bookID string, now time.Time) int32 {
+	var reserved int32
+	for _, r := range c.reservations {
+		if r.bookID == bookID && !now.After(r.expiresAt) {
+			reserved += r.quantity
+		}
+	}
+	return reserved
+}
+
+// ReserveBook 在有效期内预留库存，预留的库存不能被购买或再次预留
+func (s *BookServer) ReserveBook(ctx context.Context, req *pb.ReserveBookRequest) (*pb.ReserveResponse, error) {
+	// 记录请求日志
+	s.logger.Info("收到预留库存请求", "id", req.GetId(), "quantity", req.GetQuantity(), "ttl", req.GetTtl().AsDuration())
+
+	// 验证请求参数
+	if err := validateStockRequest(req.GetId(), req.GetQuantity()); err != nil {
+		return nil, err
+	}
+	ttl := defaultReservationTTL
+	if req.GetTtl() != nil {
+		if err := req.GetTtl().CheckValid(); err != nil || req.GetTtl().AsDuration() <= 0 {
+			return nil, status.Errorf(codes.InvalidArgument, "预留有效期必须大于0")
+		}
+		ttl = req.GetTtl().AsDuration()
+	}
+	if ttl > maxReservationTTL {
+		return nil, status.Errorf(codes.InvalidArgument, "预留有效期不能超过%v", maxReservationTTL)
+	}
+
+	reservationID, err := newRandomToken()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "生成预留ID失败: %v", err)
+	}
+
+	// 加写锁，保证检查可用库存和创建预留是原子操作
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.clock.Now()
+	catalog := s.catalogFor(ctx, false)
+	book, err := catalog.get(req.GetId())
+	if err != nil {
+		s.logger.Warn("图书不存在，无法预留", "id", req.GetId())
+		return nil, s.storeErrToStatus(err)
+	}
+	available := book.GetStock() - catalog.reservedStock(req.GetId(), now)
+	if available < req.GetQuantity() {
+		s.logger.Warn("库存不足，无法预留", "id", req.GetId(), "available", available)
+		return nil, status.Errorf(codes.FailedPrecondition, "库存不足，当前可用库存: %d", available)
+	}
+
+	if catalog.reservations == nil {
+		catalog.reservations = make(map[string]*reservation)
+	}
+	catalog.reservations[reservationID] = &reservation{
+		bookID:    req.GetId(),
+		quantity:  req.GetQuantity(),
+		expiresAt: now.Add(ttl),
+	}
+
+	s.logger.Info("成功预留库存", "id", req.GetId(), "reservation_id", reservationID)
+
+	return &pb.ReserveResponse{ReservationId: reservationID}, nil
+}
+
+// ConfirmReservation 确认预留，从库存中扣减预留数量
+func (s *BookServer) ConfirmReservation(ctx context.Context, req *pb.ReservationRequest) (*pb.ReservationResponse, error) {
+	// 记录请求日志
+	s.logger.Info("收到确认预留请求", "reservation_id", req.GetReservationId())
+
+	// 加写锁保护并发访问
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	catalog := s.catalogFor(ctx, false)
+	r, err := takeReservation(catalog, req.GetReservationId(), s.clock.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	book, err := catalog.get(r.bookID)
+	if err != nil {
+		s.logger.Warn("预留的图书已被删除", "id", r.bookID)
+		return nil, s.storeErrToStatus(err)
+	}
+
+	// 预留时已保证库存充足，这里直接扣减；替换为新的副本，不原地修改已存储的图书
+	updated := proto.Clone(book).(*pb.Book)
+	updated.Stock -= r.quantity
+	catalog.put(updated, s.clock.Now())
+
+	s.logger.Info("成功确认预留", "id", r.bookID, "stock", updated.GetStock())
+
+	return &pb.ReservationResponse{Message: "预留已确认"}, nil
+}
+
+// CancelReservation 取消预留，释放被占用的库存
+func (s *BookServer) CancelReservation(ctx context.Context, req *pb.ReservationRequest) (*pb.ReservationResponse, error) {
+	// 记录请求日志
+	s.logger.Info("收到取消预留请求", "reservation_id", req.GetReservationId())
+
+	// 加写锁保护并发访问
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, err := takeReservation(s.catalogFor(ctx, false), req.GetReservationId(), s.clock.Now()); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("成功取消预留", "reservation_id", req.GetReservationId())
+
+	return &pb.ReservationResponse{Message: "预留已取消"}, nil
+}
+
+// takeReservation 取出并删除有效的预留，调用方需持有写锁
+func takeReservation(catalog *bookCatalog, reservationID string, now time.Time) (*reservation, error) {
+	if reservationID == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "预留ID不能为空")
+	}
+
+	r, exists := catalog.reservations[reservationID]
+	if !exists {
+		return nil, status.Errorf(codes.NotFound, "预留不存在或已被释放")
+	}
+	delete(catalog.reservations, reservationID)
+
+	if now.After(r.expiresAt) {
+		return nil, status.Errorf(codes.FailedPrecondition, "预留已过期")
+	}
+	return r, nil
+}
+
+// removeExpiredReservations 释放所有租户中已过期的预留，返回释放数量
+func (s *BookServer) removeExpiredReservations(now time.Time) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	removed := 0
+	catalogs := []*bookCatalog{&s.bookCatalog}
+	for _, catalog := range s.tenants {
+		catalogs = append(catalogs, catalog)
+	}
+	for _, catalog := range catalogs {
+		for id, r := range catalog.reservations {
+			if now.After(r.expiresAt) {
+				delete(catalog.reservations, id)
+				removed++
+			}
+		}
+	}
+	return removed
+}
+
+// runReservationJanitor 定期释放过期预留，直到 ctx 被取消
+func (s *BookServer) runReservationJanitor(ctx context.Context, interval time.Duration) {
+	ticker := s.clock.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C():
+			if removed := s.removeExpiredReservations(now); removed > 0 {
+				s.logger.Info("释放过期预留", "count", removed)
+			}
+		}
+	}
+}
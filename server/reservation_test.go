@@ -0,0 +1,110 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	// 导入生成的protobuf代码
+	pb "grpc-basic-server/pb"
+
+	// 导入gRPC相关包
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// reserve 预留库存并返回预留ID
+func reserve(t *testing.T, server *BookServer, id string, quantity int32, ttl time.Duration) string {
+	t.Helper()
+
+	resp, err := server.ReserveBook(context.Background(), &pb.ReserveBookRequest{
+		Id:       id,
+		Quantity: quantity,
+		Ttl:      durationpb.New(ttl),
+	})
+	if err != nil {
+		t.Fatalf("预留库存失败: %v", err)
+	}
+	return resp.ReservationId
+}
+
+// TestConfirmReservation 测试确认预留后扣减库存
+func TestConfirmReservation(t *testing.T) {
+	// 创建服务器实例
+	server := NewBookServer()
+	ids := server.loadBooks([]*pb.Book{{Title: "图书", Author: "作者", Price: proto.Float32(10), Stock: 5}})
+	ctx := context.Background()
+
+	reservationID := reserve(t, server, ids[0], 3, time.Minute)
+
+	// 预留的库存不能被购买，但库存本身不变
+	_, err := server.PurchaseBook(ctx, &pb.PurchaseBookRequest{Id: ids[0], Quantity: 3})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("期望预留的库存不能被购买，实际错误码为: %v", status.Code(err))
+	}
+	if stock := server.books[ids[0]].GetStock(); stock != 5 {
+		t.Errorf("确认前库存不应改变，实际为: %d", stock)
+	}
+
+	if _, err := server.ConfirmReservation(ctx, &pb.ReservationRequest{ReservationId: reservationID}); err != nil {
+		t.Fatalf("确认预留失败: %v", err)
+	}
+	if stock := server.books[ids[0]].GetStock(); stock != 2 {
+		t.Errorf("期望确认后库存为2，实际为: %d", stock)
+	}
+
+	// 预留只能确认一次
+	_, err = server.ConfirmReservation(ctx, &pb.ReservationRequest{ReservationId: reservationID})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("期望重复确认返回NotFound，实际为: %v", status.Code(err))
+	}
+}
+
+// TestCancelReservation 测试取消预留后释放库存
+func TestCancelReservation(t *testing.T) {
+	server := NewBookServer()
+	ids := server.loadBooks([]*pb.Book{{Title: "图书", Author: "作者", Price: proto.Float32(10), Stock: 2}})
+	ctx := context.Background()
+
+	reservationID := reserve(t, server, ids[0], 2, time.Minute)
+	if _, err := server.CancelReservation(ctx, &pb.ReservationRequest{ReservationId: reservationID}); err != nil {
+		t.Fatalf("取消预留失败: %v", err)
+	}
+
+	// 释放后可以重新购买全部库存
+	if _, err := server.PurchaseBook(ctx, &pb.PurchaseBookRequest{Id: ids[0], Quantity: 2}); err != nil {
+		t.Errorf("取消预留后购买失败: %v", err)
+	}
+}
+
+// TestReservationExpiry 测试后台回收自动释放过期预留
+func TestReservationExpiry(t *testing.T) {
+	clock := newFakeClock()
+	server := NewBookServer(WithClock(clock))
+	ids := server.loadBooks([]*pb.Book{{Title: "图书", Author: "作者", Price: proto.Float32(10), Stock: 1}})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go server.runReservationJanitor(ctx, time.Second)
+	waitUntil(t, func() bool { return clock.activeTickers() == 1 })
+
+	reservationID := reserve(t, server, ids[0], 1, time.Minute)
+
+	// 推进时钟超过有效期，等待后台回收释放预留
+	clock.Advance(time.Minute + time.Second)
+	waitUntil(t, func() bool {
+		server.mu.RLock()
+		defer server.mu.RUnlock()
+		_, exists := server.reservations[reservationID]
+		return !exists
+	})
+
+	// 释放后库存可以重新预留，过期的预留无法确认
+	reserve(t, server, ids[0], 1, time.Minute)
+	_, err := server.ConfirmReservation(context.Background(), &pb.ReservationRequest{ReservationId: reservationID})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("期望过期预留无法确认，实际错误码为: %v", status.Code(err))
+	}
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"context"
+	"sync"
+
+	// 导入生成的protobuf代码
+	pb "grpc-basic-server/pb"
+
+	// 导入gRPC相关包
+	"google.golang.org/grpc/stats"
+	"google.golang.org/protobuf/proto"
+)
+
+// binaryLogEnv gRPC 二进制日志的环境变量。开启二进制日志时，gRPC 在响应发送之后仍会读取响应消息，不能使用消息池
+const binaryLogEnv = "GRPC_BINARY_LOG_FILTER"
+
+// responsePools 常用的小响应消息的对象池，由 WithResponsePool 开启。
+// 处理器从池中取出响应，gRPC 发送完响应后由 responseReleaser 重置并放回；
+// 没有经过 gRPC 发送的响应（直接调用处理器、调试 HTTP 接口、处理器返回错误）不会放回，由 GC 回收，
+// 因此池中的消息一定已经不再被引用
+type responsePools struct {
+	enabled bool
+
+	createBook sync.Pool
+	getBook    sync.Pool
+	updateBook sync.Pool
+	deleteBook sync.Pool
+}
+
+// WithResponsePool 设置是否复用 CreateBook、GetBook、UpdateBook、DeleteBook 的响应消息，减少高吞吐时的内存分配。
+// 响应只有在 newGRPCServer 注册的 responseReleaser 观察到发送完成后才会放回池中
+func WithResponsePool(enabled bool) ServerOption {
+	return func(s *BookServer) {
+		s.responses.enabled = enabled
+	}
+}
+
+// pooled 开启消息池时从 pool 取出一个空的消息，未开启或池为空时新分配
+func pooled[M any, P interface{ *M }](pools *responsePools, pool *sync.Pool) P {
+	if pools.enabled {
+		if m, ok := pool.Get().(P); ok {
+			return m
+		}
+	}
+	return new(M)
+}
+
+// release 重置已发送的响应并放回对应的池，不是池化类型的消息不做任何操作
+func (p *responsePools) release(msg interface{}) {
+	if !p.enabled {
+		return
+	}
+
+	var pool *sync.Pool
+	switch msg.(type) {
+	case *pb.CreateBookResponse:
+		pool = &p.createBook
+	case *pb.GetBookResponse:
+		pool = &p.getBook
+	case *pb.UpdateBookResponse:
+		pool = &p.updateBook
+	case *pb.DeleteBookResponse:
+		pool = &p.deleteBook
+	default:
+		return
+	}
+
+	// 重置只清空响应自身的字段，响应引用的图书不会被修改
+	m := msg.(proto.Message)
+	proto.Reset(m)
+	pool.Put(m)
+}
+
+// responseReleaser 在响应发送之后把响应放回消息池的 stats.Handler。
+// gRPC 在响应编码并写入传输层之后才产生 OutPayload 事件，拦截器此时都已返回；
+// 需要注册在其他 stats.Handler 之后，使它们在响应放回之前处理完 OutPayload
+type responseReleaser struct {
+	pools *responsePools
+}
+
+// TagRPC 不需要为调用附加信息
+func (r *responseReleaser) TagRPC(ctx context.Context, _ *stats.RPCTagInfo) context.Context {
+	return ctx
+}
+
+// HandleRPC 响应发送完成后放回消息池
+func (r *responseReleaser) HandleRPC(_ context.Context, s stats.RPCStats) {
+	if out, ok := s.(*stats.OutPayload); ok && !out.IsClient() {
+		r.pools.release(out.Payload)
+	}
+}
+
+// TagConn 不需要为连接附加信息
+func (r *responseReleaser) TagConn(ctx context.Context, _ *stats.ConnTagInfo) context.Context {
+	return ctx
+}
+
+// HandleConn 不处理连接事件
+func (r *responseReleaser) HandleConn(context.Context, stats.ConnStats) {}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	// 导入生成的protobuf代码
+	pb "grpc-basic-server/pb"
+
+	// 导入gRPC相关包
+	"google.golang.org/protobuf/proto"
+)
+
+// TestResponsePoolConcurrent 测试开启消息池后并发调用的响应互不干扰：
+// 响应在发送之前被放回并复用时，客户端会收到其他调用的图书
+func TestResponsePoolConcurrent(t *testing.T) {
+	silenceLog(t)
+	client, server := startTestServer(t, mustParseConfig(t, "-response-pool", "-log-level", "error"))
+
+	books := make([]*pb.Book, 50)
+	for i := range books {
+		books[i] = &pb.Book{Title: fmt.Sprintf("图书%d", i), Author: "作者", Price: proto.Float32(10)}
+	}
+	ids := server.loadBooks(books)
+	titles := make(map[string]string, len(ids))
+	for i, id := range ids {
+		titles[id] = books[i].GetTitle()
+	}
+
+	ctx := context.Background()
+	var wg sync.WaitGroup
+	errs := make(chan error, 16)
+	for g := 0; g < 16; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				id := ids[(g*7+i)%len(ids)]
+				resp, err := client.GetBook(ctx, &pb.GetBookRequest{Id: id})
+				if err != nil {
+					errs <- err
+					return
+				}
+				if resp.GetBook().GetId() != id || resp.GetBook().GetTitle() != titles[id] {
+					errs <- fmt.Errorf("请求图书 %s，收到的是: %v", id, resp.GetBook())
+					return
+				}
+
+				// 创建和删除的响应同样来自消息池
+				created, err := client.CreateBook(ctx, &pb.CreateBookRequest{Book: &pb.Book{Title: "临时图书", Author: "作者", Price: proto.Float32(10)}})
+				if err != nil {
+					errs <- err
+					return
+				}
+				deleted, err := client.DeleteBook(ctx, &pb.DeleteBookRequest{Id: created.GetId()})
+				if err != nil {
+					errs <- err
+					return
+				}
+				if created.GetId() == "" || created.GetMessage() != "图书创建成功" || !deleted.GetDeleted() {
+					errs <- fmt.Errorf("创建或删除的响应不正确: %v, %v", created, deleted)
+					return
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Error(err)
+	}
+}
+
+// TestResponsePoolDirectCall 测试直接调用处理器得到的响应不会被放回消息池，调用方可以一直持有
+func TestResponsePoolDirectCall(t *testing.T) {
+	silenceLog(t)
+	server := NewBookServer(WithResponsePool(true))
+	ids := server.loadBooks([]*pb.Book{
+		{Title: "图书1", Author: "作者", Price: proto.Float32(10)},
+		{Title: "图书2", Author: "作者", Price: proto.Float32(20)},
+	})
+
+	first, err := server.GetBook(context.Background(), &pb.GetBookRequest{Id: ids[0]})
+	if err != nil {
+		t.Fatalf("获取图书失败: %v", err)
+	}
+	second, err := server.GetBook(context.Background(), &pb.GetBookRequest{Id: ids[1]})
+	if err != nil {
+		t.Fatalf("获取图书失败: %v", err)
+	}
+	if first == second || first.GetBook().GetId() != ids[0] {
+		t.Errorf("持有的响应被复用: %v", first)
+	}
+}
+
+// TestResponsePoolBinaryLog 测试开启 gRPC 二进制日志时不允许使用消息池
+func TestResponsePoolBinaryLog(t *testing.T) {
+	t.Setenv(binaryLogEnv, "*")
+	if _, err := parseConfig([]string{"-response-pool"}); err == nil {
+		t.Error("期望开启二进制日志时 -response-pool 返回错误")
+	}
+	if _, err := parseConfig(nil); err != nil {
+		t.Errorf("未开启消息池时不应返回错误: %v", err)
+	}
+}
@@ -0,0 +1,37 @@
+package main
+
+import (
+	// 导入生成的protobuf代码
+	pb "grpc-basic-server/pb"
+
+	// 导入protobuf相关包
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// defaultMaxMessageSize 默认的最大响应消息大小，与 gRPC 默认的接收上限一致
+	defaultMaxMessageSize = 4 * 1024 * 1024
+
+	// listResponseOverhead 为图书列表以外的响应字段（总数、截断标记等）预留的字节数
+	listResponseOverhead = 64
+)
+
+// WithMaxMessageSize 设置最大响应消息大小，列表响应超过该值时会被截断
+func WithMaxMessageSize(size int) ServerOption {
+	return func(s *BookServer) {
+		s.maxMessageSize = size
+	}
+}
+
+// fitBooks 返回在 limit 字节内最多能放入响应的图书数量，按 repeated 字段的编码大小估算
+func fitBooks(books []*pb.Book, limit int) int {
+	size := listResponseOverhead
+	for i, book := range books {
+		size += protowire.SizeTag(1) + protowire.SizeBytes(proto.Size(book))
+		if size > limit {
+			return i
+		}
+	}
+	return len(books)
+}
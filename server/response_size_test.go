@@ -0,0 +1,67 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	// 导入生成的protobuf代码
+	pb "grpc-basic-server/pb"
+
+	// 导入gRPC相关包
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// TestListBooksTruncated 测试响应超过消息大小上限时截断当前页而不是报错
+func TestListBooksTruncated(t *testing.T) {
+	client, server := startTestServer(t, mustParseConfig(t, "-max-message-size", "100000"))
+
+	// 每本图书约 30KB，一页最多放下3本
+	books := make([]*pb.Book, 10)
+	for i := range books {
+		books[i] = &pb.Book{Title: fmt.Sprintf("图书%d", i), Author: "作者", Price: proto.Float32(10), Description: proto.String(strings.Repeat("x", 30000))}
+	}
+	server.loadBooks(books)
+
+	resp, err := client.ListBooks(context.Background(), &pb.ListBooksRequest{Page: 1, PageSize: 10})
+	if err != nil {
+		t.Fatalf("列出图书失败: %v", err)
+	}
+	if !resp.Truncated {
+		t.Errorf("期望响应被截断")
+	}
+	if len(resp.Books) != 3 || resp.PageSize != 3 {
+		t.Errorf("期望返回3本图书且每页大小调整为3，实际为: %d, %d", len(resp.Books), resp.PageSize)
+	}
+	if resp.Total != 10 {
+		t.Errorf("期望总数为10，实际为: %d", resp.Total)
+	}
+}
+
+// TestListBooksNotTruncated 测试响应未超过上限时不截断
+func TestListBooksNotTruncated(t *testing.T) {
+	client, server := startTestServer(t, mustParseConfig(t))
+	server.loadBooks([]*pb.Book{{Title: "图书", Author: "作者", Price: proto.Float32(10)}})
+
+	resp, err := client.ListBooks(context.Background(), &pb.ListBooksRequest{Page: 1, PageSize: 10})
+	if err != nil {
+		t.Fatalf("列出图书失败: %v", err)
+	}
+	if resp.Truncated || resp.PageSize != 10 {
+		t.Errorf("期望不截断且每页大小为10，实际为: %v, %d", resp.Truncated, resp.PageSize)
+	}
+}
+
+// TestListBooksSingleBookTooLarge 测试单本图书超过上限时返回 ResourceExhausted
+func TestListBooksSingleBookTooLarge(t *testing.T) {
+	client, server := startTestServer(t, mustParseConfig(t, "-max-message-size", "1000"))
+	server.loadBooks([]*pb.Book{{Title: "图书", Author: "作者", Price: proto.Float32(10), Description: proto.String(strings.Repeat("x", 2000))}})
+
+	_, err := client.ListBooks(context.Background(), &pb.ListBooksRequest{Page: 1, PageSize: 10})
+	if status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("期望错误码为ResourceExhausted，实际为: %v", status.Code(err))
+	}
+}
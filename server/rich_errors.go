@@ -0,0 +1,157 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+
+	// 导入gRPC相关包
+	"google.golang.org/genproto/googleapis/rpc/code"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+)
+
+// 错误信息支持的语言，默认使用中文
+const (
+	localeZH = "zh-CN"
+	localeEN = "en"
+)
+
+// errorDomain 错误详情 ErrorInfo 中的错误域
+const errorDomain = "bookstore"
+
+// fieldError 某个请求字段无效时返回的错误，同时带有中文和英文描述。
+// 未开启详细错误时按中文描述转换为普通的 gRPC 错误，与 status.Errorf 返回的错误没有区别
+type fieldError struct {
+	code     codes.Code
+	field    string
+	messages map[string]string
+}
+
+// invalidField 创建字段无效的 InvalidArgument 错误，field 为字段路径（如 book.title）
+func invalidField(field, zh, en string) error {
+	return &fieldError{
+		code:     codes.InvalidArgument,
+		field:    field,
+		messages: map[string]string{localeZH: zh, localeEN: en},
+	}
+}
+
+// Error 返回中文描述
+func (e *fieldError) Error() string {
+	return e.messages[localeZH]
+}
+
+// GRPCStatus 返回对应的 gRPC 状态，status.Code、status.Convert 等据此读取错误码
+func (e *fieldError) GRPCStatus() *status.Status {
+	return status.New(e.code, e.Error())
+}
+
+// newRichErrorInterceptor 创建详细错误拦截器：开启时所有错误都附带 google.rpc 错误详情
+// （ErrorInfo，字段无效时还有 BadRequest）和按 accept-language 选择语言的 LocalizedMessage，
+// 错误信息本身也使用该语言；没有对应语言的描述时使用中文
+func newRichErrorInterceptor(enabled bool) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		resp, err := handler(ctx, req)
+		if err == nil || !enabled {
+			return resp, err
+		}
+		return resp, richStatus(err, preferredLocale(ctx)).Err()
+	}
+}
+
+// newRichErrorStreamInterceptor 创建流式方法的详细错误拦截器，规则与一元方法相同
+func newRichErrorStreamInterceptor(enabled bool) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		err := handler(srv, ss)
+		if err == nil || !enabled {
+			return err
+		}
+		return richStatus(err, preferredLocale(ss.Context())).Err()
+	}
+}
+
+// richStatus 将错误转换为带有错误详情和指定语言描述的 gRPC 状态，已有的错误详情保留
+func richStatus(err error, locale string) *status.Status {
+	st := status.Convert(err)
+
+	message := st.Message()
+	var violation *errdetails.BadRequest
+	var fe *fieldError
+	if errors.As(err, &fe) {
+		if localized, exists := fe.messages[locale]; exists {
+			message = localized
+		} else {
+			locale = localeZH
+		}
+		violation = &errdetails.BadRequest{FieldViolations: []*errdetails.BadRequest_FieldViolation{
+			{Field: fe.field, Description: message},
+		}}
+	} else {
+		// 普通错误只有中文描述
+		locale = localeZH
+	}
+
+	p := st.Proto()
+	p.Message = message
+	details := []protoadapt.MessageV1{
+		&errdetails.ErrorInfo{Reason: code.Code(st.Code()).String(), Domain: errorDomain},
+		&errdetails.LocalizedMessage{Locale: locale, Message: message},
+	}
+	if violation != nil {
+		details = append(details, violation)
+	}
+	rich, detailErr := status.FromProto(p).WithDetails(details...)
+	if detailErr != nil {
+		return st
+	}
+	return rich
+}
+
+// preferredLocale 按 accept-language 元数据（格式与 HTTP 的 Accept-Language 相同，如 en-US,en;q=0.9）
+// 选择支持的语言，按权重从高到低匹配语言的主标签，都不支持时使用中文
+func preferredLocale(ctx context.Context) string {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	type candidate struct {
+		lang   string
+		weight float64
+	}
+	var candidates []candidate
+	for _, value := range md.Get("accept-language") {
+		for _, part := range strings.Split(value, ",") {
+			lang, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+			weight := 1.0
+			if q, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+				parsed, err := strconv.ParseFloat(q, 64)
+				if err != nil {
+					continue
+				}
+				weight = parsed
+			}
+			if lang != "" && weight > 0 {
+				candidates = append(candidates, candidate{lang: lang, weight: weight})
+			}
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].weight > candidates[j].weight
+	})
+
+	for _, c := range candidates {
+		primary, _, _ := strings.Cut(strings.ToLower(c.lang), "-")
+		switch primary {
+		case "zh":
+			return localeZH
+		case "en":
+			return localeEN
+		}
+	}
+	return localeZH
+}
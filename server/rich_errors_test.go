@@ -0,0 +1,103 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	// 导入生成的protobuf代码
+	pb "grpc-basic-server/pb"
+
+	// 导入gRPC相关包
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// TestRichErrors 测试开启详细错误后，参数错误按 accept-language 返回英文描述和字段错误详情
+func TestRichErrors(t *testing.T) {
+	client, _ := startTestServer(t, mustParseConfig(t, "-rich-errors"))
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "accept-language", "en-US,zh;q=0.5")
+	_, err := client.CreateBook(ctx, &pb.CreateBookRequest{Book: &pb.Book{Author: "作者", Price: proto.Float32(10)}})
+	st := status.Convert(err)
+	if st.Code() != codes.InvalidArgument {
+		t.Fatalf("期望错误码为InvalidArgument，实际为: %v", err)
+	}
+	if st.Message() != "book title must not be empty" {
+		t.Errorf("期望英文错误信息，实际为: %q", st.Message())
+	}
+
+	var localized *errdetails.LocalizedMessage
+	var badRequest *errdetails.BadRequest
+	var errorInfo *errdetails.ErrorInfo
+	for _, detail := range st.Details() {
+		switch d := detail.(type) {
+		case *errdetails.LocalizedMessage:
+			localized = d
+		case *errdetails.BadRequest:
+			badRequest = d
+		case *errdetails.ErrorInfo:
+			errorInfo = d
+		}
+	}
+	if localized.GetLocale() != localeEN || localized.GetMessage() != st.Message() {
+		t.Errorf("本地化描述不正确: %v", localized)
+	}
+	if violations := badRequest.GetFieldViolations(); len(violations) != 1 || violations[0].GetField() != "book.title" {
+		t.Errorf("字段错误详情不正确: %v", badRequest)
+	}
+	if errorInfo.GetReason() != "INVALID_ARGUMENT" || errorInfo.GetDomain() != errorDomain {
+		t.Errorf("错误信息详情不正确: %v", errorInfo)
+	}
+
+	// 没有指定语言时使用中文，普通错误同样附带错误详情
+	_, err = client.GetBook(context.Background(), &pb.GetBookRequest{Id: "book-404"})
+	st = status.Convert(err)
+	if st.Code() != codes.NotFound || len(st.Details()) != 2 {
+		t.Fatalf("期望返回带错误详情的NotFound，实际为: %v, %v", err, st.Details())
+	}
+	if localized, ok := st.Details()[1].(*errdetails.LocalizedMessage); !ok || localized.GetLocale() != localeZH {
+		t.Errorf("期望中文描述，实际为: %v", st.Details()[1])
+	}
+}
+
+// TestRichErrorsDisabled 测试未开启详细错误时，错误只有中文描述，没有错误详情
+func TestRichErrorsDisabled(t *testing.T) {
+	client, _ := startTestServer(t, mustParseConfig(t))
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "accept-language", "en")
+	_, err := client.CreateBook(ctx, &pb.CreateBookRequest{Book: &pb.Book{Author: "作者", Price: proto.Float32(10)}})
+	st := status.Convert(err)
+	if st.Code() != codes.InvalidArgument || st.Message() != "图书标题不能为空" || len(st.Details()) != 0 {
+		t.Errorf("期望不带错误详情的中文错误，实际为: %v, %v", err, st.Details())
+	}
+}
+
+// TestPreferredLocale 测试按 accept-language 的权重选择语言
+func TestPreferredLocale(t *testing.T) {
+	tests := []struct {
+		header string
+		want   string
+	}{
+		{"", localeZH},
+		{"en", localeEN},
+		{"EN-us", localeEN},
+		{"zh-CN,en;q=0.8", localeZH},
+		{"zh;q=0.3,en;q=0.9", localeEN},
+		{"fr,en;q=0.5", localeEN},
+		{"en;q=0", localeZH},
+		{"fr", localeZH},
+	}
+
+	for _, tt := range tests {
+		ctx := context.Background()
+		if tt.header != "" {
+			ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("accept-language", tt.header))
+		}
+		if got := preferredLocale(ctx); got != tt.want {
+			t.Errorf("accept-language %q: 期望 %s，实际为 %s", tt.header, tt.want, got)
+		}
+	}
+}
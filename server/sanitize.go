@@ -0,0 +1,70 @@
+package main
+
+import (
+	"strings"
+
+	// 导入生成的protobuf代码
+	pb "grpc-basic-server/pb"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// WithDescriptionSanitization 设置创建和更新图书时是否去除描述中的 HTML 标签，
+// 避免描述在网页中展示时成为 XSS 攻击的载体。默认关闭，不改变客户端提交的内容
+func WithDescriptionSanitization(enabled bool) ServerOption {
+	return func(s *BookServer) {
+		s.sanitizeDescriptions = enabled
+	}
+}
+
+// sanitizeBook 开启描述清理时，把图书描述替换为去除 HTML 标签后的内容
+func (s *BookServer) sanitizeBook(book *pb.Book) {
+	if !s.sanitizeDescriptions || book.Description == nil {
+		return
+	}
+	cleaned := sanitizeHTML(book.GetDescription())
+	book.Description = &cleaned
+}
+
+// sanitizeHTML 去除文本中的 HTML 标签和注释，script、style 等元素的内容一并去除。
+// 其余文本按原样保留（包括 &lt; 等字符引用），不会因为解码而产生新的标签
+func sanitizeHTML(text string) string {
+	if !strings.ContainsAny(text, "<>") {
+		return text
+	}
+
+	var b strings.Builder
+	z := html.NewTokenizer(strings.NewReader(text))
+	skipping := atom.Atom(0)
+	for {
+		switch z.Next() {
+		case html.ErrorToken:
+			// 输入结束；分词器不会返回其他错误
+			return strings.TrimSpace(b.String())
+		case html.TextToken:
+			if skipping == 0 {
+				b.Write(z.Raw())
+			}
+		case html.StartTagToken:
+			name, _ := z.TagName()
+			if tag := atom.Lookup(name); isRawTextElement(tag) {
+				skipping = tag
+			}
+		case html.EndTagToken:
+			name, _ := z.TagName()
+			if atom.Lookup(name) == skipping {
+				skipping = 0
+			}
+		}
+	}
+}
+
+// isRawTextElement 判断内容不是普通文本的元素，这些元素的内容与标签一起去除
+func isRawTextElement(tag atom.Atom) bool {
+	switch tag {
+	case atom.Script, atom.Style, atom.Iframe, atom.Noscript, atom.Textarea, atom.Title, atom.Xmp, atom.Noembed, atom.Noframes, atom.Plaintext:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	// 导入生成的protobuf代码
+	pb "grpc-basic-server/pb"
+
+	// 导入gRPC相关包
+	"google.golang.org/protobuf/proto"
+)
+
+// TestSanitizeDescription 测试开启描述清理后，包含 <script> 的描述在创建和更新时被清理后保存
+func TestSanitizeDescription(t *testing.T) {
+	client, _ := startTestServer(t, mustParseConfig(t, "-sanitize-descriptions"))
+	ctx := context.Background()
+
+	created, err := client.CreateBook(ctx, &pb.CreateBookRequest{Book: &pb.Book{
+		Title:       "图书",
+		Author:      "作者",
+		Price:       proto.Float32(10),
+		Description: proto.String(`<p>好书<script>alert("xss")</script> &lt;推荐&gt;</p>`),
+	}})
+	if err != nil {
+		t.Fatalf("创建图书失败: %v", err)
+	}
+	resp, err := client.GetBook(ctx, &pb.GetBookRequest{Id: created.GetId()})
+	if err != nil {
+		t.Fatalf("获取图书失败: %v", err)
+	}
+	if want := "好书 &lt;推荐&gt;"; resp.GetBook().GetDescription() != want {
+		t.Errorf("期望保存清理后的描述 %q，实际为: %q", want, resp.GetBook().GetDescription())
+	}
+
+	if _, err := client.UpdateBook(ctx, &pb.UpdateBookRequest{Book: &pb.Book{
+		Id:          created.GetId(),
+		Title:       "图书",
+		Author:      "作者",
+		Description: proto.String(`<img src=x onerror=alert(1)>第二版`),
+	}}); err != nil {
+		t.Fatalf("更新图书失败: %v", err)
+	}
+	resp, err = client.GetBook(ctx, &pb.GetBookRequest{Id: created.GetId()})
+	if err != nil || resp.GetBook().GetDescription() != "第二版" {
+		t.Errorf("期望更新后的描述为 %q，实际为: %v, %v", "第二版", resp, err)
+	}
+}
+
+// TestSanitizeDescriptionOff 测试默认不修改描述
+func TestSanitizeDescriptionOff(t *testing.T) {
+	server := NewBookServer()
+	description := "<b>加粗</b> & 3 < 5"
+	created, err := server.CreateBook(context.Background(), &pb.CreateBookRequest{Book: &pb.Book{
+		Title: "图书", Author: "作者", Price: proto.Float32(10), Description: proto.String(description),
+	}})
+	if err != nil {
+		t.Fatalf("创建图书失败: %v", err)
+	}
+	if got := server.books[created.GetId()].GetDescription(); got != description {
+		t.Errorf("期望描述保持不变，实际为: %q", got)
+	}
+}
+
+// TestSanitizeHTML 测试去除标签时保留普通文本
+func TestSanitizeHTML(t *testing.T) {
+	for input, want := range map[string]string{
+		"纯文本 & 符号":                        "纯文本 & 符号",
+		"<b>加粗</b>文本":                     "加粗文本",
+		"a<!-- 注释 -->b":                   "ab",
+		"<style>p{color:red}</style>样式之后": "样式之后",
+		"<SCRIPT>x</SCRIPT>大写标签":          "大写标签",
+		"3 < 5":                           "3 < 5",
+	} {
+		if got := sanitizeHTML(input); got != want {
+			t.Errorf("sanitizeHTML(%q) 期望 %q，实际为: %q", input, want, got)
+		}
+	}
+}
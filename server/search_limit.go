@@ -0,0 +1,50 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+
+	// 导入生成的protobuf代码
+	pb "grpc-basic-server/pb"
+
+	// 导入gRPC相关包
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// defaultMaxSearchResults 一元查询默认允许返回的最大图书数量
+const defaultMaxSearchResults = 10000
+
+// reasonUseStreaming 查询结果过多、需要改用流式方法时错误详情 ErrorInfo 的原因
+const reasonUseStreaming = "USE_STREAMING"
+
+// WithMaxSearchResults 设置一元查询允许返回的最大图书数量，0 表示不限制
+func WithMaxSearchResults(limit int) ServerOption {
+	return func(s *BookServer) {
+		s.maxSearchResults = limit
+	}
+}
+
+// checkSearchResults 一元查询匹配的图书数量 count 超过上限时返回 FailedPrecondition，
+// 错误详情中的 ErrorInfo 给出应改用的流式方法，避免过大的响应占用大量内存
+func (s *BookServer) checkSearchResults(count int) error {
+	if s.maxSearchResults <= 0 || count <= s.maxSearchResults {
+		return nil
+	}
+
+	message := fmt.Sprintf("匹配%d本图书，超过一元查询的上限%d，请改用 StreamBooks 并设置过滤条件", count, s.maxSearchResults)
+	st, err := status.New(codes.FailedPrecondition, message).WithDetails(&errdetails.ErrorInfo{
+		Reason: reasonUseStreaming,
+		Domain: errorDomain,
+		Metadata: map[string]string{
+			"method":  "/" + pb.BookService_ServiceDesc.ServiceName + "/StreamBooks",
+			"matched": strconv.Itoa(count),
+			"limit":   strconv.Itoa(s.maxSearchResults),
+		},
+	})
+	if err != nil {
+		return status.Error(codes.FailedPrecondition, message)
+	}
+	return st.Err()
+}
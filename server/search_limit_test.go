@@ -0,0 +1,68 @@
+package main
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	// 导入生成的protobuf代码
+	pb "grpc-basic-server/pb"
+
+	// 导入gRPC相关包
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// TestSearchRedirectsToStreaming 测试匹配的图书超过上限时一元查询返回 FailedPrecondition 并指向 StreamBooks，
+// 未超过上限的查询直接返回，流式方法可以按同样的条件取回所有结果
+func TestSearchRedirectsToStreaming(t *testing.T) {
+	client, server := startTestServer(t, mustParseConfig(t, "-max-search-results", "3"))
+	ctx := context.Background()
+	var books []*pb.Book
+	for _, price := range []float32{10, 20, 30, 40, 50} {
+		books = append(books, &pb.Book{Title: "图书", Author: "作者", Price: proto.Float32(price)})
+	}
+	server.loadBooks(books)
+
+	narrow, err := client.SearchBooksByPrice(ctx, &pb.SearchBooksByPriceRequest{MinPrice: 10, MaxPrice: 30})
+	if err != nil || len(narrow.GetBooks()) != 3 {
+		t.Fatalf("期望直接返回3本图书，实际为: %v, %v", narrow, err)
+	}
+
+	_, err = client.SearchBooksByPrice(ctx, &pb.SearchBooksByPriceRequest{MinPrice: 0, MaxPrice: 100})
+	st := status.Convert(err)
+	if st.Code() != codes.FailedPrecondition {
+		t.Fatalf("期望错误码为FailedPrecondition，实际为: %v", err)
+	}
+	var info *errdetails.ErrorInfo
+	for _, detail := range st.Details() {
+		if d, ok := detail.(*errdetails.ErrorInfo); ok {
+			info = d
+		}
+	}
+	if info.GetReason() != reasonUseStreaming || info.GetMetadata()["method"] != "/bookstore.BookService/StreamBooks" || info.GetMetadata()["matched"] != "5" {
+		t.Errorf("错误详情不正确: %v", info)
+	}
+
+	// 改用流式方法取回所有结果
+	stream, err := client.StreamBooks(ctx, &pb.StreamBooksRequest{Filter: &pb.BookFilter{MinPrice: 15, MaxPrice: 100}})
+	if err != nil {
+		t.Fatalf("流式获取图书失败: %v", err)
+	}
+	received := 0
+	for {
+		_, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("接收图书失败: %v", err)
+		}
+		received++
+	}
+	if received != 4 {
+		t.Errorf("期望流式返回4本图书，实际为%d本", received)
+	}
+}
@@ -0,0 +1,162 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	// 导入生成的protobuf代码
+	pb "grpc-basic-server/pb"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// defaultSeedBooks 内置的演示图书（与客户端演示程序一致）
+func defaultSeedBooks() []*pb.Book {
+	return []*pb.Book{
+		{
+			Title:       "The Go Programming Language",
+			Author:      "Alan A. A. Donovan",
+			Price:       proto.Float32(45.99),
+			Description: proto.String("Go语言的权威指南，适合初学者和有经验的开发者"),
+			PublishYear: proto.Int32(2015),
+		},
+		{
+			Title:       "Design Patterns",
+			Author:      "Erich Gamma",
+			Price:       proto.Float32(39.99),
+			Description: proto.String("面向对象设计模式的经典著作"),
+			PublishYear: proto.Int32(1994),
+		},
+		{
+			Title:       "Clean Code",
+			Author:      "Robert C. Martin",
+			Price:       proto.Float32(29.99),
+			Description: proto.String("编写可维护代码的最佳实践"),
+			PublishYear: proto.Int32(2008),
+		},
+	}
+}
+
+// seedBooks 根据配置加载演示数据：优先使用 seedFile，否则在 seed 开启时使用内置数据
+func seedBooks(s *BookServer, seed bool, seedFile string) (int, error) {
+	var books []*pb.Book
+	switch {
+	case seedFile != "":
+		var err error
+		if books, err = readSeedFile(seedFile); err != nil {
+			return 0, err
+		}
+	case seed:
+		books = defaultSeedBooks()
+	default:
+		return 0, nil
+	}
+
+	// 加载前逐本验证，避免写入无效数据
+	for i, book := range books {
+		if err := validateBook(book); err != nil {
+			return 0, fmt.Errorf("第 %d 本图书无效: %v", i+1, err)
+		}
+	}
+
+	s.loadBooks(books)
+	return len(books), nil
+}
+
+// readSeedFile 读取演示数据文件，按扩展名识别 JSON 或 CSV 格式
+func readSeedFile(path string) ([]*pb.Book, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("打开演示数据文件失败: %v", err)
+	}
+	defer f.Close()
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return parseSeedJSON(f)
+	case ".csv":
+		return parseSeedCSV(f)
+	default:
+		return nil, fmt.Errorf("不支持的演示数据文件格式: %s", path)
+	}
+}
+
+// parseSeedJSON 解析 JSON 数组格式的图书列表，字段名与 proto 定义一致
+func parseSeedJSON(r io.Reader) ([]*pb.Book, error) {
+	var items []json.RawMessage
+	if err := json.NewDecoder(r).Decode(&items); err != nil {
+		return nil, fmt.Errorf("解析JSON失败: %v", err)
+	}
+
+	books := make([]*pb.Book, 0, len(items))
+	for i, item := range items {
+		book := &pb.Book{}
+		if err := protojson.Unmarshal(item, book); err != nil {
+			return nil, fmt.Errorf("解析第 %d 本图书失败: %v", i+1, err)
+		}
+		books = append(books, book)
+	}
+	return books, nil
+}
+
+// parseSeedCSV 解析带表头的 CSV，支持列: title, author, price, description, publish_year, currency
+func parseSeedCSV(r io.Reader) ([]*pb.Book, error) {
+	records, err := csv.NewReader(r).ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("解析CSV失败: %v", err)
+	}
+	if len(records) == 0 {
+		return nil, nil
+	}
+
+	// 记录每一列对应的字段
+	columns := make(map[string]int)
+	for i, name := range records[0] {
+		columns[strings.TrimSpace(name)] = i
+	}
+	for _, required := range []string{"title", "author", "price"} {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("CSV缺少必需的列: %s", required)
+		}
+	}
+
+	field := func(record []string, name string) string {
+		if i, ok := columns[name]; ok && i < len(record) {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+
+	books := make([]*pb.Book, 0, len(records)-1)
+	for line, record := range records[1:] {
+		price, err := strconv.ParseFloat(field(record, "price"), 32)
+		if err != nil {
+			return nil, fmt.Errorf("第 %d 行价格无效: %v", line+2, err)
+		}
+		book := &pb.Book{
+			Title:    field(record, "title"),
+			Author:   field(record, "author"),
+			Price:    proto.Float32(float32(price)),
+			Currency: field(record, "currency"),
+		}
+		if description := field(record, "description"); description != "" {
+			book.Description = proto.String(description)
+		}
+		if year := field(record, "publish_year"); year != "" {
+			y, err := strconv.ParseInt(year, 10, 32)
+			if err != nil {
+				return nil, fmt.Errorf("第 %d 行出版年份无效: %v", line+2, err)
+			}
+			book.PublishYear = proto.Int32(int32(y))
+		}
+		books = append(books, book)
+	}
+	return books, nil
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	// 导入生成的protobuf代码
+	pb "grpc-basic-server/pb"
+)
+
+// TestSeedDefaultBooks 测试使用 -seed 启动时加载内置演示图书
+func TestSeedDefaultBooks(t *testing.T) {
+	client, _ := startTestServer(t, mustParseConfig(t, "-seed"))
+
+	resp, err := client.ListBooks(context.Background(), &pb.ListBooksRequest{Page: 1, PageSize: 10})
+	if err != nil {
+		t.Fatalf("列出图书失败: %v", err)
+	}
+
+	expected := defaultSeedBooks()
+	if resp.Total != int32(len(expected)) {
+		t.Fatalf("期望总数为%d，实际为: %d", len(expected), resp.Total)
+	}
+
+	// 演示图书按加载顺序分配ID，列表按ID排序
+	for i, book := range resp.Books {
+		if book.Title != expected[i].Title {
+			t.Errorf("第 %d 本图书标题不匹配，期望: %s, 实际: %s", i+1, expected[i].Title, book.Title)
+		}
+	}
+}
+
+// TestSeedFile 测试从 JSON 和 CSV 文件加载演示图书
+func TestSeedFile(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"books.json": `[
+			{"title": "图书1", "author": "作者1", "price": 19.99, "publishYear": 2020},
+			{"title": "图书2", "author": "作者2", "price": 29.99, "description": "描述2"}
+		]`,
+		"books.csv": "title,author,price,description,publish_year\n" +
+			"图书1,作者1,19.99,,2020\n" +
+			"图书2,作者2,29.99,描述2,\n",
+	}
+
+	for name, content := range files {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(dir, name)
+			if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+				t.Fatalf("写入演示数据文件失败: %v", err)
+			}
+
+			client, _ := startTestServer(t, mustParseConfig(t, "-seed-file", path))
+			resp, err := client.ListBooks(context.Background(), &pb.ListBooksRequest{})
+			if err != nil {
+				t.Fatalf("列出图书失败: %v", err)
+			}
+			if resp.Total != 2 {
+				t.Fatalf("期望总数为2，实际为: %d", resp.Total)
+			}
+			if resp.Books[0].GetPublishYear() != 2020 || resp.Books[1].GetDescription() != "描述2" {
+				t.Errorf("演示图书内容不匹配: %v", resp.Books)
+			}
+		})
+	}
+}
+
+// TestSeedFileInvalidBook 测试演示数据中的无效图书会导致启动失败
+func TestSeedFileInvalidBook(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "books.json")
+	if err := os.WriteFile(path, []byte(`[{"title": "图书", "author": "作者", "price": 0}]`), 0o644); err != nil {
+		t.Fatalf("写入演示数据文件失败: %v", err)
+	}
+
+	if _, _, err := newGRPCServer(mustParseConfig(t, "-seed-file", path)); err == nil {
+		t.Error("期望加载无效图书时返回错误")
+	}
+}
@@ -2,12 +2,92 @@ package main
 
 import (
 	"context"
+	"net"
 	"testing"
 
 	// 导入生成的protobuf代码
-	pb "grpc-basic/pb/bookstore"
+	pb "grpc-basic-server/pb"
+
+	// 导入gRPC相关包
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/proto"
 )
 
+// startTestServer 使用 bufconn 按配置启动测试服务器，返回客户端和服务器实例
+func startTestServer(t testing.TB, cfg *config, opts ...ServerOption) (pb.BookServiceClient, *BookServer) {
+	t.Helper()
+
+	conn, bookServer := startTestConn(t, cfg, opts...)
+	return pb.NewBookServiceClient(conn), bookServer
+}
+
+// startTestConn 使用 bufconn 启动测试服务器，返回客户端连接，用于需要访问多个服务的测试
+func startTestConn(t testing.TB, cfg *config, opts ...ServerOption) (*grpc.ClientConn, *BookServer) {
+	t.Helper()
+
+	return startTestConnWith(t, cfg, testHook{}, opts...)
+}
+
+// testHook 定制 startTestConnWith 启动的测试服务器和客户端连接
+type testHook struct {
+	// dialOptions 追加到客户端的拨号选项
+	dialOptions []grpc.DialOption
+	// register 在服务器启动前调用，用于注册额外的服务
+	register func(s *grpc.Server)
+	// server 非空时代替按配置创建的服务器，用于只装配部分拦截器的测试，cfg 和 opts 被忽略
+	server *grpc.Server
+}
+
+// startTestConnWith 与 startTestConn 相同，按 hook 追加拨号选项、注册服务或替换服务器；
+// 替换服务器时返回的 BookServer 为 nil
+func startTestConnWith(t testing.TB, cfg *config, hook testHook, opts ...ServerOption) (*grpc.ClientConn, *BookServer) {
+	t.Helper()
+
+	s, bookServer := hook.server, (*BookServer)(nil)
+	if s == nil {
+		var err error
+		s, bookServer, err = newGRPCServer(cfg, opts...)
+		if err != nil {
+			t.Fatalf("创建测试服务器失败: %v", err)
+		}
+	}
+	if hook.register != nil {
+		hook.register(s)
+	}
+	lis := bufconn.Listen(1 << 20)
+	go s.Serve(lis)
+	t.Cleanup(s.Stop)
+
+	dialOptions := append([]grpc.DialOption{
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	}, hook.dialOptions...)
+	conn, err := grpc.NewClient("passthrough:///bufnet", dialOptions...)
+	if err != nil {
+		t.Fatalf("连接测试服务器失败: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	return conn, bookServer
+}
+
+// mustParseConfig 解析测试用的命令行参数
+func mustParseConfig(t testing.TB, args ...string) *config {
+	t.Helper()
+
+	cfg, err := parseConfig(args)
+	if err != nil {
+		t.Fatalf("解析参数失败: %v", err)
+	}
+	return cfg
+}
+
 // TestCreateBook 测试创建图书功能
 func TestCreateBook(t *testing.T) {
 	// 创建服务器实例
@@ -17,9 +97,9 @@ func TestCreateBook(t *testing.T) {
 	book := &pb.Book{
 		Title:       "测试图书",
 		Author:      "测试作者",
-		Price:       29.99,
-		Description: "这是一本测试图书",
-		PublishYear: 2023,
+		Price:       proto.Float32(29.99),
+		Description: proto.String("这是一本测试图书"),
+		PublishYear: proto.Int32(2023),
 	}
 
 	// 创建请求
@@ -58,9 +138,9 @@ func TestGetBook(t *testing.T) {
 	book := &pb.Book{
 		Title:       "测试图书",
 		Author:      "测试作者",
-		Price:       29.99,
-		Description: "这是一本测试图书",
-		PublishYear: 2023,
+		Price:       proto.Float32(29.99),
+		Description: proto.String("这是一本测试图书"),
+		PublishYear: proto.Int32(2023),
 	}
 
 	createReq := &pb.CreateBookRequest{Book: book}
@@ -96,9 +176,9 @@ func TestUpdateBook(t *testing.T) {
 	book := &pb.Book{
 		Title:       "原始图书",
 		Author:      "原始作者",
-		Price:       29.99,
-		Description: "原始描述",
-		PublishYear: 2023,
+		Price:       proto.Float32(29.99),
+		Description: proto.String("原始描述"),
+		PublishYear: proto.Int32(2023),
 	}
 
 	createReq := &pb.CreateBookRequest{Book: book}
@@ -112,9 +192,9 @@ func TestUpdateBook(t *testing.T) {
 		Id:          createResp.Id,
 		Title:       "更新后的图书",
 		Author:      "更新后的作者",
-		Price:       39.99,
-		Description: "更新后的描述",
-		PublishYear: 2024,
+		Price:       proto.Float32(39.99),
+		Description: proto.String("更新后的描述"),
+		PublishYear: proto.Int32(2024),
 	}
 
 	updateReq := &pb.UpdateBookRequest{Book: updatedBook}
@@ -146,9 +226,9 @@ func TestDeleteBook(t *testing.T) {
 	book := &pb.Book{
 		Title:       "要删除的图书",
 		Author:      "作者",
-		Price:       29.99,
-		Description: "描述",
-		PublishYear: 2023,
+		Price:       proto.Float32(29.99),
+		Description: proto.String("描述"),
+		PublishYear: proto.Int32(2023),
 	}
 
 	createReq := &pb.CreateBookRequest{Book: book}
@@ -176,6 +256,30 @@ func TestDeleteBook(t *testing.T) {
 	}
 }
 
+// TestDeleteBookNotFound 测试删除不存在的图书：默认返回NotFound，幂等模式返回成功
+func TestDeleteBookNotFound(t *testing.T) {
+	// 创建服务器实例
+	server := NewBookServer()
+	ids := server.loadBooks([]*pb.Book{{Title: "图书", Author: "作者", Price: proto.Float32(10)}})
+
+	// 严格模式：图书不存在时返回NotFound
+	_, err := server.DeleteBook(context.Background(), &pb.DeleteBookRequest{Id: "book-999"})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("期望错误码为NotFound，实际为: %v", status.Code(err))
+	}
+
+	// 幂等模式：第一次删除成功，重复删除同样成功但 deleted 为 false
+	for i, deleted := range []bool{true, false} {
+		resp, err := server.DeleteBook(context.Background(), &pb.DeleteBookRequest{Id: ids[0], IgnoreNotFound: true})
+		if err != nil {
+			t.Fatalf("第%d次删除失败: %v", i+1, err)
+		}
+		if resp.Deleted != deleted {
+			t.Errorf("第%d次删除期望 deleted 为%v，实际为: %v", i+1, deleted, resp.Deleted)
+		}
+	}
+}
+
 // TestListBooks 测试列出图书功能
 func TestListBooks(t *testing.T) {
 	// 创建服务器实例
@@ -183,9 +287,9 @@ func TestListBooks(t *testing.T) {
 
 	// 创建多本图书
 	books := []*pb.Book{
-		{Title: "图书1", Author: "作者1", Price: 29.99, Description: "描述1", PublishYear: 2023},
-		{Title: "图书2", Author: "作者2", Price: 39.99, Description: "描述2", PublishYear: 2024},
-		{Title: "图书3", Author: "作者3", Price: 49.99, Description: "描述3", PublishYear: 2025},
+		{Title: "图书1", Author: "作者1", Price: proto.Float32(29.99), Description: proto.String("描述1"), PublishYear: proto.Int32(2023)},
+		{Title: "图书2", Author: "作者2", Price: proto.Float32(39.99), Description: proto.String("描述2"), PublishYear: proto.Int32(2024)},
+		{Title: "图书3", Author: "作者3", Price: proto.Float32(49.99), Description: proto.String("描述3"), PublishYear: proto.Int32(2025)},
 	}
 
 	// 创建图书
@@ -222,9 +326,9 @@ func TestSearchBooksByPrice(t *testing.T) {
 
 	// 创建不同价格的图书
 	books := []*pb.Book{
-		{Title: "便宜图书", Author: "作者1", Price: 19.99, Description: "描述1", PublishYear: 2023},
-		{Title: "中等图书", Author: "作者2", Price: 39.99, Description: "描述2", PublishYear: 2024},
-		{Title: "昂贵图书", Author: "作者3", Price: 59.99, Description: "描述3", PublishYear: 2025},
+		{Title: "便宜图书", Author: "作者1", Price: proto.Float32(19.99), Description: proto.String("描述1"), PublishYear: proto.Int32(2023)},
+		{Title: "中等图书", Author: "作者2", Price: proto.Float32(39.99), Description: proto.String("描述2"), PublishYear: proto.Int32(2024)},
+		{Title: "昂贵图书", Author: "作者3", Price: proto.Float32(59.99), Description: proto.String("描述3"), PublishYear: proto.Int32(2025)},
 	}
 
 	// 创建图书
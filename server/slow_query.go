@@ -0,0 +1,45 @@
+package main
+
+import (
+	"context"
+	"time"
+)
+
+// scanStatsKey 在 context 中保存本次调用扫描统计的键
+type scanStatsKey struct{}
+
+// scanStats 查询类处理器记录的扫描统计：遍历的图书数和匹配的图书数，供慢查询日志使用
+type scanStats struct {
+	recorded bool
+	scanned  int
+	matched  int
+}
+
+// withScanStats 返回可以记录扫描统计的 context
+func withScanStats(ctx context.Context) (context.Context, *scanStats) {
+	stats := &scanStats{}
+	return context.WithValue(ctx, scanStatsKey{}, stats), stats
+}
+
+// recordScan 记录查询遍历的图书数和匹配的图书数，一次调用多次扫描时累加；
+// 没有开启慢查询日志（context 中没有统计）时不做任何操作
+func recordScan(ctx context.Context, scanned, matched int) {
+	stats, ok := ctx.Value(scanStatsKey{}).(*scanStats)
+	if !ok {
+		return
+	}
+	stats.recorded = true
+	stats.scanned += scanned
+	stats.matched += matched
+}
+
+// logSlowQuery 记录了扫描统计的调用耗时达到阈值时，记录查询参数（经过脱敏）、遍历和匹配的图书数，
+// 便于找出需要索引的查询
+func logSlowQuery(logger Logger, opts logInterceptorOptions, method, reqID string, req interface{}, duration time.Duration, stats *scanStats) {
+	if stats == nil || !stats.recorded || duration < opts.slowQueryThreshold {
+		return
+	}
+	fields := []interface{}{"method", method, "request_id", reqID, "duration", duration, "scanned", stats.scanned, "matched", stats.matched}
+	fields = appendJSONField(fields, "request", req, opts.redactor)
+	logger.Warn("慢查询", fields...)
+}
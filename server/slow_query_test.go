@@ -0,0 +1,56 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	// 导入生成的protobuf代码
+	pb "grpc-basic-server/pb"
+
+	// 导入gRPC相关包
+	"google.golang.org/protobuf/proto"
+)
+
+// TestSlowQueryLog 测试查询耗时达到阈值时记录遍历和匹配的图书数
+func TestSlowQueryLog(t *testing.T) {
+	logger := &captureLogger{}
+	client, server := startTestServer(t, mustParseConfig(t, "-slow-query-threshold", "1ns"), WithLogger(logger))
+
+	books := make([]*pb.Book, 2000)
+	for i := range books {
+		books[i] = &pb.Book{Title: fmt.Sprintf("图书%d", i), Author: "作者", Price: proto.Float32(float32(i%100 + 1))}
+	}
+	server.loadBooks(books)
+	ctx := context.Background()
+
+	if _, err := client.ListBooks(ctx, &pb.ListBooksRequest{Page: 1, PageSize: 10}); err != nil {
+		t.Fatalf("列出图书失败: %v", err)
+	}
+	if want := "scanned=2000 matched=10"; !logger.contains("慢查询 method=/bookstore.BookService/ListBooks") || !logger.contains(want) {
+		t.Errorf("期望记录 ListBooks 的慢查询日志（%s），实际为: %v", want, logger.lines)
+	}
+
+	if _, err := client.GetBooksByTitles(ctx, &pb.GetBooksByTitlesRequest{Titles: []string{"图书7"}}); err != nil {
+		t.Fatalf("按标题查询失败: %v", err)
+	}
+	if want := "scanned=2000 matched=1"; !logger.contains(want) {
+		t.Errorf("期望记录 GetBooksByTitles 的慢查询日志（%s），实际为: %v", want, logger.lines)
+	}
+
+	// 通过价格索引只遍历区间内的图书
+	if _, err := client.SearchBooksByPrice(ctx, &pb.SearchBooksByPriceRequest{MinPrice: 1, MaxPrice: 2}); err != nil {
+		t.Fatalf("按价格查询失败: %v", err)
+	}
+	if want := "scanned=40 matched=40"; !logger.contains(want) {
+		t.Errorf("期望记录 SearchBooksByPrice 的慢查询日志（%s），实际为: %v", want, logger.lines)
+	}
+
+	// 非查询类调用不记录
+	if _, err := client.GetBook(ctx, &pb.GetBookRequest{Id: "book-1"}); err != nil {
+		t.Fatalf("获取图书失败: %v", err)
+	}
+	if logger.contains("慢查询 method=/bookstore.BookService/GetBook ") {
+		t.Errorf("GetBook 不应记录慢查询日志: %v", logger.lines)
+	}
+}
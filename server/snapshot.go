@@ -0,0 +1,160 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"sort"
+	"time"
+
+	// 导入生成的protobuf代码
+	pb "grpc-basic-server/pb"
+
+	// 导入gRPC相关包
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// defaultSnapshotTTL 快照的默认有效期
+const defaultSnapshotTTL = 5 * time.Minute
+
+// snapshot 某一时刻图书存储的只读副本
+type snapshot struct {
+	// 快照所属的租户，只允许同一租户使用
+	tenant string
+
+	// 按ID排序的图书副本，创建后不再修改
+	books []*pb.Book
+
+	// 过期时间
+	expiresAt time.Time
+}
+
+// OpenSnapshot 打开只读快照，后续分页请求携带令牌即可获得一致的结果
+func (s *BookServer) OpenSnapshot(ctx context.Context, _ *emptypb.Empty) (*pb.SnapshotResponse, error) {
+	// 记录请求日志
+	s.logger.Info("收到打开快照请求")
+
+	token, err := newRandomToken()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "生成快照令牌失败: %v", err)
+	}
+
+	// 在读锁内复制图书，之后的修改不会影响快照
+	s.mu.RLock()
+	catalog := s.catalogFor(ctx, false)
+	books := make([]*pb.Book, 0, len(catalog.books))
+	for _, book := range catalog.books {
+		books = append(books, proto.Clone(book).(*pb.Book))
+	}
+	s.mu.RUnlock()
+
+	sortBooksByID(books)
+
+	s.snapMu.Lock()
+	s.snapshots[token] = &snapshot{
+		tenant:    s.tenantID(ctx),
+		books:     books,
+		expiresAt: s.clock.Now().Add(s.snapshotTTL),
+	}
+	s.snapMu.Unlock()
+
+	s.logger.Info("成功打开快照", "books", len(books))
+
+	return &pb.SnapshotResponse{Token: token}, nil
+}
+
+// booksForRead 返回调用方租户按ID排序的图书列表，token 非空时从对应快照读取
+func (s *BookServer) booksForRead(ctx context.Context, token string) ([]*pb.Book, error) {
+	if token != "" {
+		s.snapMu.Lock()
+		defer s.snapMu.Unlock()
+
+		snap, exists := s.snapshots[token]
+		if !exists || snap.tenant != s.tenantID(ctx) {
+			return nil, status.Errorf(codes.NotFound, "快照不存在或已被回收")
+		}
+		if s.clock.Now().After(snap.expiresAt) {
+			delete(s.snapshots, token)
+			return nil, status.Errorf(codes.FailedPrecondition, "快照已过期，请重新打开快照")
+		}
+		return s.filterForRead(snap.books), nil
+	}
+
+	// 加读锁保护并发访问
+	s.mu.RLock()
+	catalog := s.catalogFor(ctx, false)
+	books := make([]*pb.Book, 0, len(catalog.books))
+	for _, book := range catalog.books {
+		books = append(books, book)
+	}
+	s.mu.RUnlock()
+
+	sortBooksByID(books)
+	return s.filterForRead(books), nil
+}
+
+// removeExpiredSnapshots 回收所有已过期的快照，返回回收数量
+func (s *BookServer) removeExpiredSnapshots(now time.Time) int {
+	s.snapMu.Lock()
+	defer s.snapMu.Unlock()
+
+	removed := 0
+	for token, snap := range s.snapshots {
+		if now.After(snap.expiresAt) {
+			delete(s.snapshots, token)
+			removed++
+		}
+	}
+	return removed
+}
+
+// runSnapshotJanitor 定期回收过期快照，直到 ctx 被取消
+func (s *BookServer) runSnapshotJanitor(ctx context.Context, interval time.Duration) {
+	ticker := s.clock.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C():
+			if removed := s.removeExpiredSnapshots(now); removed > 0 {
+				s.logger.Info("回收过期快照", "count", removed)
+			}
+		}
+	}
+}
+
+// newRandomToken 生成随机令牌，用于快照令牌、预留ID等
+func newRandomToken() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+// sortBooksByID 按ID自然顺序排序（book-2 排在 book-10 之前），保证分页稳定
+func sortBooksByID(books []*pb.Book) {
+	sort.Slice(books, func(i, j int) bool {
+		return idLess(books[i].GetId(), books[j].GetId())
+	})
+}
+
+// sortIDs 按ID自然顺序排序ID列表
+func sortIDs(ids []string) {
+	sort.Slice(ids, func(i, j int) bool {
+		return idLess(ids[i], ids[j])
+	})
+}
+
+// idLess 比较两个ID的自然顺序：较短的ID在前，长度相同时按字典序
+func idLess(a, b string) bool {
+	if len(a) != len(b) {
+		return len(a) < len(b)
+	}
+	return a < b
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	// 导入生成的protobuf代码
+	pb "grpc-basic-server/pb"
+
+	// 导入gRPC相关包
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// TestSnapshotPagination 测试基于快照分页时不受并发创建影响
+func TestSnapshotPagination(t *testing.T) {
+	// 创建服务器实例
+	server := NewBookServer()
+
+	// 先创建5本图书
+	original := make(map[string]bool)
+	for i := 0; i < 5; i++ {
+		resp, err := server.CreateBook(context.Background(), &pb.CreateBookRequest{
+			Book: &pb.Book{Title: fmt.Sprintf("图书%d", i), Author: "作者", Price: proto.Float32(10)},
+		})
+		if err != nil {
+			t.Fatalf("创建图书失败: %v", err)
+		}
+		original[resp.Id] = true
+	}
+
+	// 打开快照
+	snap, err := server.OpenSnapshot(context.Background(), &emptypb.Empty{})
+	if err != nil {
+		t.Fatalf("打开快照失败: %v", err)
+	}
+
+	// 分页的同时并发创建新图书
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 20; i++ {
+			server.CreateBook(context.Background(), &pb.CreateBookRequest{
+				Book: &pb.Book{Title: fmt.Sprintf("新图书%d", i), Author: "作者", Price: proto.Float32(10)},
+			})
+		}
+	}()
+
+	// 基于快照逐页读取
+	seen := make(map[string]bool)
+	for page := int32(1); ; page++ {
+		resp, err := server.ListBooks(context.Background(), &pb.ListBooksRequest{
+			Page: page, PageSize: 2, SnapshotToken: snap.Token,
+		})
+		if err != nil {
+			t.Fatalf("列出图书失败: %v", err)
+		}
+		if resp.Total != 5 {
+			t.Errorf("快照总数应保持为5，实际为: %d", resp.Total)
+		}
+		if len(resp.Books) == 0 {
+			break
+		}
+		for _, book := range resp.Books {
+			if seen[book.Id] {
+				t.Errorf("图书重复出现: %s", book.Id)
+			}
+			seen[book.Id] = true
+		}
+	}
+	wg.Wait()
+
+	// 验证只包含快照时的图书
+	if len(seen) != len(original) {
+		t.Errorf("期望读取5本图书，实际为: %d", len(seen))
+	}
+	for id := range seen {
+		if !original[id] {
+			t.Errorf("快照中出现了新创建的图书: %s", id)
+		}
+	}
+}
+
+// TestSnapshotExpired 测试过期快照会被拒绝和回收
+func TestSnapshotExpired(t *testing.T) {
+	// 使用测试时钟，推进时间超过有效期
+	clock := newFakeClock()
+	server := NewBookServer(WithClock(clock), WithSnapshotTTL(time.Minute))
+
+	snap, err := server.OpenSnapshot(context.Background(), &emptypb.Empty{})
+	if err != nil {
+		t.Fatalf("打开快照失败: %v", err)
+	}
+	clock.Advance(2 * time.Minute)
+
+	_, err = server.ListBooks(context.Background(), &pb.ListBooksRequest{SnapshotToken: snap.Token})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("期望错误码为FailedPrecondition，实际为: %v", status.Code(err))
+	}
+
+	// 过期快照被访问后即回收，再次访问返回 NotFound
+	_, err = server.ListBooks(context.Background(), &pb.ListBooksRequest{SnapshotToken: snap.Token})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("期望错误码为NotFound，实际为: %v", status.Code(err))
+	}
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	// 导入生成的protobuf代码
+	pb "grpc-basic-server/pb"
+
+	// 导入gRPC相关包
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// defaultShutdownTimeout 优雅关闭时等待进行中请求完成的默认最长时间
+const defaultShutdownTimeout = 10 * time.Second
+
+// GetStats 获取服务运行状态
+func (s *BookServer) GetStats(ctx context.Context, _ *emptypb.Empty) (*pb.StatsResponse, error) {
+	return &pb.StatsResponse{
+		InFlightRequests: s.inFlight.Load(),
+		BookCount:        int32(s.bookCount()),
+		RequestSizes:     s.requestSizes.snapshot(),
+		PanicsTotal:      s.panics.snapshot(),
+	}, nil
+}
+
+// bookCount 返回所有租户的图书总数
+func (s *BookServer) bookCount() int {
+	// 加读锁保护并发访问
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	count := len(s.books)
+	for _, catalog := range s.tenants {
+		count += len(catalog.books)
+	}
+	return count
+}
+
+// inFlightInterceptor 统计正在处理中的请求数量
+func (s *BookServer) inFlightInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	s.inFlight.Add(1)
+	defer s.inFlight.Add(-1)
+
+	return handler(ctx, req)
+}
+
+// inFlightStreamInterceptor 统计正在处理中的流式请求，与一元请求计入同一个数量
+func (s *BookServer) inFlightStreamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	s.inFlight.Add(1)
+	defer s.inFlight.Add(-1)
+
+	return handler(srv, ss)
+}
+
+// beginShutdown 通知长时间运行的流服务正在关闭，可以重复调用
+func (s *BookServer) beginShutdown() {
+	s.shutdownOnce.Do(func() { close(s.shuttingDown) })
+}
+
+// gracefulShutdown 优雅关闭服务器：通知长时间运行的流结束，等待进行中的请求完成，期间定期打印剩余数量，
+// 超过 timeout 后强制停止。返回 true 表示所有请求都已正常完成
+func gracefulShutdown(s *grpc.Server, bookServer *BookServer, timeout, logInterval time.Duration) bool {
+	bookServer.beginShutdown()
+
+	done := make(chan struct{})
+	go func() {
+		s.GracefulStop()
+		close(done)
+	}()
+
+	ticker := time.NewTicker(logInterval)
+	defer ticker.Stop()
+	deadline := time.NewTimer(timeout)
+	defer deadline.Stop()
+
+	for {
+		select {
+		case <-done:
+			bookServer.logger.Info("所有进行中的请求已完成")
+			return true
+		case <-ticker.C:
+			bookServer.logger.Info("等待进行中的请求完成", "in_flight", bookServer.inFlight.Load())
+		case <-deadline.C:
+			bookServer.logger.Warn("等待超时，强制停止服务，进行中的请求将被中止", "in_flight", bookServer.inFlight.Load(), "timeout", timeout)
+			s.Stop()
+			<-done
+			return false
+		}
+	}
+}
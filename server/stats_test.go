@@ -0,0 +1,159 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	// 导入生成的protobuf代码
+	pb "grpc-basic-server/pb"
+
+	// 导入gRPC相关包
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// TestGetStats 测试运行状态中的图书数量
+func TestGetStats(t *testing.T) {
+	// 创建服务器实例
+	server := NewBookServer()
+
+	for i := 0; i < 2; i++ {
+		req := &pb.CreateBookRequest{Book: &pb.Book{Title: "图书", Author: "作者", Price: proto.Float32(10)}}
+		if _, err := server.CreateBook(context.Background(), req); err != nil {
+			t.Fatalf("创建图书失败: %v", err)
+		}
+	}
+
+	resp, err := server.GetStats(context.Background(), &emptypb.Empty{})
+	if err != nil {
+		t.Fatalf("获取运行状态失败: %v", err)
+	}
+	if resp.BookCount != 2 {
+		t.Errorf("期望图书数量为2，实际为: %d", resp.BookCount)
+	}
+}
+
+// TestGracefulShutdownDrainsInFlight 测试优雅关闭时进行中的请求计数
+func TestGracefulShutdownDrainsInFlight(t *testing.T) {
+	bookServer := NewBookServer()
+
+	// 阻塞 ListBooks，模拟慢请求
+	started := make(chan struct{})
+	release := make(chan struct{})
+	slow := func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if info.FullMethod == "/bookstore.BookService/ListBooks" {
+			close(started)
+			<-release
+		}
+		return handler(ctx, req)
+	}
+
+	s := grpc.NewServer(grpc.ChainUnaryInterceptor(bookServer.inFlightInterceptor, slow))
+	pb.RegisterBookServiceServer(s, bookServer)
+	conn, _ := startTestConnWith(t, nil, testHook{server: s})
+	client := pb.NewBookServiceClient(conn)
+
+	// 发起慢请求
+	callErr := make(chan error, 1)
+	go func() {
+		_, err := client.ListBooks(context.Background(), &pb.ListBooksRequest{})
+		callErr <- err
+	}()
+	<-started
+
+	// 触发优雅关闭
+	result := make(chan bool, 1)
+	go func() {
+		result <- gracefulShutdown(s, bookServer, 5*time.Second, 10*time.Millisecond)
+	}()
+
+	// 关闭期间计数应反映进行中的请求
+	time.Sleep(50 * time.Millisecond)
+	if n := bookServer.inFlight.Load(); n != 1 {
+		t.Errorf("期望进行中的请求数为1，实际为: %d", n)
+	}
+
+	// 放行慢请求，关闭应正常完成
+	close(release)
+	if err := <-callErr; err != nil {
+		t.Errorf("慢请求应正常完成: %v", err)
+	}
+	if !<-result {
+		t.Error("期望优雅关闭正常完成，实际为强制停止")
+	}
+	if n := bookServer.inFlight.Load(); n != 0 {
+		t.Errorf("关闭后进行中的请求数应为0，实际为: %d", n)
+	}
+}
+
+// TestGracefulShutdownForcedStop 测试请求超过关闭等待时间时强制停止，进行中的请求被中止
+func TestGracefulShutdownForcedStop(t *testing.T) {
+	logger := &captureLogger{}
+	bookServer := NewBookServer(WithLogger(logger))
+
+	// 阻塞 ListBooks 直到请求被取消，模拟不会自行结束的请求
+	started := make(chan struct{})
+	blocking := func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		close(started)
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+
+	s := grpc.NewServer(grpc.ChainUnaryInterceptor(bookServer.inFlightInterceptor, blocking))
+	pb.RegisterBookServiceServer(s, bookServer)
+	conn, _ := startTestConnWith(t, nil, testHook{server: s})
+	client := pb.NewBookServiceClient(conn)
+
+	callErr := make(chan error, 1)
+	go func() {
+		_, err := client.ListBooks(context.Background(), &pb.ListBooksRequest{})
+		callErr <- err
+	}()
+	<-started
+
+	if gracefulShutdown(s, bookServer, 50*time.Millisecond, 10*time.Millisecond) {
+		t.Error("期望等待超时后强制停止，实际为正常完成")
+	}
+	if err := <-callErr; err == nil {
+		t.Error("期望被中止的请求返回错误")
+	}
+	if !logger.contains("强制停止服务") {
+		t.Errorf("期望记录强制停止日志，实际为: %v", logger.lines)
+	}
+}
+
+// TestGracefulShutdownEndsHistogramStream 测试进行中的价格分布流计入进行中的请求，
+// 开始关闭时流以 Unavailable 结束，不必等到关闭超时
+func TestGracefulShutdownEndsHistogramStream(t *testing.T) {
+	bookServer := NewBookServer()
+
+	s := grpc.NewServer(grpc.ChainStreamInterceptor(bookServer.inFlightStreamInterceptor))
+	pb.RegisterBookServiceServer(s, bookServer)
+	conn, _ := startTestConnWith(t, nil, testHook{server: s})
+	client := pb.NewBookServiceClient(conn)
+
+	stream, err := client.StreamPriceHistogram(context.Background(), &pb.StreamPriceHistogramRequest{BucketWidth: 10})
+	if err != nil {
+		t.Fatalf("打开价格分布流失败: %v", err)
+	}
+	if _, err := stream.Recv(); err != nil {
+		t.Fatalf("接收价格分布失败: %v", err)
+	}
+	if n := bookServer.inFlight.Load(); n != 1 {
+		t.Errorf("期望进行中的请求数为1，实际为: %d", n)
+	}
+
+	if !gracefulShutdown(s, bookServer, 5*time.Second, 10*time.Millisecond) {
+		t.Error("期望价格分布流结束后优雅关闭正常完成，实际为强制停止")
+	}
+	if _, err := stream.Recv(); status.Code(err) != codes.Unavailable {
+		t.Errorf("期望价格分布流返回 Unavailable，实际为: %v", err)
+	}
+	if n := bookServer.inFlight.Load(); n != 0 {
+		t.Errorf("关闭后进行中的请求数应为0，实际为: %d", n)
+	}
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"context"
+	"math"
+
+	// 导入生成的protobuf代码
+	pb "grpc-basic-server/pb"
+
+	// 导入gRPC相关包
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// PurchaseBook 购买图书，在写锁内原子地扣减库存
+func (s *BookServer) PurchaseBook(ctx context.Context, req *pb.PurchaseBookRequest) (*pb.PurchaseBookResponse, error) {
+	// 记录请求日志
+	s.logger.Info("收到购买图书请求", "id", req.GetId(), "quantity", req.GetQuantity())
+
+	// 验证请求参数
+	if err := validateStockRequest(req.GetId(), req.GetQuantity()); err != nil {
+		return nil, err
+	}
+
+	// 加写锁，保证检查库存和扣减库存是原子操作
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	catalog := s.catalogFor(ctx, false)
+	book, err := catalog.get(req.GetId())
+	if err != nil {
+		s.logger.Warn("图书不存在，无法购买", "id", req.GetId())
+		return nil, s.storeErrToStatus(err)
+	}
+	// 已被预留的库存不能再购买
+	available := book.GetStock() - catalog.reservedStock(req.GetId(), s.clock.Now())
+	if available < req.GetQuantity() {
+		s.logger.Warn("库存不足", "id", req.GetId(), "available", available, "quantity", req.GetQuantity())
+		return nil, status.Errorf(codes.FailedPrecondition, "库存不足，当前可用库存: %d", available)
+	}
+
+	// 替换为新的副本，不原地修改已存储的图书
+	updated := proto.Clone(book).(*pb.Book)
+	updated.Stock -= req.GetQuantity()
+	catalog.put(updated, s.clock.Now())
+
+	s.logger.Info("成功购买图书", "id", req.GetId(), "stock", updated.GetStock())
+
+	return &pb.PurchaseBookResponse{
+		RemainingStock: updated.GetStock(),
+	}, nil
+}
+
+// RestockBook 补充图书库存
+func (s *BookServer) RestockBook(ctx context.Context, req *pb.RestockBookRequest) (*pb.RestockBookResponse, error) {
+	// 记录请求日志
+	s.logger.Info("收到补充库存请求", "id", req.GetId(), "quantity", req.GetQuantity())
+
+	// 验证请求参数
+	if err := validateStockRequest(req.GetId(), req.GetQuantity()); err != nil {
+		return nil, err
+	}
+
+	// 加写锁保护并发访问
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	catalog := s.catalogFor(ctx, false)
+	book, err := catalog.get(req.GetId())
+	if err != nil {
+		s.logger.Warn("图书不存在，无法补充库存", "id", req.GetId())
+		return nil, s.storeErrToStatus(err)
+	}
+	if book.GetStock() > math.MaxInt32-req.GetQuantity() {
+		return nil, status.Errorf(codes.InvalidArgument, "补充后库存超出上限")
+	}
+
+	// 替换为新的副本，不原地修改已存储的图书
+	updated := proto.Clone(book).(*pb.Book)
+	updated.Stock += req.GetQuantity()
+	catalog.put(updated, s.clock.Now())
+
+	s.logger.Info("成功补充库存", "id", req.GetId(), "stock", updated.GetStock())
+
+	return &pb.RestockBookResponse{
+		Stock: updated.GetStock(),
+	}, nil
+}
+
+// validateStockRequest 验证库存操作的图书ID和数量
+func validateStockRequest(id string, quantity int32) error {
+	if id == "" {
+		return status.Errorf(codes.InvalidArgument, "图书ID不能为空")
+	}
+	if quantity <= 0 {
+		return status.Errorf(codes.InvalidArgument, "数量必须大于0")
+	}
+	return nil
+}
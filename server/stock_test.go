@@ -0,0 +1,91 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	// 导入生成的protobuf代码
+	pb "grpc-basic-server/pb"
+
+	// 导入gRPC相关包
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// TestPurchaseBook 测试购买图书扣减库存
+func TestPurchaseBook(t *testing.T) {
+	// 创建服务器实例
+	server := NewBookServer()
+	ids := server.loadBooks([]*pb.Book{{Title: "图书", Author: "作者", Price: proto.Float32(10), Stock: 5}})
+
+	resp, err := server.PurchaseBook(context.Background(), &pb.PurchaseBookRequest{Id: ids[0], Quantity: 3})
+	if err != nil {
+		t.Fatalf("购买图书失败: %v", err)
+	}
+	if resp.RemainingStock != 2 {
+		t.Errorf("期望剩余库存为2，实际为: %d", resp.RemainingStock)
+	}
+	if stock := server.books[ids[0]].GetStock(); stock != 2 {
+		t.Errorf("期望存储的库存为2，实际为: %d", stock)
+	}
+}
+
+// TestPurchaseBookInsufficientStock 测试库存不足时拒绝购买且库存不变
+func TestPurchaseBookInsufficientStock(t *testing.T) {
+	server := NewBookServer()
+	ids := server.loadBooks([]*pb.Book{{Title: "图书", Author: "作者", Price: proto.Float32(10), Stock: 2}})
+
+	_, err := server.PurchaseBook(context.Background(), &pb.PurchaseBookRequest{Id: ids[0], Quantity: 3})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("期望错误码为FailedPrecondition，实际为: %v", status.Code(err))
+	}
+	if stock := server.books[ids[0]].GetStock(); stock != 2 {
+		t.Errorf("购买失败后库存不应改变，实际为: %d", stock)
+	}
+}
+
+// TestRestockBook 测试补充库存
+func TestRestockBook(t *testing.T) {
+	server := NewBookServer()
+	ids := server.loadBooks([]*pb.Book{{Title: "图书", Author: "作者", Price: proto.Float32(10), Stock: 1}})
+
+	resp, err := server.RestockBook(context.Background(), &pb.RestockBookRequest{Id: ids[0], Quantity: 4})
+	if err != nil {
+		t.Fatalf("补充库存失败: %v", err)
+	}
+	if resp.Stock != 5 {
+		t.Errorf("期望库存为5，实际为: %d", resp.Stock)
+	}
+}
+
+// TestStockRequestErrors 测试库存操作的参数校验
+func TestStockRequestErrors(t *testing.T) {
+	server := NewBookServer()
+	ids := server.loadBooks([]*pb.Book{{Title: "图书", Author: "作者", Price: proto.Float32(10), Stock: 1}})
+	ctx := context.Background()
+
+	tests := []struct {
+		name string
+		id   string
+		qty  int32
+		code codes.Code
+	}{
+		{"数量为0", ids[0], 0, codes.InvalidArgument},
+		{"数量为负数", ids[0], -1, codes.InvalidArgument},
+		{"空ID", "", 1, codes.InvalidArgument},
+		{"图书不存在", "book-999", 1, codes.NotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := server.PurchaseBook(ctx, &pb.PurchaseBookRequest{Id: tt.id, Quantity: tt.qty})
+			if status.Code(err) != tt.code {
+				t.Errorf("购买期望错误码为%v，实际为: %v", tt.code, status.Code(err))
+			}
+			_, err = server.RestockBook(ctx, &pb.RestockBookRequest{Id: tt.id, Quantity: tt.qty})
+			if status.Code(err) != tt.code {
+				t.Errorf("补充库存期望错误码为%v，实际为: %v", tt.code, status.Code(err))
+			}
+		})
+	}
+}
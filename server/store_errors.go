@@ -0,0 +1,52 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	// 导入生成的protobuf代码
+	pb "grpc-basic-server/pb"
+
+	// 导入gRPC相关包
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// 图书存储返回的错误，处理器通过 storeErrToStatus 统一转换为 gRPC 错误码
+var (
+	// ErrNotFound 图书不存在
+	ErrNotFound = errors.New("图书不存在")
+
+	// ErrAlreadyExists 图书已存在
+	ErrAlreadyExists = errors.New("图书已存在")
+
+	// ErrConflict 图书已被并发修改
+	ErrConflict = errors.New("图书已被其他请求修改")
+)
+
+// get 查找图书，不存在时返回包装了 ErrNotFound 的错误，调用方需持有 s.mu
+func (c *bookCatalog) get(id string) (*pb.Book, error) {
+	book, exists := c.books[id]
+	if !exists {
+		return nil, fmt.Errorf("%w，ID: %s", ErrNotFound, id)
+	}
+	return book, nil
+}
+
+// storeErrToStatus 将存储错误转换为 gRPC 错误：已知错误映射为对应的错误码，
+// 未知错误记录原始信息后返回 Internal，不向客户端泄露内部细节
+func (s *BookServer) storeErrToStatus(err error) error {
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, ErrNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, ErrAlreadyExists):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, ErrConflict):
+		return status.Error(codes.Aborted, err.Error())
+	default:
+		s.logger.Error("存储操作失败", "error", err)
+		return status.Error(codes.Internal, "内部错误")
+	}
+}
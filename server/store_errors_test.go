@@ -0,0 +1,54 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	// 导入gRPC相关包
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// TestStoreErrToStatus 测试存储错误到 gRPC 错误码的映射
+func TestStoreErrToStatus(t *testing.T) {
+	server := NewBookServer(WithLogger(&captureLogger{}))
+
+	tests := []struct {
+		name string
+		err  error
+		code codes.Code
+	}{
+		{"图书不存在", ErrNotFound, codes.NotFound},
+		{"包装后的图书不存在", fmt.Errorf("%w，ID: book-1", ErrNotFound), codes.NotFound},
+		{"图书已存在", ErrAlreadyExists, codes.AlreadyExists},
+		{"并发修改冲突", ErrConflict, codes.Aborted},
+		{"未知错误", errors.New("driver: connection reset"), codes.Internal},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if code := status.Code(server.storeErrToStatus(tt.err)); code != tt.code {
+				t.Errorf("期望错误码为%v，实际为: %v", tt.code, code)
+			}
+		})
+	}
+
+	if err := server.storeErrToStatus(nil); err != nil {
+		t.Errorf("nil 应映射为 nil，实际为: %v", err)
+	}
+}
+
+// TestStoreErrToStatusHidesInternal 测试未知错误只记录日志，不泄露给客户端
+func TestStoreErrToStatusHidesInternal(t *testing.T) {
+	logger := &captureLogger{}
+	server := NewBookServer(WithLogger(logger))
+
+	err := server.storeErrToStatus(errors.New("driver: password=secret"))
+	if strings.Contains(status.Convert(err).Message(), "secret") {
+		t.Errorf("错误信息不应包含内部细节: %v", err)
+	}
+	if !logger.contains("driver: password=secret") {
+		t.Errorf("期望记录原始错误，实际为: %v", logger.lines)
+	}
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"time"
+
+	// 导入生成的protobuf代码
+	pb "grpc-basic-server/pb"
+
+	// 导入gRPC相关包
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// defaultStreamGrace 流式请求允许部分结果时，距离截止时间小于该值即提前结束
+const defaultStreamGrace = 200 * time.Millisecond
+
+// StreamBooks 按ID顺序流式返回调用方租户的所有图书，设置过滤条件时只返回符合条件的图书。
+// 请求允许部分结果且截止时间临近时，停止发送并以 truncated 标记结束，而不是超时报错
+func (s *BookServer) StreamBooks(req *pb.StreamBooksRequest, stream grpc.ServerStreamingServer[pb.StreamBooksResponse]) error {
+	ctx := stream.Context()
+
+	// 记录请求日志
+	s.logger.Info("收到流式获取图书请求", "allow_partial", req.GetAllowPartial(), "filter", req.GetFilter())
+
+	// 验证过滤条件
+	if err := validateFilter(req.GetFilter()); err != nil {
+		return err
+	}
+
+	all, err := s.booksForRead(ctx, "")
+	if err != nil {
+		return err
+	}
+	books := all
+	if req.GetFilter() != nil {
+		books = make([]*pb.Book, 0, len(all))
+		for _, book := range all {
+			if matchFilter(book, req.GetFilter()) {
+				books = append(books, book)
+			}
+		}
+	}
+
+	deadline, hasDeadline := ctx.Deadline()
+	for i, book := range books {
+		if req.GetAllowPartial() && hasDeadline && time.Until(deadline) < s.streamGrace {
+			s.logger.Warn("临近截止时间，提前结束", "sent", i, "total", len(books))
+			return stream.Send(&pb.StreamBooksResponse{Truncated: true})
+		}
+		if err := ctx.Err(); err != nil {
+			return status.FromContextError(err).Err()
+		}
+		if err := stream.Send(&pb.StreamBooksResponse{Book: book}); err != nil {
+			return err
+		}
+	}
+
+	s.logger.Info("流式返回图书", "count", len(books))
+
+	return nil
+}
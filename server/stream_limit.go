@@ -0,0 +1,42 @@
+package main
+
+import (
+	// 导入gRPC相关包
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// defaultMaxStreamMessages 客户端流式调用（如 ReplaceCatalog、ValidateBooks、GetBooksBatchStream）默认允许接收的最大消息数
+const defaultMaxStreamMessages = 10000
+
+// newStreamMessageLimitInterceptor 创建限制客户端流式调用接收消息总数的拦截器，0 表示不限制。
+// 超过上限后 RecvMsg 返回 ResourceExhausted，处理器返回该错误后流被关闭，
+// 避免客户端持续发送消息长时间占用连接；与按条目数计算的批量上限互为补充
+func newStreamMessageLimitInterceptor(limit int) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if limit <= 0 || !info.IsClientStream {
+			return handler(srv, ss)
+		}
+		return handler(srv, &limitedServerStream{ServerStream: ss, limit: limit})
+	}
+}
+
+// limitedServerStream 统计已接收消息数的服务端流
+type limitedServerStream struct {
+	grpc.ServerStream
+	limit    int
+	received int
+}
+
+// RecvMsg 接收消息，已接收的消息数超过上限时丢弃消息并返回 ResourceExhausted
+func (s *limitedServerStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	s.received++
+	if s.received > s.limit {
+		return status.Errorf(codes.ResourceExhausted, "流式请求的消息数超过上限%d", s.limit)
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	// 导入生成的protobuf代码
+	pb "grpc-basic-server/pb"
+
+	// 导入gRPC相关包
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// TestStreamMessageLimit 测试客户端流式调用的消息数超过上限时流以 ResourceExhausted 结束
+func TestStreamMessageLimit(t *testing.T) {
+	client, server := startTestServer(t, mustParseConfig(t, "-max-stream-messages", "3"))
+
+	books := func(n int) []*pb.Book {
+		var result []*pb.Book
+		for i := 0; i < n; i++ {
+			result = append(result, &pb.Book{Title: fmt.Sprintf("图书%d", i), Author: "作者", Price: proto.Float32(10)})
+		}
+		return result
+	}
+
+	// 未超过上限时正常处理
+	if _, err := replaceCatalog(t, client, books(3)); err != nil {
+		t.Fatalf("替换目录失败: %v", err)
+	}
+
+	// 超过上限时流被终止，目录不做任何修改。服务端可能在客户端发送完之前关闭流，
+	// 此时 Send 返回 io.EOF，错误码由 CloseAndRecv 得到
+	stream, err := client.ReplaceCatalog(context.Background())
+	if err != nil {
+		t.Fatalf("打开替换目录流失败: %v", err)
+	}
+	for _, book := range books(5) {
+		if err := stream.Send(book); err != nil {
+			break
+		}
+	}
+	_, err = stream.CloseAndRecv()
+	if status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("期望错误码为ResourceExhausted，实际为: %v", err)
+	}
+	server.mu.RLock()
+	count := len(server.books)
+	server.mu.RUnlock()
+	if count != 3 {
+		t.Errorf("期望目录保持3本图书，实际为: %d", count)
+	}
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"testing"
+	"time"
+
+	// 导入生成的protobuf代码
+	pb "grpc-basic-server/pb"
+
+	// 导入gRPC相关包
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// slowStream 测试用的服务端流，每次发送都会等待一段时间以模拟慢速网络
+type slowStream struct {
+	grpc.ServerStream
+	ctx   context.Context
+	delay time.Duration
+	sent  []*pb.StreamBooksResponse
+}
+
+// Context 返回流的 context
+func (s *slowStream) Context() context.Context {
+	return s.ctx
+}
+
+// Send 记录发送的消息
+func (s *slowStream) Send(resp *pb.StreamBooksResponse) error {
+	time.Sleep(s.delay)
+	s.sent = append(s.sent, resp)
+	return nil
+}
+
+// TestStreamBooksTruncated 测试截止时间临近时返回部分结果和截断标记
+func TestStreamBooksTruncated(t *testing.T) {
+	// 创建包含大量图书的服务器实例
+	server := NewBookServer(WithStreamGrace(50 * time.Millisecond))
+	books := make([]*pb.Book, 1000)
+	for i := range books {
+		books[i] = &pb.Book{Title: fmt.Sprintf("图书%d", i), Author: "作者", Price: proto.Float32(10)}
+	}
+	server.loadBooks(books)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	stream := &slowStream{ctx: ctx, delay: time.Millisecond}
+
+	if err := server.StreamBooks(&pb.StreamBooksRequest{AllowPartial: true}, stream); err != nil {
+		t.Fatalf("流式获取图书失败: %v", err)
+	}
+
+	// 最后一条消息是截断标记，之前是部分图书
+	if len(stream.sent) < 2 || len(stream.sent) > len(books) {
+		t.Fatalf("期望返回部分结果，实际发送 %d 条消息", len(stream.sent))
+	}
+	last := stream.sent[len(stream.sent)-1]
+	if !last.GetTruncated() || last.GetBook() != nil {
+		t.Errorf("期望最后一条消息为截断标记，实际为: %v", last)
+	}
+	for _, resp := range stream.sent[:len(stream.sent)-1] {
+		if resp.GetBook() == nil || resp.GetTruncated() {
+			t.Fatalf("截断标记之前的消息应只包含图书，实际为: %v", resp)
+		}
+	}
+}
+
+// TestStreamBooksComplete 测试没有截止时间压力时返回全部图书且不带截断标记
+func TestStreamBooksComplete(t *testing.T) {
+	client, server := startTestServer(t, mustParseConfig(t))
+	server.loadBooks([]*pb.Book{
+		{Title: "图书1", Author: "作者", Price: proto.Float32(10)},
+		{Title: "图书2", Author: "作者", Price: proto.Float32(20)},
+	})
+
+	stream, err := client.StreamBooks(context.Background(), &pb.StreamBooksRequest{AllowPartial: true})
+	if err != nil {
+		t.Fatalf("流式获取图书失败: %v", err)
+	}
+
+	var count int
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("接收图书失败: %v", err)
+		}
+		if resp.GetTruncated() {
+			t.Errorf("不应返回截断标记")
+		}
+		count++
+	}
+	if count != 2 {
+		t.Errorf("期望返回2本图书，实际为: %d", count)
+	}
+}
+
+// TestStreamBooksRequiredMetadata 测试流式方法同样检查必需元数据
+func TestStreamBooksRequiredMetadata(t *testing.T) {
+	client, _ := startTestServer(t, mustParseConfig(t, "-required-metadata", "x-tenant-id"))
+
+	stream, err := client.StreamBooks(context.Background(), &pb.StreamBooksRequest{})
+	if err == nil {
+		_, err = stream.Recv()
+	}
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("期望错误码为InvalidArgument，实际为: %v", status.Code(err))
+	}
+}
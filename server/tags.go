@@ -0,0 +1,94 @@
+package main
+
+import (
+	"context"
+	"slices"
+
+	// 导入生成的protobuf代码
+	pbv2 "grpc-basic-server/pb/v2"
+
+	// 导入gRPC相关包
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// AddTags 为多本图书添加标签，已有的标签不会重复添加
+func (v *bookServiceV2) AddTags(ctx context.Context, req *pbv2.AddTagsRequest) (*pbv2.TagsResponse, error) {
+	v.s.logger.Info("收到批量添加标签请求", "ids", len(req.GetIds()), "tags", req.GetTags())
+
+	return v.editTags(ctx, req.GetIds(), req.GetTags(), func(current, tags []string) []string {
+		added := append([]string(nil), current...)
+		for _, tag := range tags {
+			if !slices.Contains(added, tag) {
+				added = append(added, tag)
+			}
+		}
+		return added
+	})
+}
+
+// RemoveTags 从多本图书删除标签，图书没有的标签会被忽略
+func (v *bookServiceV2) RemoveTags(ctx context.Context, req *pbv2.RemoveTagsRequest) (*pbv2.TagsResponse, error) {
+	v.s.logger.Info("收到批量删除标签请求", "ids", len(req.GetIds()), "tags", req.GetTags())
+
+	return v.editTags(ctx, req.GetIds(), req.GetTags(), func(current, tags []string) []string {
+		var remaining []string
+		for _, tag := range current {
+			if !slices.Contains(tags, tag) {
+				remaining = append(remaining, tag)
+			}
+		}
+		return remaining
+	})
+}
+
+// editTags 在一次写锁内对每本图书应用 edit 计算新的标签，标签有变化的图书版本号递增
+func (v *bookServiceV2) editTags(ctx context.Context, ids, tags []string, edit func(current, tags []string) []string) (*pbv2.TagsResponse, error) {
+	s := v.s
+
+	// 验证请求参数
+	if len(ids) == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "图书ID列表不能为空")
+	}
+	if len(tags) == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "标签列表不能为空")
+	}
+	if err := s.checkBatchSize(len(ids)); err != nil {
+		return nil, err
+	}
+	tags, err := normalizeTags(tags)
+	if err != nil {
+		return nil, err
+	}
+
+	// 加写锁，保证所有图书在一次操作中完成修改
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// 先计算所有图书的新标签，全部通过检查后再保存，避免只修改了部分图书
+	catalog := s.catalogFor(ctx, false)
+	resp := &pbv2.TagsResponse{}
+	for _, id := range ids {
+		if _, exists := catalog.books[id]; !exists {
+			resp.Results = append(resp.Results, &pbv2.TagsResult{Id: id})
+			continue
+		}
+		updated := edit(catalog.metaFor(id).tags, tags)
+		if len(updated) > maxTags {
+			return nil, status.Errorf(codes.InvalidArgument, "图书 %s 的标签数量不能超过%d个", id, maxTags)
+		}
+		resp.Results = append(resp.Results, &pbv2.TagsResult{Id: id, Found: true, Tags: updated})
+	}
+
+	// 元信息不原地修改，标签有变化的图书通过 put 保存新的元信息
+	now := s.clock.Now()
+	for _, result := range resp.GetResults() {
+		if result.GetFound() && !slices.Equal(catalog.metaFor(result.GetId()).tags, result.GetTags()) {
+			catalog.put(catalog.books[result.GetId()], now).tags = append([]string(nil), result.GetTags()...)
+		}
+	}
+
+	s.logger.Info("批量修改标签完成", "ids", len(ids))
+
+	return resp, nil
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	// 导入生成的protobuf代码
+	pbv2 "grpc-basic-server/pb/v2"
+
+	// 导入gRPC相关包
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// TestBulkTags 测试为三本图书添加标签、再从其中一本删除，并报告不存在的图书
+func TestBulkTags(t *testing.T) {
+	conn, _ := startTestConn(t, mustParseConfig(t))
+	v2 := pbv2.NewBookServiceV2Client(conn)
+	ctx := context.Background()
+
+	var ids []string
+	for _, tags := range [][]string{nil, {"go"}, {"grpc"}} {
+		created, err := v2.CreateBook(ctx, &pbv2.CreateBookRequest{Book: &pbv2.Book{
+			Title: "图书", Author: "作者", Price: 10, Tags: tags,
+		}})
+		if err != nil {
+			t.Fatalf("创建图书失败: %v", err)
+		}
+		ids = append(ids, created.GetId())
+	}
+
+	added, err := v2.AddTags(ctx, &pbv2.AddTagsRequest{Ids: append(ids, "不存在"), Tags: []string{"go", " go "}})
+	if err != nil {
+		t.Fatalf("批量添加标签失败: %v", err)
+	}
+	if len(added.GetResults()) != 4 || added.GetResults()[3].GetFound() {
+		t.Errorf("期望报告不存在的图书，实际为: %v", added.GetResults())
+	}
+
+	if _, err := v2.RemoveTags(ctx, &pbv2.RemoveTagsRequest{Ids: ids[2:], Tags: []string{"go"}}); err != nil {
+		t.Fatalf("批量删除标签失败: %v", err)
+	}
+
+	want := [][]string{{"go"}, {"go"}, {"grpc"}}
+	for i, id := range ids {
+		book, err := v2.GetBook(ctx, &pbv2.GetBookRequest{Id: id})
+		if err != nil {
+			t.Fatalf("获取图书失败: %v", err)
+		}
+		if !equalIDs(book.GetTags(), want[i]) {
+			t.Errorf("图书 %s 期望标签为 %v，实际为: %v", id, want[i], book.GetTags())
+		}
+	}
+
+	// 第二本图书已有该标签，版本号不应变化
+	book, err := v2.GetBook(ctx, &pbv2.GetBookRequest{Id: ids[1]})
+	if err != nil {
+		t.Fatalf("获取图书失败: %v", err)
+	}
+	if book.GetVersion() != 1 {
+		t.Errorf("标签未变化时版本号不应递增，实际为: %d", book.GetVersion())
+	}
+
+	_, err = v2.AddTags(ctx, &pbv2.AddTagsRequest{Ids: ids, Tags: []string{""}})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("期望空标签返回 InvalidArgument，实际为: %v", err)
+	}
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	// 导入生成的protobuf代码
+	pb "grpc-basic-server/pb"
+
+	// 导入gRPC相关包
+	"google.golang.org/grpc/metadata"
+)
+
+// bookCatalog 单个租户的图书存储，ID 在租户内唯一。
+// 已存储的图书不会被原地修改，更新时总是替换为新的对象，
+// 因此释放锁后继续读取（如序列化响应）是安全的
+type bookCatalog struct {
+	// 内存中的图书存储（实际项目中应该使用数据库）
+	books map[string]*pb.Book
+
+	// 用于生成唯一ID的计数器
+	idCounter int64
+
+	// 未确认的库存预留，按预留ID索引
+	reservations map[string]*reservation
+
+	// 图书的元信息（标签、时间戳、版本号），由 put/remove 维护
+	meta map[string]*bookMeta
+
+	// 作者和出版年份的二级索引，由 put/remove 维护
+	index bookIndex
+
+	// 图书被修改时通知等待方，由 put/remove 触发
+	changes *changeNotifier
+
+	// 变更序号和删除记录，由 put/remove 维护，供 ListChangedSince 增量同步
+	changeLog
+}
+
+// generateID 生成租户内唯一的图书ID，跳过已被客户端指定的ID占用的编号
+func (c *bookCatalog) generateID() string {
+	for {
+		c.idCounter++
+		id := fmt.Sprintf("book-%d", c.idCounter)
+		if _, exists := c.books[id]; !exists {
+			return id
+		}
+	}
+}
+
+// tenantID 返回请求所属的租户，未开启多租户或未携带租户信息时返回空字符串（默认租户）
+func (s *BookServer) tenantID(ctx context.Context) string {
+	if s.tenantKey == "" {
+		return ""
+	}
+	if tenant := metadataValue(ctx, s.tenantKey); tenant != "" {
+		return tenant
+	}
+	md, _ := metadata.FromIncomingContext(ctx)
+	if vals := md.Get(s.tenantKey); len(vals) > 0 {
+		return vals[0]
+	}
+	return ""
+}
+
+// catalogFor 返回调用方租户的图书存储，调用方需持有 s.mu。
+// create 为 true 时（需持有写锁）租户不存在则创建；否则返回一个只读的空存储
+func (s *BookServer) catalogFor(ctx context.Context, create bool) *bookCatalog {
+	tenant := s.tenantID(ctx)
+	if tenant == "" {
+		return &s.bookCatalog
+	}
+
+	catalog, exists := s.tenants[tenant]
+	if !exists {
+		catalog = &bookCatalog{books: make(map[string]*pb.Book), changes: s.changes, changeLog: changeLog{policy: &s.tombstonePolicy}}
+		if create {
+			s.tenants[tenant] = catalog
+		}
+	}
+	return catalog
+}
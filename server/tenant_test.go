@@ -0,0 +1,76 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	// 导入生成的protobuf代码
+	pb "grpc-basic-server/pb"
+
+	// 导入gRPC相关包
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// tenantContext 返回携带租户元数据的上下文
+func tenantContext(tenant string) context.Context {
+	return metadata.AppendToOutgoingContext(context.Background(), "x-tenant-id", tenant)
+}
+
+// TestTenantIsolation 测试不同租户的图书相互隔离
+func TestTenantIsolation(t *testing.T) {
+	client, _ := startTestServer(t, mustParseConfig(t, "-tenant-metadata", "x-tenant-id"))
+
+	// 租户A创建两本图书，租户B创建一本
+	created := map[string][]string{}
+	for tenant, titles := range map[string][]string{
+		"tenant-a": {"A的图书1", "A的图书2"},
+		"tenant-b": {"B的图书1"},
+	} {
+		for _, title := range titles {
+			resp, err := client.CreateBook(tenantContext(tenant), &pb.CreateBookRequest{
+				Book: &pb.Book{Title: title, Author: "作者", Price: proto.Float32(10)},
+			})
+			if err != nil {
+				t.Fatalf("创建图书失败: %v", err)
+			}
+			created[tenant] = append(created[tenant], resp.Id)
+		}
+	}
+
+	// ID 在租户内唯一，不同租户可以重复
+	if created["tenant-a"][0] != "book-1" || created["tenant-b"][0] != "book-1" {
+		t.Errorf("每个租户的ID应独立生成，实际为: %v", created)
+	}
+
+	// 每个租户只能列出自己的图书
+	for tenant, prefix := range map[string]string{"tenant-a": "A的", "tenant-b": "B的"} {
+		resp, err := client.ListBooks(tenantContext(tenant), &pb.ListBooksRequest{})
+		if err != nil {
+			t.Fatalf("列出图书失败: %v", err)
+		}
+		if resp.Total != int32(len(created[tenant])) {
+			t.Errorf("租户 %s 期望总数为%d，实际为: %d", tenant, len(created[tenant]), resp.Total)
+		}
+		for _, book := range resp.Books {
+			if !strings.HasPrefix(book.Title, prefix) {
+				t.Errorf("租户 %s 看到了其他租户的图书: %s", tenant, book.Title)
+			}
+		}
+	}
+
+	// 租户B无法获取只存在于租户A的图书
+	_, err := client.GetBook(tenantContext("tenant-b"), &pb.GetBookRequest{Id: created["tenant-a"][1]})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("期望错误码为NotFound，实际为: %v", status.Code(err))
+	}
+
+	// 未携带租户信息的请求被拒绝
+	_, err = client.ListBooks(context.Background(), &pb.ListBooksRequest{})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("期望错误码为InvalidArgument，实际为: %v", status.Code(err))
+	}
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"context"
+	"strings"
+
+	// 导入生成的protobuf代码
+	pb "grpc-basic-server/pb"
+
+	// 导入gRPC相关包
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// maxTitles 单次请求允许的最大标题数量
+const maxTitles = 100
+
+// GetBooksByTitles 一次遍历图书存储，按多个标题（不区分大小写完全匹配）分别返回匹配的图书
+func (s *BookServer) GetBooksByTitles(ctx context.Context, req *pb.GetBooksByTitlesRequest) (*pb.GetBooksByTitlesResponse, error) {
+	// 记录请求日志
+	s.logger.Info("收到按多个标题查询图书请求", "titles", len(req.GetTitles()))
+
+	// 验证请求参数
+	titles := req.GetTitles()
+	if len(titles) == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "标题列表不能为空")
+	}
+	if len(titles) > maxTitles {
+		return nil, status.Errorf(codes.InvalidArgument, "标题数量不能超过%d", maxTitles)
+	}
+
+	// 获取按ID排序的图书，结果中的图书因此保持ID顺序
+	books, err := s.booksForRead(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+
+	// 同一个标题可能在请求中出现多次，每次都对应一个结果
+	results := make([]*pb.TitleResult, len(titles))
+	byTitle := make(map[string][]int, len(titles))
+	for i, title := range titles {
+		results[i] = &pb.TitleResult{Title: title}
+		key := strings.ToLower(title)
+		byTitle[key] = append(byTitle[key], i)
+	}
+
+	// 只遍历一次图书，将每本图书放入所有标题匹配的结果
+	matched := 0
+	for _, book := range books {
+		for _, i := range byTitle[strings.ToLower(book.GetTitle())] {
+			results[i].Books = append(results[i].Books, book)
+			matched++
+		}
+	}
+	recordScan(ctx, len(books), matched)
+
+	s.logger.Info("按多个标题查询完成", "titles", len(results))
+
+	return &pb.GetBooksByTitlesResponse{Results: results}, nil
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	// 导入生成的protobuf代码
+	pb "grpc-basic-server/pb"
+
+	// 导入gRPC相关包
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// TestGetBooksByTitles 测试按多个标题分组返回图书，没有匹配的标题返回空结果
+func TestGetBooksByTitles(t *testing.T) {
+	// 创建服务器实例
+	server := NewBookServer()
+	ids := server.loadBooks([]*pb.Book{
+		{Title: "Go语言编程", Author: "作者1", Price: proto.Float32(10)},
+		{Title: "go语言编程", Author: "作者2", Price: proto.Float32(20)},
+		{Title: "gRPC实战", Author: "作者3", Price: proto.Float32(30)},
+		{Title: "Go语言编程进阶", Author: "作者4", Price: proto.Float32(40)},
+	})
+
+	resp, err := server.GetBooksByTitles(context.Background(), &pb.GetBooksByTitlesRequest{
+		Titles: []string{"GO语言编程", "不存在的书", "grpc实战"},
+	})
+	if err != nil {
+		t.Fatalf("按多个标题查询图书失败: %v", err)
+	}
+
+	expected := [][]string{ids[:2], {}, ids[2:3]}
+	results := resp.GetResults()
+	if len(results) != len(expected) {
+		t.Fatalf("期望%d个结果，实际为: %d", len(expected), len(results))
+	}
+	for i, result := range results {
+		if got := bookIDs(result.GetBooks()); !equalIDs(got, expected[i]) {
+			t.Errorf("标题 %s 期望匹配 %v，实际为: %v", result.GetTitle(), expected[i], got)
+		}
+	}
+	if results[1].GetTitle() != "不存在的书" {
+		t.Errorf("没有匹配的标题也应返回结果，实际为: %v", results[1])
+	}
+
+	_, err = server.GetBooksByTitles(context.Background(), &pb.GetBooksByTitlesRequest{})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("期望标题列表为空时返回 InvalidArgument，实际为: %v", err)
+	}
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	// 导入gRPC相关包
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// serverVersion 服务端版本号，通过响应尾部元数据返回给客户端
+const serverVersion = "1.0.0"
+
+// 响应尾部元数据的键
+const (
+	trailerServerVersion   = "x-server-version"
+	trailerRequestID       = "x-request-id"
+	trailerHandlerDuration = "x-handler-duration"
+)
+
+// requestID 返回客户端通过 x-request-id 传入的请求ID，未传入时生成一个新的ID
+func requestID(ctx context.Context) string {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if vals := md.Get(trailerRequestID); len(vals) > 0 && vals[0] != "" {
+		return vals[0]
+	}
+	id, err := newRandomToken()
+	if err != nil {
+		return ""
+	}
+	return id
+}
+
+// setDebugTrailer 在响应尾部附加服务端版本、请求ID和处理耗时，便于排查问题
+func setDebugTrailer(ctx context.Context, logger Logger, requestID string, duration time.Duration) {
+	trailer := metadata.Pairs(
+		trailerServerVersion, serverVersion,
+		trailerRequestID, requestID,
+		trailerHandlerDuration, duration.String(),
+	)
+	if err := grpc.SetTrailer(ctx, trailer); err != nil {
+		logger.Warn("设置响应尾部元数据失败", "error", err)
+	}
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	// 导入生成的protobuf代码
+	pb "grpc-basic-server/pb"
+
+	// 导入gRPC相关包
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// TestDebugTrailer 测试一元调用的响应尾部包含服务端版本、请求ID和处理耗时
+func TestDebugTrailer(t *testing.T) {
+	client, _ := startTestServer(t, mustParseConfig(t))
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), trailerRequestID, "req-123")
+	var trailer metadata.MD
+	if _, err := client.GetStats(ctx, &emptypb.Empty{}, grpc.Trailer(&trailer)); err != nil {
+		t.Fatalf("调用失败: %v", err)
+	}
+
+	if got := trailer.Get(trailerServerVersion); len(got) != 1 || got[0] != serverVersion {
+		t.Errorf("期望服务端版本为%s，实际为: %v", serverVersion, got)
+	}
+	if got := trailer.Get(trailerRequestID); len(got) != 1 || got[0] != "req-123" {
+		t.Errorf("期望请求ID为req-123，实际为: %v", got)
+	}
+	got := trailer.Get(trailerHandlerDuration)
+	if len(got) != 1 {
+		t.Fatalf("缺少处理耗时，实际为: %v", trailer)
+	}
+	if _, err := time.ParseDuration(got[0]); err != nil {
+		t.Errorf("处理耗时格式错误: %v", got[0])
+	}
+}
+
+// TestDebugTrailerOnError 测试调用失败时同样返回响应尾部，未传入请求ID时自动生成
+func TestDebugTrailerOnError(t *testing.T) {
+	client, _ := startTestServer(t, mustParseConfig(t))
+
+	var trailer metadata.MD
+	_, err := client.GetBook(context.Background(), &pb.GetBookRequest{Id: "book-999"}, grpc.Trailer(&trailer))
+	if err == nil {
+		t.Fatalf("期望返回错误")
+	}
+	if got := trailer.Get(trailerRequestID); len(got) != 1 || got[0] == "" {
+		t.Errorf("期望自动生成请求ID，实际为: %v", got)
+	}
+}
+
+// TestDebugTrailerDisabled 测试关闭后不附加响应尾部
+func TestDebugTrailerDisabled(t *testing.T) {
+	client, _ := startTestServer(t, mustParseConfig(t, "-debug-trailers=false"))
+
+	var trailer metadata.MD
+	if _, err := client.GetStats(context.Background(), &emptypb.Empty{}, grpc.Trailer(&trailer)); err != nil {
+		t.Fatalf("调用失败: %v", err)
+	}
+	if got := trailer.Get(trailerServerVersion); len(got) != 0 {
+		t.Errorf("关闭后不应返回服务端版本，实际为: %v", got)
+	}
+}
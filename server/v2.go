@@ -0,0 +1,276 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	// 导入生成的protobuf代码
+	pb "grpc-basic-server/pb"
+	pbv2 "grpc-basic-server/pb/v2"
+
+	// 导入gRPC相关包
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// maxTags 每本图书最多的标签数量
+	maxTags = 20
+
+	// maxTagLength 单个标签的最大长度（字符数）
+	maxTagLength = 32
+)
+
+// bookServiceV2 图书服务 v2 的实现，与 v1 共享同一个 BookServer 的图书存储。
+// v1 的 Book 消息不包含标签、时间戳和版本号，这些字段保存在 bookMeta 中，
+// 因此 v1 客户端读取 v2 创建的图书时只会看到原有字段
+type bookServiceV2 struct {
+	// 嵌入未实现的服务接口，确保向后兼容
+	pbv2.UnimplementedBookServiceV2Server
+
+	// 共享的 v1 服务器
+	s *BookServer
+}
+
+// CreateBook 创建图书，返回创建后的完整图书
+func (v *bookServiceV2) CreateBook(ctx context.Context, req *pbv2.CreateBookRequest) (*pbv2.Book, error) {
+	s := v.s
+	s.logger.Info("收到v2创建图书请求", "title", req.GetBook().GetTitle())
+
+	// 验证图书信息
+	if req.GetBook() == nil {
+		return nil, errBookRequired
+	}
+	book := toV1Book(req.GetBook())
+	if err := validateBook(book); err != nil {
+		return nil, err
+	}
+	s.sanitizeBook(book)
+	tags, err := normalizeTags(req.GetBook().GetTags())
+	if err != nil {
+		return nil, err
+	}
+
+	// 默认由服务端设置时间戳，信任客户端时间戳时使用请求中的时间，修改时间默认与创建时间相同
+	now := s.clock.Now()
+	createdAt, err := s.clientTimestamp("created_at", req.GetBook().GetCreatedAt(), now, now)
+	if err != nil {
+		return nil, err
+	}
+	updatedAt, err := s.clientTimestamp("updated_at", req.GetBook().GetUpdatedAt(), now, createdAt)
+	if err != nil {
+		return nil, err
+	}
+	if updatedAt.Before(createdAt) {
+		return nil, status.Errorf(codes.InvalidArgument, "updated_at 不能早于 created_at")
+	}
+
+	// 加写锁保护并发访问
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// 在调用方租户内生成唯一ID，与 v1 的 CreateBook 规则相同
+	catalog := s.catalogFor(ctx, true)
+	if book.Id, err = s.assignID(catalog, book.GetId()); err != nil {
+		s.logger.Warn("图书ID不可用", "id", req.GetBook().GetId(), "error", err)
+		return nil, err
+	}
+	s.defaults.apply(book, now)
+
+	// 新的元信息尚未被其他请求读取，可以直接设置标签和时间戳
+	meta := catalog.put(book, now)
+	meta.tags = tags
+	meta.createdAt, meta.updatedAt = createdAt, updatedAt
+
+	s.logger.Info("成功创建图书", "id", book.GetId())
+
+	return toV2Book(book, meta), nil
+}
+
+// GetBook 获取图书信息
+func (v *bookServiceV2) GetBook(ctx context.Context, req *pbv2.GetBookRequest) (*pbv2.Book, error) {
+	s := v.s
+	s.logger.Info("收到v2获取图书请求", "id", req.GetId())
+
+	// 验证请求参数
+	if req.GetId() == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "图书ID不能为空")
+	}
+
+	// 加读锁保护并发访问
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	catalog := s.catalogFor(ctx, false)
+	book, err := catalog.get(req.GetId())
+	if err != nil {
+		s.logger.Warn("图书未找到", "id", req.GetId())
+		return nil, s.storeErrToStatus(err)
+	}
+	if !s.validForRead(book) {
+		return nil, status.Errorf(codes.DataLoss, "图书数据无效，ID: %s", req.GetId())
+	}
+
+	return toV2Book(book, catalog.metaFor(book.GetId())), nil
+}
+
+// UpdateBook 更新图书信息和标签，请求中的版本号非0时检查是否与当前版本一致
+func (v *bookServiceV2) UpdateBook(ctx context.Context, req *pbv2.UpdateBookRequest) (*pbv2.Book, error) {
+	s := v.s
+	s.logger.Info("收到v2更新图书请求", "id", req.GetBook().GetId(), "version", req.GetBook().GetVersion())
+
+	// 验证请求参数
+	if req.GetBook() == nil {
+		return nil, errBookRequired
+	}
+	book := toV1Book(req.GetBook())
+	if book.GetId() == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "图书ID不能为空")
+	}
+	if err := validateBook(book); err != nil {
+		return nil, err
+	}
+	s.sanitizeBook(book)
+	tags, err := normalizeTags(req.GetBook().GetTags())
+	if err != nil {
+		return nil, err
+	}
+
+	// 创建时间不能修改；信任客户端时间戳时可以指定修改时间
+	now := s.clock.Now()
+	updatedAt, err := s.clientTimestamp("updated_at", req.GetBook().GetUpdatedAt(), now, now)
+	if err != nil {
+		return nil, err
+	}
+
+	// 加写锁保护并发访问
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	catalog := s.catalogFor(ctx, false)
+	existing, err := catalog.get(book.GetId())
+	if err != nil {
+		s.logger.Warn("图书不存在，无法更新", "id", book.GetId())
+		return nil, s.storeErrToStatus(err)
+	}
+	if err := s.checkImmutableFields(existing, book); err != nil {
+		s.logger.Warn("试图修改不可修改的字段", "id", book.GetId(), "error", err)
+		return nil, err
+	}
+	if version := req.GetBook().GetVersion(); version != 0 && version != catalog.metaFor(book.GetId()).version {
+		s.logger.Warn("图书版本不一致，拒绝更新", "id", book.GetId(), "version", version)
+		return nil, s.storeErrToStatus(fmt.Errorf("%w，ID: %s", ErrConflict, book.GetId()))
+	}
+	if updatedAt.Before(catalog.metaFor(book.GetId()).createdAt) {
+		return nil, status.Errorf(codes.InvalidArgument, "updated_at 不能早于 created_at")
+	}
+
+	// 保留原有的推荐状态和库存，它们只能通过专门的RPC修改；未指定币种时保留原有币种
+	book.Featured = existing.GetFeatured()
+	book.FeaturedRank = existing.GetFeaturedRank()
+	book.Stock = existing.GetStock()
+	book.Currency = resolveCurrency(book.GetCurrency(), existing.GetCurrency())
+
+	// 新的元信息尚未被其他请求读取，可以直接设置标签和修改时间
+	meta := catalog.put(book, now)
+	meta.tags = tags
+	meta.updatedAt = updatedAt
+
+	s.logger.Info("成功更新图书", "id", book.GetId(), "version", meta.version)
+
+	return toV2Book(book, meta), nil
+}
+
+// ListBooks 列出图书（支持分页），分页和截断规则与 v1 相同
+func (v *bookServiceV2) ListBooks(ctx context.Context, req *pbv2.ListBooksRequest) (*pbv2.ListBooksResponse, error) {
+	s := v.s
+
+	page, err := s.ListBooks(ctx, &pb.ListBooksRequest{Page: req.GetPage(), PageSize: req.GetPageSize()})
+	if err != nil {
+		return nil, err
+	}
+
+	// 在读锁内读取图书及其元信息，保证两者一致；分页后被删除的图书不再返回
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	catalog := s.catalogFor(ctx, false)
+	resp := &pbv2.ListBooksResponse{Total: page.GetTotal()}
+	for _, listed := range page.GetBooks() {
+		if book, exists := catalog.books[listed.GetId()]; exists {
+			resp.Books = append(resp.Books, toV2Book(book, catalog.metaFor(book.GetId())))
+		}
+	}
+	return resp, nil
+}
+
+// toV1Book 将 v2 图书投影为 v1 图书，丢弃 v1 中不存在的字段。
+// v2 图书没有字段存在性，描述为空、出版年份为0视为未设置
+func toV1Book(book *pbv2.Book) *pb.Book {
+	v1 := &pb.Book{
+		Id:       book.GetId(),
+		Title:    book.GetTitle(),
+		Author:   book.GetAuthor(),
+		Price:    proto.Float32(book.GetPrice()),
+		Isbn:     book.GetIsbn(),
+		Currency: book.GetCurrency(),
+	}
+	if description := book.GetDescription(); description != "" {
+		v1.Description = proto.String(description)
+	}
+	if year := book.GetPublishYear(); year != 0 {
+		v1.PublishYear = proto.Int32(year)
+	}
+	return v1
+}
+
+// toV2Book 将已存储的 v1 图书和元信息组合为 v2 图书
+func toV2Book(book *pb.Book, meta *bookMeta) *pbv2.Book {
+	v2 := &pbv2.Book{
+		Id:           book.GetId(),
+		Title:        book.GetTitle(),
+		Author:       book.GetAuthor(),
+		Price:        book.GetPrice(),
+		Description:  book.GetDescription(),
+		PublishYear:  book.GetPublishYear(),
+		Featured:     book.GetFeatured(),
+		FeaturedRank: book.GetFeaturedRank(),
+		Stock:        book.GetStock(),
+		Isbn:         book.GetIsbn(),
+		Currency:     book.GetCurrency(),
+		Tags:         append([]string(nil), meta.tags...),
+		Version:      meta.version,
+	}
+	if !meta.createdAt.IsZero() {
+		v2.CreatedAt = timestamppb.New(meta.createdAt)
+		v2.UpdatedAt = timestamppb.New(meta.updatedAt)
+	}
+	return v2
+}
+
+// normalizeTags 去除标签首尾空白并去重（保持原有顺序），标签为空、过长或数量过多时返回 InvalidArgument
+func normalizeTags(tags []string) ([]string, error) {
+	if len(tags) > maxTags {
+		return nil, status.Errorf(codes.InvalidArgument, "标签数量不能超过%d个", maxTags)
+	}
+
+	seen := make(map[string]bool, len(tags))
+	var normalized []string
+	for _, tag := range tags {
+		tag = strings.TrimSpace(tag)
+		if tag == "" {
+			return nil, status.Errorf(codes.InvalidArgument, "标签不能为空")
+		}
+		if len([]rune(tag)) > maxTagLength {
+			return nil, status.Errorf(codes.InvalidArgument, "标签长度不能超过%d个字符: %s", maxTagLength, tag)
+		}
+		if !seen[tag] {
+			seen[tag] = true
+			normalized = append(normalized, tag)
+		}
+	}
+	return normalized, nil
+}
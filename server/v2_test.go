@@ -0,0 +1,110 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	// 导入生成的protobuf代码
+	pb "grpc-basic-server/pb"
+	pbv2 "grpc-basic-server/pb/v2"
+
+	// 导入gRPC相关包
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// TestV1GetBookCreatedByV2 测试 v1 客户端可以读取 v2 创建的图书，新字段被忽略
+func TestV1GetBookCreatedByV2(t *testing.T) {
+	conn, _ := startTestConn(t, mustParseConfig(t))
+	v1 := pb.NewBookServiceClient(conn)
+	v2 := pbv2.NewBookServiceV2Client(conn)
+	ctx := context.Background()
+
+	created, err := v2.CreateBook(ctx, &pbv2.CreateBookRequest{Book: &pbv2.Book{
+		Title: "图书", Author: "作者", Price: 10, Tags: []string{"go", " go ", "grpc"},
+	}})
+	if err != nil {
+		t.Fatalf("v2创建图书失败: %v", err)
+	}
+	if created.GetVersion() != 1 || created.GetCreatedAt() == nil {
+		t.Errorf("期望版本号为1且设置创建时间，实际为: %v", created)
+	}
+	if !equalIDs(created.GetTags(), []string{"go", "grpc"}) {
+		t.Errorf("期望标签去重为[go grpc]，实际为: %v", created.GetTags())
+	}
+
+	resp, err := v1.GetBook(ctx, &pb.GetBookRequest{Id: created.GetId()})
+	if err != nil {
+		t.Fatalf("v1获取图书失败: %v", err)
+	}
+	if book := resp.GetBook(); book.GetTitle() != "图书" || book.GetAuthor() != "作者" || book.GetPrice() != 10 {
+		t.Errorf("期望v1返回原有字段，实际为: %v", book)
+	}
+}
+
+// TestV2SeesV1Updates 测试 v1 的修改递增版本号并保留 v2 设置的标签
+func TestV2SeesV1Updates(t *testing.T) {
+	conn, _ := startTestConn(t, mustParseConfig(t))
+	v1 := pb.NewBookServiceClient(conn)
+	v2 := pbv2.NewBookServiceV2Client(conn)
+	ctx := context.Background()
+
+	created, err := v2.CreateBook(ctx, &pbv2.CreateBookRequest{Book: &pbv2.Book{
+		Title: "图书", Author: "作者", Price: 10, Tags: []string{"go"},
+	}})
+	if err != nil {
+		t.Fatalf("v2创建图书失败: %v", err)
+	}
+	if _, err := v1.UpdateBook(ctx, &pb.UpdateBookRequest{Book: &pb.Book{
+		Id: created.GetId(), Title: "新书名", Author: "作者", Price: proto.Float32(12),
+	}}); err != nil {
+		t.Fatalf("v1更新图书失败: %v", err)
+	}
+
+	got, err := v2.GetBook(ctx, &pbv2.GetBookRequest{Id: created.GetId()})
+	if err != nil {
+		t.Fatalf("v2获取图书失败: %v", err)
+	}
+	if got.GetTitle() != "新书名" || got.GetVersion() != 2 || !equalIDs(got.GetTags(), []string{"go"}) {
+		t.Errorf("期望v2看到v1的修改且保留标签，实际为: %v", got)
+	}
+}
+
+// TestV2UpdateVersionConflict 测试版本号不一致时更新返回 Aborted
+func TestV2UpdateVersionConflict(t *testing.T) {
+	conn, _ := startTestConn(t, mustParseConfig(t))
+	v2 := pbv2.NewBookServiceV2Client(conn)
+	ctx := context.Background()
+
+	created, err := v2.CreateBook(ctx, &pbv2.CreateBookRequest{Book: &pbv2.Book{Title: "图书", Author: "作者", Price: 10}})
+	if err != nil {
+		t.Fatalf("v2创建图书失败: %v", err)
+	}
+
+	created.Title = "新书名"
+	updated, err := v2.UpdateBook(ctx, &pbv2.UpdateBookRequest{Book: created})
+	if err != nil {
+		t.Fatalf("期望版本一致时更新成功，实际为: %v", err)
+	}
+	if updated.GetVersion() != 2 {
+		t.Errorf("期望版本号为2，实际为: %d", updated.GetVersion())
+	}
+
+	// 使用过期的版本号再次更新
+	_, err = v2.UpdateBook(ctx, &pbv2.UpdateBookRequest{Book: created})
+	if status.Code(err) != codes.Aborted {
+		t.Errorf("期望错误码为Aborted，实际为: %v", err)
+	}
+}
+
+// TestV2ReadOnly 测试只读模式同样拒绝 v2 的修改类方法
+func TestV2ReadOnly(t *testing.T) {
+	conn, _ := startTestConn(t, mustParseConfig(t, "-readonly"))
+	v2 := pbv2.NewBookServiceV2Client(conn)
+
+	_, err := v2.CreateBook(context.Background(), &pbv2.CreateBookRequest{Book: &pbv2.Book{Title: "图书", Author: "作者", Price: 10}})
+	if status.Code(err) != codes.PermissionDenied {
+		t.Errorf("期望错误码为PermissionDenied，实际为: %v", err)
+	}
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"unicode/utf8"
+
+	// 导入生成的protobuf代码
+	pb "grpc-basic-server/pb"
+)
+
+// maxBookPrice 图书价格上限
+const maxBookPrice = 1000000
+
+// errBookRequired 请求中缺少图书信息时返回的错误，与字段为空的错误区分开
+var errBookRequired = invalidField("book", "缺少图书信息", "book is required")
+
+// validateBook 验证创建/更新时的图书信息，不检查ID
+func validateBook(book *pb.Book) error {
+	if book == nil {
+		return errBookRequired
+	}
+	if book.GetTitle() == "" {
+		return invalidField("book.title", "图书标题不能为空", "book title must not be empty")
+	}
+	if book.GetAuthor() == "" {
+		return invalidField("book.author", "作者不能为空", "book author must not be empty")
+	}
+
+	// 字符串字段必须是合法的UTF-8，否则响应无法序列化
+	for _, field := range []struct{ name, value string }{
+		{"book.title", book.GetTitle()},
+		{"book.author", book.GetAuthor()},
+		{"book.description", book.GetDescription()},
+	} {
+		if !utf8.ValidString(field.value) {
+			return invalidField(field.name, "图书信息包含无效的UTF-8字符", "book field contains invalid UTF-8 characters")
+		}
+	}
+
+	if !isKnownCurrency(book.GetCurrency()) {
+		return invalidField("book.currency",
+			fmt.Sprintf("不支持的币种: %s，需要 ISO 4217 代码（如 CNY、USD）", book.GetCurrency()),
+			fmt.Sprintf("unsupported currency: %s, expected an ISO 4217 code (e.g. CNY, USD)", book.GetCurrency()))
+	}
+
+	// NaN 与任何数比较都为 false，需要单独检查
+	price := book.GetPrice()
+	if !isFinite(price) || price <= 0 {
+		return invalidField("book.price", "图书价格必须大于0", "book price must be greater than 0")
+	}
+	if price > maxBookPrice {
+		return invalidField("book.price",
+			fmt.Sprintf("图书价格不能超过%d", maxBookPrice),
+			fmt.Sprintf("book price must not exceed %d", maxBookPrice))
+	}
+	if book.GetStock() < 0 {
+		return invalidField("book.stock", "库存不能为负数", "book stock must not be negative")
+	}
+	return nil
+}
+
+// isFinite 判断价格是否为有限数值（非 NaN、非无穷大）
+func isFinite(price float32) bool {
+	f := float64(price)
+	return !math.IsNaN(f) && !math.IsInf(f, 0)
+}
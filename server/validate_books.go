@@ -0,0 +1,46 @@
+package main
+
+import (
+	"errors"
+	"io"
+
+	// 导入生成的protobuf代码
+	pb "grpc-basic-server/pb"
+
+	// 导入gRPC相关包
+	"google.golang.org/grpc"
+)
+
+// ValidateBooks 按创建图书的规则（validateBook）校验客户端发送的每本图书，不修改存储，
+// 用于导入或 ReplaceCatalog 之前预先检查目录。每本无效图书报告第一个无效的字段和原因
+func (s *BookServer) ValidateBooks(stream grpc.ClientStreamingServer[pb.Book, pb.ValidateBooksResponse]) error {
+	// 记录请求日志
+	s.logger.Info("收到校验图书目录请求")
+
+	resp := &pb.ValidateBooksResponse{}
+	for index := int32(0); ; index++ {
+		book, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		err = validateBook(book)
+		if err == nil {
+			resp.ValidCount++
+			continue
+		}
+		violation := &pb.BookViolation{Index: index, Description: err.Error()}
+		var fe *fieldError
+		if errors.As(err, &fe) {
+			violation.Field = fe.field
+		}
+		resp.Violations = append(resp.Violations, violation)
+	}
+
+	s.logger.Info("校验图书目录完成", "valid", resp.GetValidCount(), "invalid", len(resp.GetViolations()))
+
+	return stream.SendAndClose(resp)
+}
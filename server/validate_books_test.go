@@ -0,0 +1,61 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	// 导入生成的protobuf代码
+	pb "grpc-basic-server/pb"
+
+	// 导入gRPC相关包
+	"google.golang.org/protobuf/proto"
+)
+
+// TestValidateBooks 测试校验有效和无效图书混合的目录，报告每本无效图书的位置和字段，且不修改存储
+func TestValidateBooks(t *testing.T) {
+	client, server := startTestServer(t, mustParseConfig(t))
+
+	stream, err := client.ValidateBooks(context.Background())
+	if err != nil {
+		t.Fatalf("打开校验流失败: %v", err)
+	}
+	for _, book := range []*pb.Book{
+		{Title: "有效图书1", Author: "作者", Price: proto.Float32(10)},
+		{Title: "", Author: "作者", Price: proto.Float32(10)},
+		{Title: "有效图书2", Author: "作者", Price: proto.Float32(20), Currency: "USD"},
+		{Title: "负价格", Author: "作者", Price: proto.Float32(-1)},
+		{Title: "未知币种", Author: "作者", Price: proto.Float32(10), Currency: "XYZ"},
+	} {
+		if err := stream.Send(book); err != nil {
+			t.Fatalf("发送图书失败: %v", err)
+		}
+	}
+	resp, err := stream.CloseAndRecv()
+	if err != nil {
+		t.Fatalf("校验图书失败: %v", err)
+	}
+
+	if resp.GetValidCount() != 2 {
+		t.Errorf("期望2本有效图书，实际为: %d", resp.GetValidCount())
+	}
+	want := []struct {
+		index int32
+		field string
+	}{{1, "book.title"}, {3, "book.price"}, {4, "book.currency"}}
+	if len(resp.GetViolations()) != len(want) {
+		t.Fatalf("期望%d本无效图书，实际为: %v", len(want), resp.GetViolations())
+	}
+	for i, violation := range resp.GetViolations() {
+		if violation.GetIndex() != want[i].index || violation.GetField() != want[i].field || violation.GetDescription() == "" {
+			t.Errorf("第%d个校验结果期望位置%d、字段%s，实际为: %v", i+1, want[i].index, want[i].field, violation)
+		}
+	}
+
+	// 校验不修改存储
+	server.mu.RLock()
+	count := len(server.books)
+	server.mu.RUnlock()
+	if count != 0 {
+		t.Errorf("校验不应创建图书，实际存储中有%d本", count)
+	}
+}
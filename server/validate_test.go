@@ -0,0 +1,124 @@
+package main
+
+import (
+	"context"
+	"math"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	// 导入生成的protobuf代码
+	pb "grpc-basic-server/pb"
+	pbv2 "grpc-basic-server/pb/v2"
+
+	// 导入gRPC相关包
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// FuzzValidateBook 模糊测试图书验证：不能 panic，通过验证的图书必须满足所有约束
+func FuzzValidateBook(f *testing.F) {
+	// 预置语料：正常值、空字符串、极大值、无效UTF-8
+	f.Add("测试图书", "测试作者", float32(29.99), "描述", int32(2023))
+	f.Add("", "", float32(0), "", int32(0))
+	f.Add("标题", "作者", float32(-1), "", int32(-1))
+	f.Add("标题", "作者", float32(math.MaxFloat32), "", int32(math.MaxInt32))
+	f.Add("标题", "作者", float32(math.NaN()), "", int32(0))
+	f.Add("标题", "作者", float32(math.Inf(1)), "", int32(0))
+	f.Add("\xff\xfe", "作者", float32(10), "\xc3\x28", int32(2000))
+	f.Add(strings.Repeat("长", 10000), "作者", float32(maxBookPrice), "", int32(2000))
+
+	f.Fuzz(func(t *testing.T, title, author string, price float32, description string, year int32) {
+		book := &pb.Book{
+			Title:       title,
+			Author:      author,
+			Price:       proto.Float32(price),
+			Description: proto.String(description),
+			PublishYear: proto.Int32(year),
+		}
+
+		err := validateBook(book)
+		if err != nil {
+			// 失败时必须是干净的 InvalidArgument 错误
+			if status.Code(err) != codes.InvalidArgument {
+				t.Fatalf("期望错误码为InvalidArgument，实际为: %v", err)
+			}
+			return
+		}
+
+		// 通过验证的图书必须满足约束
+		if title == "" || author == "" {
+			t.Fatalf("标题或作者为空却通过了验证: %q, %q", title, author)
+		}
+		if !utf8.ValidString(title) || !utf8.ValidString(author) || !utf8.ValidString(description) {
+			t.Fatalf("无效的UTF-8通过了验证")
+		}
+		if !isFinite(price) || price <= 0 || price > maxBookPrice {
+			t.Fatalf("无效的价格通过了验证: %v", price)
+		}
+	})
+}
+
+// FuzzValidateFilter 模糊测试过滤条件验证：不能 panic，通过验证的条件必须有效
+func FuzzValidateFilter(f *testing.F) {
+	f.Add(float32(0), float32(0), "")
+	f.Add(float32(10), float32(5), "作者")
+	f.Add(float32(-1), float32(100), "")
+	f.Add(float32(math.NaN()), float32(math.Inf(1)), "\xff")
+
+	f.Fuzz(func(t *testing.T, minPrice, maxPrice float32, author string) {
+		filter := &pb.BookFilter{MinPrice: minPrice, MaxPrice: maxPrice, Author: author}
+
+		err := validateFilter(filter)
+		if err != nil {
+			if status.Code(err) != codes.InvalidArgument {
+				t.Fatalf("期望错误码为InvalidArgument，实际为: %v", err)
+			}
+			return
+		}
+
+		if !isFinite(minPrice) || !isFinite(maxPrice) || minPrice < 0 || maxPrice < 0 {
+			t.Fatalf("无效的价格通过了验证: %v - %v", minPrice, maxPrice)
+		}
+		if maxPrice > 0 && maxPrice < minPrice {
+			t.Fatalf("价格区间颠倒却通过了验证: %v - %v", minPrice, maxPrice)
+		}
+
+		// 过滤本身也不能 panic
+		matchFilter(&pb.Book{Title: "标题", Author: "作者", Price: proto.Float32(10)}, filter)
+	})
+}
+
+// TestNilBook 测试修改类方法收到空的图书信息时返回“缺少图书信息”，而不是字段为空的错误
+func TestNilBook(t *testing.T) {
+	conn, _ := startTestConn(t, mustParseConfig(t))
+	v1 := pb.NewBookServiceClient(conn)
+	v2 := pbv2.NewBookServiceV2Client(conn)
+	ctx := context.Background()
+
+	calls := map[string]func() error{
+		"CreateBook": func() error {
+			_, err := v1.CreateBook(ctx, &pb.CreateBookRequest{})
+			return err
+		},
+		"UpdateBook": func() error {
+			_, err := v1.UpdateBook(ctx, &pb.UpdateBookRequest{})
+			return err
+		},
+		"v2 CreateBook": func() error {
+			_, err := v2.CreateBook(ctx, &pbv2.CreateBookRequest{})
+			return err
+		},
+		"v2 UpdateBook": func() error {
+			_, err := v2.UpdateBook(ctx, &pbv2.UpdateBookRequest{})
+			return err
+		},
+	}
+	for name, call := range calls {
+		err := call()
+		if st := status.Convert(err); st.Code() != codes.InvalidArgument || st.Message() != "缺少图书信息" {
+			t.Errorf("%s: 期望返回缺少图书信息的InvalidArgument，实际为: %v", name, err)
+		}
+	}
+}
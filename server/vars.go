@@ -0,0 +1,76 @@
+package main
+
+import (
+	"context"
+	"expvar"
+	"fmt"
+	"net/http"
+	"sync"
+
+	// 导入gRPC相关包
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// callMetrics 通过 expvar 发布的调用统计：各方法的请求数，以及各方法按错误码划分的错误数
+type callMetrics struct {
+	requests expvar.Map
+
+	// errors 的值为按错误码计数的 *expvar.Map，mu 保证每个方法只创建一次
+	mu     sync.Mutex
+	errors expvar.Map
+}
+
+// observe 记录一次调用的结果
+func (m *callMetrics) observe(method string, err error) {
+	m.requests.Add(method, 1)
+	if err == nil {
+		return
+	}
+
+	m.mu.Lock()
+	byCode, ok := m.errors.Get(method).(*expvar.Map)
+	if !ok {
+		byCode = new(expvar.Map)
+		m.errors.Set(method, byCode)
+	}
+	m.mu.Unlock()
+	byCode.Add(status.Code(err).String(), 1)
+}
+
+// metricsInterceptor 统计一元调用的请求数和错误数
+func (s *BookServer) metricsInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	resp, err := handler(ctx, req)
+	s.calls.observe(info.FullMethod, err)
+	return resp, err
+}
+
+// metricsStreamInterceptor 统计流式调用的请求数和错误数，规则与一元方法相同
+func (s *BookServer) metricsStreamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	err := handler(srv, ss)
+	s.calls.observe(info.FullMethod, err)
+	return err
+}
+
+// expvars 返回本服务器发布的变量：图书数量、各方法请求数、各方法按错误码划分的错误数
+func (s *BookServer) expvars() *expvar.Map {
+	vars := new(expvar.Map)
+	vars.Set("book_count", expvar.Func(func() any { return s.bookCount() }))
+	vars.Set("requests_total", &s.calls.requests)
+	vars.Set("errors_total", &s.calls.errors)
+	return vars
+}
+
+// newVarsHandler 以与 expvar.Handler 相同的 JSON 格式输出全局变量（如 memstats、cmdline），
+// 并在 bookstore 键下附加本服务器的变量。变量不注册到全局，同一进程中可以有多个服务器实例
+func newVarsHandler(s *BookServer) http.Handler {
+	vars := s.expvars()
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		fmt.Fprintf(w, "{\n")
+		expvar.Do(func(kv expvar.KeyValue) {
+			fmt.Fprintf(w, "%q: %s,\n", kv.Key, kv.Value)
+		})
+		fmt.Fprintf(w, "%q: %s\n}\n", "bookstore", vars)
+	})
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	// 导入生成的protobuf代码
+	pb "grpc-basic-server/pb"
+
+	// 导入gRPC相关包
+	"google.golang.org/protobuf/proto"
+)
+
+// TestExpvarMetrics 测试 /debug/vars 输出的图书数量、请求数和按错误码划分的错误数
+func TestExpvarMetrics(t *testing.T) {
+	client, bookServer := startTestServer(t, mustParseConfig(t))
+	httpServer := httptest.NewServer(newDebugHTTPHandler(bookServer))
+	defer httpServer.Close()
+
+	for i := 0; i < 2; i++ {
+		if _, err := client.CreateBook(context.Background(), &pb.CreateBookRequest{Book: &pb.Book{Title: "图书", Author: "作者", Price: proto.Float32(10)}}); err != nil {
+			t.Fatalf("创建图书失败: %v", err)
+		}
+	}
+	if _, err := client.GetBook(context.Background(), &pb.GetBookRequest{Id: "不存在"}); err == nil {
+		t.Fatal("期望获取不存在的图书失败")
+	}
+
+	resp, err := http.Get(httpServer.URL + "/debug/vars")
+	if err != nil {
+		t.Fatalf("读取运行指标失败: %v", err)
+	}
+	defer resp.Body.Close()
+
+	var vars struct {
+		Memstats  json.RawMessage `json:"memstats"`
+		Bookstore struct {
+			BookCount     int                       `json:"book_count"`
+			RequestsTotal map[string]int            `json:"requests_total"`
+			ErrorsTotal   map[string]map[string]int `json:"errors_total"`
+		} `json:"bookstore"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&vars); err != nil {
+		t.Fatalf("解析运行指标失败: %v", err)
+	}
+	if len(vars.Memstats) == 0 {
+		t.Error("期望同时输出全局的 expvar 变量")
+	}
+	if vars.Bookstore.BookCount != 2 {
+		t.Errorf("期望图书数量为2，实际为: %d", vars.Bookstore.BookCount)
+	}
+	if n := vars.Bookstore.RequestsTotal["/bookstore.BookService/CreateBook"]; n != 2 {
+		t.Errorf("期望 CreateBook 请求数为2，实际为: %d", n)
+	}
+	if n := vars.Bookstore.ErrorsTotal["/bookstore.BookService/GetBook"]["NotFound"]; n != 1 {
+		t.Errorf("期望 GetBook 的 NotFound 错误数为1，实际为: %v", vars.Bookstore.ErrorsTotal)
+	}
+}
@@ -0,0 +1,153 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	// 导入生成的protobuf代码
+	pb "grpc-basic-server/pb"
+	pbv2 "grpc-basic-server/pb/v2"
+
+	// 导入gRPC相关包
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// writeQuotas 按租户限制写请求速率的令牌桶，由 WithWriteQuota 设置。
+// 每个租户（包括默认租户）一个令牌桶，一个租户的突发写入不会占用其他租户的配额
+type writeQuotas struct {
+	mu sync.Mutex
+
+	// 默认每秒允许的写请求数，0 表示不限制
+	rate float64
+
+	// 令牌桶容量（允许的突发请求数），0 表示与速率相同（至少为1）
+	burst int
+
+	// 单独设置了速率的租户，未列出的租户使用默认速率
+	tenants map[string]float64
+
+	// 每个租户的令牌桶，第一次写请求时创建
+	buckets map[string]*tokenBucket
+}
+
+// tokenBucket 单个租户的令牌桶
+type tokenBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// WithWriteQuota 设置每个租户每秒允许的写请求数和突发上限，tenants 中单独设置部分租户的速率。
+// 速率为 0 表示不限制；超过配额的写请求返回 ResourceExhausted
+func WithWriteQuota(rate float64, burst int, tenants map[string]float64) ServerOption {
+	return func(s *BookServer) {
+		s.writeQuotas.rate = rate
+		s.writeQuotas.burst = burst
+		s.writeQuotas.tenants = tenants
+	}
+}
+
+// allow 从租户的令牌桶中取出一个令牌，令牌不足时返回 false
+func (q *writeQuotas) allow(tenant string, now time.Time) bool {
+	rate, ok := q.tenants[tenant]
+	if !ok {
+		rate = q.rate
+	}
+	if rate <= 0 {
+		return true
+	}
+	burst := float64(q.burst)
+	if burst <= 0 {
+		burst = math.Max(1, math.Ceil(rate))
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	bucket, exists := q.buckets[tenant]
+	if !exists {
+		if q.buckets == nil {
+			q.buckets = make(map[string]*tokenBucket)
+		}
+		bucket = &tokenBucket{tokens: burst, last: now}
+		q.buckets[tenant] = bucket
+	}
+	if elapsed := now.Sub(bucket.last); elapsed > 0 {
+		bucket.tokens = math.Min(burst, bucket.tokens+elapsed.Seconds()*rate)
+		bucket.last = now
+	}
+	if bucket.tokens < 1 {
+		return false
+	}
+	bucket.tokens--
+	return true
+}
+
+// isMutatingMethod 判断是否为图书服务（v1 和 v2）的修改类方法，包括流式方法（如 ReplaceCatalog）
+func isMutatingMethod(fullMethod string) bool {
+	for _, service := range []string{pb.BookService_ServiceDesc.ServiceName, pbv2.BookServiceV2_ServiceDesc.ServiceName} {
+		method, ok := strings.CutPrefix(fullMethod, "/"+service+"/")
+		if !ok {
+			continue
+		}
+		for _, prefix := range mutatingMethodPrefixes {
+			if strings.HasPrefix(method, prefix) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// checkWriteQuota 修改类方法超过调用方租户的写配额时返回 ResourceExhausted
+func (s *BookServer) checkWriteQuota(ctx context.Context, fullMethod string) error {
+	if !isMutatingMethod(fullMethod) {
+		return nil
+	}
+	tenant := s.tenantID(ctx)
+	if !s.writeQuotas.allow(tenant, s.clock.Now()) {
+		s.logger.Warn("写请求超过租户配额", "tenant", tenant, "method", fullMethod)
+		return status.Errorf(codes.ResourceExhausted, "租户 %q 的写请求过于频繁，请稍后重试", tenant)
+	}
+	return nil
+}
+
+// writeQuotaInterceptor 按租户限制修改类方法的调用速率
+func (s *BookServer) writeQuotaInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if err := s.checkWriteQuota(ctx, info.FullMethod); err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+// writeQuotaStreamInterceptor 按租户限制修改类流式方法的调用速率，每个流计为一次写请求
+func (s *BookServer) writeQuotaStreamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if err := s.checkWriteQuota(ss.Context(), info.FullMethod); err != nil {
+		return err
+	}
+	return handler(srv, ss)
+}
+
+// parseTenantWriteRates 解析租户写速率列表，格式为 租户=每秒请求数，如 tenant-a=5,tenant-b=50
+func parseTenantWriteRates(items []string) (map[string]float64, error) {
+	rates := make(map[string]float64, len(items))
+	for _, item := range items {
+		tenant, value, ok := strings.Cut(item, "=")
+		tenant = strings.TrimSpace(tenant)
+		if !ok || tenant == "" {
+			return nil, fmt.Errorf("无效的租户写速率 %q，格式应为 租户=每秒请求数", item)
+		}
+		rate, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil || rate < 0 || math.IsInf(rate, 0) || math.IsNaN(rate) {
+			return nil, fmt.Errorf("无效的租户写速率 %q，速率应为非负数", item)
+		}
+		rates[tenant] = rate
+	}
+	return rates, nil
+}
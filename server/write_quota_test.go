@@ -0,0 +1,75 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	// 导入生成的protobuf代码
+	pb "grpc-basic-server/pb"
+
+	// 导入gRPC相关包
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// TestWriteQuotaPerTenant 测试一个租户超过写配额被限流，其他租户不受影响
+func TestWriteQuotaPerTenant(t *testing.T) {
+	clock := newFakeClock()
+	client, _ := startTestServer(t, mustParseConfig(t,
+		"-tenant-metadata", "x-tenant-id",
+		"-write-rate", "2",
+		"-tenant-write-rates", "tenant-b=100",
+	), WithClock(clock))
+
+	create := func(tenant string) error {
+		_, err := client.CreateBook(tenantContext(tenant), &pb.CreateBookRequest{
+			Book: &pb.Book{Title: "图书", Author: "作者", Price: proto.Float32(10)},
+		})
+		return err
+	}
+
+	// 租户A的令牌桶容量为2，第三次写请求被限流
+	for i := 0; i < 2; i++ {
+		if err := create("tenant-a"); err != nil {
+			t.Fatalf("租户A第%d次创建失败: %v", i+1, err)
+		}
+	}
+	if err := create("tenant-a"); status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("期望租户A超过配额返回ResourceExhausted，实际为: %v", err)
+	}
+
+	// 读请求不计入写配额
+	if _, err := client.ListBooks(tenantContext("tenant-a"), &pb.ListBooksRequest{}); err != nil {
+		t.Errorf("超过写配额后读请求不应被限流: %v", err)
+	}
+
+	// 租户B使用单独的配额，不受租户A影响
+	for i := 0; i < 10; i++ {
+		if err := create("tenant-b"); err != nil {
+			t.Fatalf("租户B第%d次创建失败: %v", i+1, err)
+		}
+	}
+
+	// 令牌按速率补充，半秒后租户A可以再写一次
+	clock.Advance(500 * time.Millisecond)
+	if err := create("tenant-a"); err != nil {
+		t.Errorf("令牌补充后租户A应可以写入: %v", err)
+	}
+	if err := create("tenant-a"); status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("期望租户A再次超过配额，实际为: %v", err)
+	}
+}
+
+// TestParseTenantWriteRates 测试租户写速率列表的解析
+func TestParseTenantWriteRates(t *testing.T) {
+	rates, err := parseTenantWriteRates([]string{"tenant-a=5", "tenant-b = 0.5"})
+	if err != nil || rates["tenant-a"] != 5 || rates["tenant-b"] != 0.5 {
+		t.Errorf("解析结果不正确: %v, %v", rates, err)
+	}
+	for _, item := range []string{"tenant-a", "=5", "tenant-a=-1", "tenant-a=abc"} {
+		if _, err := parseTenantWriteRates([]string{item}); err == nil {
+			t.Errorf("期望 %q 解析失败", item)
+		}
+	}
+}